@@ -0,0 +1,129 @@
+package approvals
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/approvals"
+	"strconv"
+)
+
+type Handler struct {
+	service approvals.ApprovalService
+}
+
+func New(service approvals.ApprovalService) *Handler {
+	return &Handler{service: service}
+}
+
+// Submit puts a draft product in the review queue.
+func (h *Handler) Submit(ctx *krogo.Context) (interface{}, error) {
+	id, user, err := productAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.Submit(ctx, id, user)
+}
+
+// GetQueue lists the products waiting for a reviewer.
+func (h *Handler) GetQueue(ctx *krogo.Context) (interface{}, error) {
+	user := ctx.Header(models.UserHeader)
+	if user == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.GetQueue(ctx, user)
+}
+
+// GetByProductID lists the reviews of a product with their comments.
+func (h *Handler) GetByProductID(ctx *krogo.Context) (interface{}, error) {
+	id, user, err := productAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.GetByProductID(ctx, id, user)
+}
+
+// Preview hands out a token for reading a product before it is published.
+func (h *Handler) Preview(ctx *krogo.Context) (interface{}, error) {
+	id, user, err := productAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.Preview(ctx, id, user)
+}
+
+// Comment adds a note to a review; the body is {"body": "..."}.
+func (h *Handler) Comment(ctx *krogo.Context) (interface{}, error) {
+	var comment models.ApprovalComment
+
+	id, user, err := approvalAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Bind(&comment); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Comment(ctx, id, user, comment.Body)
+}
+
+// Approve publishes the product of a review; the body is {"comment": "..."}, the comment being optional.
+func (h *Handler) Approve(ctx *krogo.Context) (interface{}, error) {
+	var decision models.Decision
+
+	id, user, err := approvalAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Bind(&decision); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Approve(ctx, id, user, decision.Comment)
+}
+
+// Reject sends the product of a review back to its owner; the body is {"comment": "..."} saying what to change.
+func (h *Handler) Reject(ctx *krogo.Context) (interface{}, error) {
+	var decision models.Decision
+
+	id, user, err := approvalAndUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Bind(&decision); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Reject(ctx, id, user, decision.Comment)
+}
+
+func productAndUser(ctx *krogo.Context) (id, user string, err error) {
+	if id = ctx.PathParam("id"); id == "" {
+		return "", "", errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if user = ctx.Header(models.UserHeader); user == "" {
+		return "", "", errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return id, user, nil
+}
+
+func approvalAndUser(ctx *krogo.Context) (id, user string, err error) {
+	if id, user, err = productAndUser(ctx); err != nil {
+		return "", "", err
+	}
+
+	if _, err = strconv.Atoi(id); err != nil {
+		return "", "", errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return id, user, nil
+}
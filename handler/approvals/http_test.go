@@ -0,0 +1,228 @@
+package approvals
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/approvals"
+	"testing"
+)
+
+func getContext(body, user string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, "/approvals", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, user)
+
+	ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	pending := &models.Approval{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1"}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		User           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			User:           "u1",
+			ExpectedResult: pending,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Submit(gomock.Any(), "1", "u1").Return(pending, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Submit(getContext("", test.User, map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	queue := []models.Approval{{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1"}}
+
+	mockService.EXPECT().GetQueue(gomock.Any(), "r1").Return(queue, nil)
+
+	res, err := mockHandler.GetQueue(getContext("", "r1", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, queue, res)
+
+	_, err = mockHandler.GetQueue(getContext("", "", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"X-User-ID"}}, err)
+}
+
+func TestHandler_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	history := []models.Approval{{ID: 1, ProductID: "1", State: "rejected", SubmittedBy: "u1", Reviewer: "r1"}}
+
+	mockService.EXPECT().GetByProductID(gomock.Any(), "1", "u1").Return(history, nil)
+
+	res, err := mockHandler.GetByProductID(getContext("", "u1", map[string]string{"id": "1"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, history, res)
+}
+
+func TestHandler_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	token := &models.PreviewToken{Token: "abc", ProductID: "1", CreatedBy: "u1"}
+
+	mockService.EXPECT().Preview(gomock.Any(), "1", "u1").Return(token, nil)
+
+	res, err := mockHandler.Preview(getContext("", "u1", map[string]string{"id": "1"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, token, res)
+
+	_, err = mockHandler.Preview(getContext("", "", map[string]string{"id": "1"}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"X-User-ID"}}, err)
+}
+
+func TestHandler_Comment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	comment := &models.ApprovalComment{ID: 1, ApprovalID: 1, Author: "r1", Body: "needs a photo"}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			Body:           `{"body":"needs a photo"}`,
+			ExpectedResult: comment,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Comment(gomock.Any(), "1", "r1", "needs a photo").Return(comment, nil),
+			},
+		},
+		{
+			Desc:        "Failure: id not a number",
+			ID:          "one",
+			Body:        `{"body":"needs a photo"}`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			ID:          "1",
+			Body:        `["needs a photo"]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Comment(getContext(test.Body, "r1", map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Approve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	approved := &models.Approval{ID: 1, ProductID: "1", State: "approved", SubmittedBy: "u1", Reviewer: "r1"}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "r1",
+			Body:           `{}`,
+			ExpectedResult: approved,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Approve(gomock.Any(), "1", "r1", "").Return(approved, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing user",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+		{
+			Desc:        "bind error",
+			User:        "r1",
+			Body:        `"ok"`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Approve(getContext(test.Body, test.User, map[string]string{"id": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := approvals.NewMockApprovalService(ctrl)
+	mockHandler := New(mockService)
+
+	rejected := &models.Approval{ID: 1, ProductID: "1", State: "rejected", SubmittedBy: "u1", Reviewer: "r1"}
+
+	mockService.EXPECT().Reject(gomock.Any(), "1", "r1", "needs a photo").Return(rejected, nil)
+
+	res, err := mockHandler.Reject(getContext(`{"comment":"needs a photo"}`, "r1", map[string]string{"id": "1"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, rejected, res)
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/audit"
+	"strconv"
+	"time"
+)
+
+// maxLimit caps how many audit entries are listed at once; older ones are reached by narrowing to=.
+const maxLimit = 1000
+
+var (
+	entities = map[string]bool{"products": true, "variants": true, "tags": true, "categories": true, "media": true, "translations": true,
+		"relationships": true, "bundles": true, "options": true, "inventory": true, "stock": true}
+	actions = map[string]bool{models.AuditCreate: true, models.AuditUpdate: true, models.AuditStatus: true, models.AuditRevert: true,
+		models.AuditDelete: true}
+)
+
+type Handler struct {
+	service audit.AuditService
+}
+
+func New(service audit.AuditService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetAll lists audit entries, newest first. They can be filtered by entity, entity_id, product_id, actor, action and
+// request_id, and by time with from= and to= in RFC 3339; limit= sets how many are listed.
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	if entity := ctx.Param("entity"); entity != "" && !entities[entity] {
+		return nil, errors.InvalidParam{Param: []string{"entity"}}
+	}
+
+	if action := ctx.Param("action"); action != "" && !actions[action] {
+		return nil, errors.InvalidParam{Param: []string{"action"}}
+	}
+
+	for _, param := range []string{"from", "to"} {
+		if value := ctx.Param(param); value != "" {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return nil, errors.InvalidParam{Param: []string{param}}
+			}
+		}
+	}
+
+	if limit := ctx.Param("limit"); limit != "" {
+		if n, err := strconv.Atoi(limit); err != nil || n < 1 || n > maxLimit {
+			return nil, errors.InvalidParam{Param: []string{"limit"}}
+		}
+	}
+
+	return h.service.GetAll(ctx)
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/audit"
+	"testing"
+)
+
+func getContext(target string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestHandler_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := audit.NewMockAuditService(ctrl)
+	mockHandler := New(mockService)
+
+	entries := []models.AuditEntry{{ID: 1, Action: models.AuditCreate, Entity: "products", EntityID: "1", ProductID: "1", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Target:         "/audit?entity=products&action=create&actor=u1&from=2023-05-01T00:00:00Z&to=2023-06-01T00:00:00Z&limit=10",
+			ExpectedResult: entries,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().GetAll(gomock.Any()).Return(entries, nil),
+			},
+		},
+		{
+			Desc:           "Success: deleted tags",
+			Target:         "/audit?entity=tags&action=delete",
+			ExpectedResult: entries,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().GetAll(gomock.Any()).Return(entries, nil),
+			},
+		},
+		{
+			Desc:        "Failure: invalid entity",
+			Target:      "/audit?entity=brands",
+			ExpectedErr: errors.InvalidParam{Param: []string{"entity"}},
+		},
+		{
+			Desc:        "Failure: invalid action",
+			Target:      "/audit?action=purge",
+			ExpectedErr: errors.InvalidParam{Param: []string{"action"}},
+		},
+		{
+			Desc:        "Failure: invalid from",
+			Target:      "/audit?from=yesterday",
+			ExpectedErr: errors.InvalidParam{Param: []string{"from"}},
+		},
+		{
+			Desc:        "Failure: invalid to",
+			Target:      "/audit?to=2023-06-01",
+			ExpectedErr: errors.InvalidParam{Param: []string{"to"}},
+		},
+		{
+			Desc:        "Failure: limit out of range",
+			Target:      "/audit?limit=5000",
+			ExpectedErr: errors.InvalidParam{Param: []string{"limit"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetAll(getContext(test.Target))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,90 @@
+package brands
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/brands"
+)
+
+type Handler struct {
+	service brands.BrandService
+}
+
+func New(service brands.BrandService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetByID(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetByID(ctx, id)
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var brand *models.Brand
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&brand); err != nil || brand == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Create(ctx, brand)
+}
+
+func (h *Handler) Update(ctx *krogo.Context) (interface{}, error) {
+	var brand *models.Brand
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&brand); err != nil || brand == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	brand.ID = id
+
+	return h.service.Update(ctx, brand)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, id)
+}
+
+func (h *Handler) GetProducts(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetProducts(ctx, id)
+}
@@ -0,0 +1,134 @@
+package brands
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/brands"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, "/brands", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandService := brands.NewMockBrandService(ctrl)
+	mockHandler := New(mockBrandService)
+
+	mockBrandService.EXPECT().GetByID(gomock.Any(), "kroger").Return(&models.Brand{ID: "kroger", Name: "Kroger"}, nil)
+
+	res, err := mockHandler.GetByID(getContext("", map[string]string{"id": "kroger"}))
+	assert.Equal(t, &models.Brand{ID: "kroger", Name: "Kroger"}, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.GetByID(getContext("", nil))
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_CreateAndUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandService := brands.NewMockBrandService(ctrl)
+	mockHandler := New(mockBrandService)
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: create",
+			Handle:         mockHandler.Create,
+			Body:           `{"name":"Kroger"}`,
+			ExpectedResult: &models.Brand{ID: "kroger", Name: "Kroger"},
+			Calls: []*gomock.Call{
+				mockBrandService.EXPECT().Create(gomock.Any(), &models.Brand{Name: "Kroger"}).
+					Return(&models.Brand{ID: "kroger", Name: "Kroger"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: rename",
+			Handle:         mockHandler.Update,
+			PathParams:     map[string]string{"id": "kroger"},
+			Body:           `{"name":"Kroger Co"}`,
+			ExpectedResult: &models.Brand{ID: "kroger", Name: "Kroger Co"},
+			Calls: []*gomock.Call{
+				mockBrandService.EXPECT().Update(gomock.Any(), &models.Brand{ID: "kroger", Name: "Kroger Co"}).
+					Return(&models.Brand{ID: "kroger", Name: "Kroger Co"}, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.Create,
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_DeleteAndGetProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandService := brands.NewMockBrandService(ctrl)
+	mockHandler := New(mockBrandService)
+
+	mockBrandService.EXPECT().Delete(gomock.Any(), "kroger").Return(nil)
+	mockBrandService.EXPECT().GetProducts(gomock.Any(), "kroger").Return([]models.ProductWithVariants{{ID: "1"}}, nil)
+
+	res, err := mockHandler.Delete(getContext("", map[string]string{"id": "kroger"}))
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	res, err = mockHandler.GetProducts(getContext("", map[string]string{"id": "kroger"}))
+	assert.Equal(t, []models.ProductWithVariants{{ID: "1"}}, res)
+	assert.NoError(t, err)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(brands.NewMockBrandService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "Update", Handle: mockHandler.Update},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
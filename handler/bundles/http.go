@@ -0,0 +1,47 @@
+package bundles
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/bundles"
+)
+
+type Handler struct {
+	service bundles.BundleService
+}
+
+func New(service bundles.BundleService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.Get(ctx, id)
+}
+
+// Set replaces the components and pricing of the bundle product of the path.
+func (h *Handler) Set(ctx *krogo.Context) (interface{}, error) {
+	var body *models.Bundle
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Set(ctx, id, body)
+}
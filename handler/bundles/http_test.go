@@ -0,0 +1,114 @@
+package bundles
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/bundles"
+	"testing"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := bundles.NewMockBundleService(ctrl)
+	mockHandler := New(mockService)
+
+	bundle := &models.Bundle{Pricing: "fixed", PriceCents: 4500, Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 1}}}
+
+	mockService.EXPECT().Get(gomock.Any(), "set").Return(bundle, nil)
+
+	res, err := mockHandler.Get(getContext("/products/set/bundle", "", map[string]string{"id": "set"}))
+
+	assert.Equal(t, bundle, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Get(getContext("/products//bundle", "", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := bundles.NewMockBundleService(ctrl)
+	mockHandler := New(mockService)
+
+	body := &models.Bundle{Pricing: "sum", DiscountCents: 500, Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 2}}}
+	result := &models.Bundle{Pricing: "sum", PriceCents: 5498, DiscountCents: 500, Available: 2,
+		Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 2, PriceCents: 2999, Available: 4}}}
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "set"},
+			Body:           `{"pricing":"sum","discount_cents":500,"components":[{"variant_id":"k-1","quantity":2}]}`,
+			ExpectedResult: result,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Set(gomock.Any(), "set", body).Return(result, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Body:        `{"pricing":"fixed"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			PathParams:  map[string]string{"id": "set"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Set(getContext("/products/set/bundle", test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(bundles.NewMockBundleService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Set", Handle: mockHandler.Set},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,140 @@
+package categories
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/categories"
+)
+
+type Handler struct {
+	service categories.CategoryService
+}
+
+func New(service categories.CategoryService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetByID(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetByID(ctx, id)
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var category *models.Category
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&category); err != nil || category == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Create(ctx, category)
+}
+
+func (h *Handler) Update(ctx *krogo.Context) (interface{}, error) {
+	var category *models.Category
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&category); err != nil || category == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	category.ID = id
+
+	return h.service.Update(ctx, category)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, id)
+}
+
+func (h *Handler) GetProductCategories(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetProductCategories(ctx, id)
+}
+
+func (h *Handler) SetProductCategories(ctx *krogo.Context) (interface{}, error) {
+	var body *models.ProductCategories
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetProductCategories(ctx, id, body.CategoryIDs)
+}
+
+func (h *Handler) GetAttributes(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetAttributes(ctx, id)
+}
+
+func (h *Handler) SetAttributes(ctx *krogo.Context) (interface{}, error) {
+	var body *models.CategoryAttributes
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetAttributes(ctx, id, body.Attributes)
+}
@@ -0,0 +1,258 @@
+package categories
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/categories"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, "/categories", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryService := categories.NewMockCategoryService(ctrl)
+	mockHandler := New(mockCategoryService)
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "dairy",
+			ExpectedResult: &models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().GetByID(gomock.Any(), "dairy").Return(&models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetByID(getContext("", map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_CreateAndUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryService := categories.NewMockCategoryService(ctrl)
+	mockHandler := New(mockCategoryService)
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: create",
+			Handle:         mockHandler.Create,
+			Body:           `{"id":"dairy","name":"Dairy"}`,
+			ExpectedResult: &models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().Create(gomock.Any(), &models.Category{ID: "dairy", Name: "Dairy"}).
+					Return(&models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: update",
+			Handle:         mockHandler.Update,
+			PathParams:     map[string]string{"id": "milk"},
+			Body:           `{"name":"Milk","parent_id":"dairy"}`,
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().Update(gomock.Any(), &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy"}).
+					Return(&models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: update missing id",
+			Handle:      mockHandler.Update,
+			Body:        `{"name":"Milk"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.Create,
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryService := categories.NewMockCategoryService(ctrl)
+	mockHandler := New(mockCategoryService)
+
+	mockCategoryService.EXPECT().Delete(gomock.Any(), "milk").Return(nil)
+
+	res, err := mockHandler.Delete(getContext("", map[string]string{"id": "milk"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Delete(getContext("", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_SetProductCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryService := categories.NewMockCategoryService(ctrl)
+	mockHandler := New(mockCategoryService)
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"category_ids":["milk"]}`,
+			ExpectedResult: []models.Category{{ID: "milk", Path: "dairy/milk/"}},
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().SetProductCategories(gomock.Any(), "1", []string{"milk"}).
+					Return([]models.Category{{ID: "milk", Path: "dairy/milk/"}}, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.SetProductCategories(getContext(test.Body, map[string]string{"id": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Attributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryService := categories.NewMockCategoryService(ctrl)
+	mockHandler := New(mockCategoryService)
+
+	schema := []models.AttributeDefinition{{CategoryID: "kettles", Name: "wattage", Type: "integer", Unit: "W"}}
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: get",
+			Handle:         mockHandler.GetAttributes,
+			PathParams:     map[string]string{"id": "kettles"},
+			ExpectedResult: schema,
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().GetAttributes(gomock.Any(), "kettles").Return(schema, nil),
+			},
+		},
+		{
+			Desc:           "Success: set",
+			Handle:         mockHandler.SetAttributes,
+			PathParams:     map[string]string{"id": "kettles"},
+			Body:           `{"attributes":[{"name":"wattage","type":"integer","unit":"W"}]}`,
+			ExpectedResult: schema,
+			Calls: []*gomock.Call{
+				mockCategoryService.EXPECT().SetAttributes(gomock.Any(), "kettles",
+					[]models.AttributeDefinition{{Name: "wattage", Type: "integer", Unit: "W"}}).Return(schema, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Handle:      mockHandler.GetAttributes,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.SetAttributes,
+			PathParams:  map[string]string{"id": "kettles"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(categories.NewMockCategoryService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "Update", Handle: mockHandler.Update},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+		{Desc: "SetProductCategories", Handle: mockHandler.SetProductCategories},
+		{Desc: "SetAttributes", Handle: mockHandler.SetAttributes},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,68 @@
+package currencies
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/currencies"
+)
+
+type Handler struct {
+	service currencies.CurrencyService
+}
+
+func New(service currencies.CurrencyService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+func (h *Handler) GetByCode(ctx *krogo.Context) (interface{}, error) {
+	code := ctx.PathParam("code")
+
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	return h.service.GetByCode(ctx, code)
+}
+
+// Set adds or replaces the rate of the currency in the path; the body is
+// {"rate": 1.35, "rounding": "half_up", "increment": 1}.
+func (h *Handler) Set(ctx *krogo.Context) (interface{}, error) {
+	var rate *models.CurrencyRate
+
+	code := ctx.PathParam("code")
+
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&rate); err != nil || rate == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	rate.Currency = code
+
+	return h.service.Set(ctx, rate)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	code := ctx.PathParam("code")
+
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, code)
+}
@@ -0,0 +1,175 @@
+package currencies
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/currencies"
+	"testing"
+	"time"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+var updated = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestHandler_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := currencies.NewMockCurrencyService(ctrl)
+	mockHandler := New(mockService)
+
+	list := []models.CurrencyRate{{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}}
+
+	mockService.EXPECT().GetAll(gomock.Any()).Return(list, nil)
+
+	res, err := mockHandler.GetAll(getContext("/currencies", "", nil))
+
+	assert.Equal(t, list, res)
+	assert.NoError(t, err)
+}
+
+func TestHandler_GetByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := currencies.NewMockCurrencyService(ctrl)
+	mockHandler := New(mockService)
+
+	rate := &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Code:           "CAD",
+			ExpectedResult: rate,
+			Calls:          []*gomock.Call{mockService.EXPECT().GetByCode(gomock.Any(), "CAD").Return(rate, nil)},
+		},
+		{
+			Desc:        "Failure: missing code",
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetByCode(getContext("/currencies/"+test.Code, "", map[string]string{"code": test.Code}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := currencies.NewMockCurrencyService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Code:           "MXN",
+			Body:           `{"currency":"EUR","rate":17.0512,"rounding":"up","increment":5}`,
+			ExpectedResult: &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5, UpdatedAt: updated},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Set(gomock.Any(), &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5}).
+					Return(&models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5, UpdatedAt: updated}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: bind error",
+			Code:        "MXN",
+			Body:        `[]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing code",
+			Body:        `{"rate":17.0512}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Set(getContext("/currencies/"+test.Code, test.Body, map[string]string{"code": test.Code}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := currencies.NewMockCurrencyService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc        string
+		Code        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc:  "Success",
+			Code:  "CAD",
+			Calls: []*gomock.Call{mockService.EXPECT().Delete(gomock.Any(), "CAD").Return(nil)},
+		},
+		{
+			Desc:        "Failure: missing code",
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Delete(getContext("/currencies/"+test.Code, "", map[string]string{"code": test.Code}))
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(currencies.NewMockCurrencyService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Set", Handle: mockHandler.Set},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,113 @@
+package inventory
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/inventory"
+)
+
+type Handler struct {
+	service inventory.InventoryService
+}
+
+func New(service inventory.InventoryService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(ctx *krogo.Context) (interface{}, error) {
+	id, pID, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.Get(ctx, id, pID)
+}
+
+func (h *Handler) Update(ctx *krogo.Context) (interface{}, error) {
+	var inv *models.Inventory
+
+	id, pID, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err = ctx.Bind(&inv); err != nil || inv == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	inv.VariantID = id
+
+	return h.service.Update(ctx, pID, inv)
+}
+
+func (h *Handler) Reserve(ctx *krogo.Context) (interface{}, error) {
+	id, pID, adjustment, err := adjustmentParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.Reserve(ctx, id, pID, adjustment.Quantity)
+}
+
+func (h *Handler) Release(ctx *krogo.Context) (interface{}, error) {
+	id, pID, adjustment, err := adjustmentParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.Release(ctx, id, pID, adjustment.Quantity)
+}
+
+func (h *Handler) Commit(ctx *krogo.Context) (interface{}, error) {
+	id, pID, adjustment, err := adjustmentParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.Commit(ctx, id, pID, adjustment.Location, adjustment.Quantity)
+}
+
+func pathParams(ctx *krogo.Context) (id, pID string, err error) {
+	id = ctx.PathParam("id")
+	pID = ctx.PathParam("pid")
+
+	if id == "" {
+		return "", "", errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return "", "", errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	return id, pID, nil
+}
+
+func adjustmentParams(ctx *krogo.Context) (id, pID string, adjustment *models.StockAdjustment, err error) {
+	id, pID, err = pathParams(ctx)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if err = ctx.Bind(&adjustment); err != nil || adjustment == nil {
+		return "", "", nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return id, pID, adjustment, nil
+}
@@ -0,0 +1,191 @@
+package inventory
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/inventory"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, "/products/{pid}/variant/{id}/inventory", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryService := inventory.NewMockInventoryService(ctrl)
+	mockHandler := New(mockInventoryService)
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "1", "pid": "1"},
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 3, Available: 3},
+			Calls: []*gomock.Call{
+				mockInventoryService.EXPECT().Get(gomock.Any(), "1", "1").
+					Return(&models.Inventory{VariantID: "1", OnHand: 3, Available: 3}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing variant id",
+			PathParams:  map[string]string{"pid": "1"},
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: missing product id",
+			PathParams:  map[string]string{"id": "1"},
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Get(getContext("", test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryService := inventory.NewMockInventoryService(ctrl)
+	mockHandler := New(mockInventoryService)
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"on_hand":7}`,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 7, Available: 7},
+			Calls: []*gomock.Call{
+				mockInventoryService.EXPECT().Update(gomock.Any(), "1", &models.Inventory{VariantID: "1", OnHand: 7}).
+					Return(&models.Inventory{VariantID: "1", OnHand: 7, Available: 7}, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Update(getContext(test.Body, map[string]string{"id": "1", "pid": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Adjust(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryService := inventory.NewMockInventoryService(ctrl)
+	mockHandler := New(mockInventoryService)
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: reserve",
+			Handle:         mockHandler.Reserve,
+			Body:           `{"quantity":2}`,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Reserved: 2, Available: 3},
+			Calls: []*gomock.Call{
+				mockInventoryService.EXPECT().Reserve(gomock.Any(), "1", "1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Reserved: 2, Available: 3}, nil),
+			},
+		},
+		{
+			Desc:           "Success: release",
+			Handle:         mockHandler.Release,
+			Body:           `{"quantity":2}`,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Available: 5},
+			Calls: []*gomock.Call{
+				mockInventoryService.EXPECT().Release(gomock.Any(), "1", "1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Available: 5}, nil),
+			},
+		},
+		{
+			Desc:           "Success: commit",
+			Handle:         mockHandler.Commit,
+			Body:           `{"quantity":2,"location":"CIN1"}`,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 3, Available: 3},
+			Calls: []*gomock.Call{
+				mockInventoryService.EXPECT().Commit(gomock.Any(), "1", "1", "CIN1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 3, Available: 3}, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.Reserve,
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, map[string]string{"id": "1", "pid": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(inventory.NewMockInventoryService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Update", Handle: mockHandler.Update},
+		{Desc: "Reserve", Handle: mockHandler.Reserve},
+		{Desc: "Release", Handle: mockHandler.Release},
+		{Desc: "Commit", Handle: mockHandler.Commit},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
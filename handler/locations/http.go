@@ -0,0 +1,109 @@
+package locations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/locations"
+)
+
+type Handler struct {
+	service locations.LocationService
+}
+
+func New(service locations.LocationService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetByCode(ctx *krogo.Context) (interface{}, error) {
+	code := ctx.PathParam("code")
+
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	return h.service.GetByCode(ctx, code)
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var location *models.Location
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&location); err != nil || location == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Create(ctx, location)
+}
+
+func (h *Handler) SetStock(ctx *krogo.Context) (interface{}, error) {
+	var stock *models.LocationStock
+
+	id, pID, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code := ctx.PathParam("code")
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err = ctx.Bind(&stock); err != nil || stock == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	stock.VariantID = id
+	stock.Code = code
+
+	return h.service.SetStock(ctx, pID, stock)
+}
+
+func (h *Handler) GetAvailability(ctx *krogo.Context) (interface{}, error) {
+	id, pID, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.GetAvailability(ctx, id, pID, ctx.Param("location"))
+}
+
+func (h *Handler) GetNearest(ctx *krogo.Context) (interface{}, error) {
+	id, pID, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code := ctx.Param("location")
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"location"}}
+	}
+
+	return h.service.GetNearest(ctx, id, pID, code)
+}
+
+func pathParams(ctx *krogo.Context) (id, pID string, err error) {
+	id = ctx.PathParam("id")
+	pID = ctx.PathParam("pid")
+
+	if id == "" {
+		return "", "", errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return "", "", errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	return id, pID, nil
+}
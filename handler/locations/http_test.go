@@ -0,0 +1,246 @@
+package locations
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/locations"
+	"testing"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationService := locations.NewMockLocationService(ctrl)
+	mockHandler := New(mockLocationService)
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Code:           "CIN1",
+			ExpectedResult: &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"},
+			Calls: []*gomock.Call{
+				mockLocationService.EXPECT().GetByCode(gomock.Any(), "CIN1").
+					Return(&models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing code",
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetByCode(getContext("/locations/"+test.Code, "", map[string]string{"code": test.Code}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationService := locations.NewMockLocationService(ctrl)
+	mockHandler := New(mockLocationService)
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"code":"CIN1","name":"Cincinnati","type":"store"}`,
+			ExpectedResult: &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"},
+			Calls: []*gomock.Call{
+				mockLocationService.EXPECT().Create(gomock.Any(), &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}).
+					Return(&models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Create(getContext("/locations", test.Body, nil))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationService := locations.NewMockLocationService(ctrl)
+	mockHandler := New(mockLocationService)
+
+	stock := &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 3}
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "1", "pid": "1", "code": "CIN1"},
+			Body:           `{"on_hand":3}`,
+			ExpectedResult: stock,
+			Calls: []*gomock.Call{
+				mockLocationService.EXPECT().SetStock(gomock.Any(), "1", stock).Return(stock, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing code",
+			PathParams:  map[string]string{"id": "1", "pid": "1"},
+			Body:        `{"on_hand":3}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "bind error",
+			PathParams:  map[string]string{"id": "1", "pid": "1", "code": "CIN1"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.SetStock(getContext("/products/1/variant/1/availability/CIN1", test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationService := locations.NewMockLocationService(ctrl)
+	mockHandler := New(mockLocationService)
+
+	stocks := []models.LocationStock{{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 3}}
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "1", "pid": "1"},
+			ExpectedResult: stocks,
+			Calls: []*gomock.Call{
+				mockLocationService.EXPECT().GetAvailability(gomock.Any(), "1", "1", "CIN1").Return(stocks, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing product id",
+			PathParams:  map[string]string{"id": "1"},
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetAvailability(getContext("/products/1/variant/1/availability?location=CIN1", "", test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetNearest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationService := locations.NewMockLocationService(ctrl)
+	mockHandler := New(mockLocationService)
+
+	stock := &models.LocationStock{Location: models.Location{Code: "DAY1"}, VariantID: "1", OnHand: 3}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Target:         "/products/1/variant/1/availability/nearest?location=CIN1",
+			ExpectedResult: stock,
+			Calls: []*gomock.Call{
+				mockLocationService.EXPECT().GetNearest(gomock.Any(), "1", "1", "CIN1").Return(stock, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing location",
+			Target:      "/products/1/variant/1/availability/nearest",
+			ExpectedErr: errors.MissingParam{Param: []string{"location"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetNearest(getContext(test.Target, "", map[string]string{"id": "1", "pid": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(locations.NewMockLocationService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "SetStock", Handle: mockHandler.SetStock},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,171 @@
+package media
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/types"
+	"io"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/media"
+	"strconv"
+)
+
+// maxUploadSize is the largest image file accepted by Upload.
+const maxUploadSize = 10 << 20
+
+type Handler struct {
+	service media.MediaService
+}
+
+func New(service media.MediaService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetByProductID(ctx *krogo.Context) (interface{}, error) {
+	pID := ctx.PathParam("pid")
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	return h.service.GetByProductID(ctx, pID)
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var m *models.Media
+
+	pID := ctx.PathParam("pid")
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&m); err != nil || m == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	m.ProductID = pID
+
+	return h.service.Create(ctx, m)
+}
+
+// Upload takes a multipart form with the image in "file" and the optional fields "role", "alt_text" and
+// "variant_id". The role defaults to lifestyle.
+func (h *Handler) Upload(ctx *krogo.Context) (interface{}, error) {
+	pID := ctx.PathParam("pid")
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	r := ctx.Request()
+	// leave room for the other form fields on top of the file itself
+	r.Body = http.MaxBytesReader(nil, r.Body, maxUploadSize+1<<20)
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"file"}}
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.MissingParam{Param: []string{"file"}}
+	}
+
+	defer file.Close()
+
+	if header.Size > maxUploadSize {
+		return nil, errors.InvalidParam{Param: []string{"file"}}
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{"file"}}
+	}
+
+	m := &models.Media{
+		ProductID: pID,
+		VariantID: r.FormValue("variant_id"),
+		AltText:   r.FormValue("alt_text"),
+		Role:      r.FormValue("role"),
+	}
+
+	if m.Role == "" {
+		m.Role = "lifestyle"
+	}
+
+	return h.service.Upload(ctx, m, data)
+}
+
+func (h *Handler) GetFile(ctx *krogo.Context) (interface{}, error) {
+	key := ctx.PathParam("key")
+
+	if key == "" {
+		return nil, errors.MissingParam{Param: []string{"key"}}
+	}
+
+	data, contentType, err := h.service.GetFile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.File{Content: data, ContentType: contentType}, nil
+}
+
+func (h *Handler) Update(ctx *krogo.Context) (interface{}, error) {
+	var m *models.Media
+
+	pID, id, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err = ctx.Bind(&m); err != nil || m == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	m.ProductID = pID
+	m.ID = id
+
+	return h.service.Update(ctx, m)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, pID, id)
+}
+
+func pathParams(ctx *krogo.Context) (pID string, id int, err error) {
+	pID = ctx.PathParam("pid")
+
+	if pID == "" {
+		return "", 0, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	id, err = strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return "", 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return pID, id, nil
+}
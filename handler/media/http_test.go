@@ -0,0 +1,240 @@
+package media
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/krogertechnology/krogo/pkg/krogo/types"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/media"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, "/products", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaService := media.NewMockMediaService(ctrl)
+	mockHandler := New(mockMediaService)
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		PID            string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"url":"url","role":"lifestyle","width":800,"height":600}`,
+			PID:            "1",
+			ExpectedResult: &models.Media{ID: 1, ProductID: "1", URL: "url", Role: "lifestyle", Width: 800, Height: 600},
+			Calls: []*gomock.Call{
+				mockMediaService.EXPECT().Create(gomock.Any(), &models.Media{ProductID: "1", URL: "url", Role: "lifestyle", Width: 800, Height: 600}).
+					Return(&models.Media{ID: 1, ProductID: "1", URL: "url", Role: "lifestyle", Width: 800, Height: 600}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			PID:         "1",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Create(getContext(test.Body, map[string]string{"pid": test.PID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaService := media.NewMockMediaService(ctrl)
+	mockHandler := New(mockMediaService)
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ID             string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"url":"url","role":"swatch","position":2}`,
+			ID:             "4",
+			ExpectedResult: &models.Media{ID: 4, ProductID: "1", URL: "url", Role: "swatch", Position: 2},
+			Calls: []*gomock.Call{
+				mockMediaService.EXPECT().Update(gomock.Any(), &models.Media{ID: 4, ProductID: "1", URL: "url", Role: "swatch", Position: 2}).
+					Return(&models.Media{ID: 4, ProductID: "1", URL: "url", Role: "swatch", Position: 2}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: invalid id",
+			Body:        `{}`,
+			ID:          "a",
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Update(getContext(test.Body, map[string]string{"pid": "1", "id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaService := media.NewMockMediaService(ctrl)
+	mockHandler := New(mockMediaService)
+
+	mockMediaService.EXPECT().Delete(gomock.Any(), "1", 4).Return(nil)
+
+	res, err := mockHandler.Delete(getContext("", map[string]string{"pid": "1", "id": "4"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+}
+
+func getUploadContext(t *testing.T, fields map[string]string, file []byte) *krogo.Context {
+	var body bytes.Buffer
+
+	w := multipart.NewWriter(&body)
+
+	for k, v := range fields {
+		_ = w.WriteField(k, v)
+	}
+
+	if file != nil {
+		part, err := w.CreateFormFile("file", "photo.jpg")
+		if err != nil {
+			t.Fatalf("error while building form %v", err)
+		}
+
+		_, _ = part.Write(file)
+	}
+
+	_ = w.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/products/1/media/upload", &body)
+	r.Header.Set(models.UserHeader, "u1")
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	ctx.SetPathParams(map[string]string{"pid": "1"})
+
+	return ctx
+}
+
+func TestHandler_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaService := media.NewMockMediaService(ctrl)
+	mockHandler := New(mockMediaService)
+
+	testcases := []struct {
+		Desc           string
+		Fields         map[string]string
+		File           []byte
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: default role",
+			Fields:         map[string]string{"alt_text": "on the table"},
+			File:           []byte("image"),
+			ExpectedResult: &models.Media{ID: 1, ProductID: "1", AltText: "on the table", Role: "lifestyle"},
+			Calls: []*gomock.Call{
+				mockMediaService.EXPECT().Upload(gomock.Any(), &models.Media{ProductID: "1", AltText: "on the table", Role: "lifestyle"}, []byte("image")).
+					Return(&models.Media{ID: 1, ProductID: "1", AltText: "on the table", Role: "lifestyle"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: variant swatch",
+			Fields:         map[string]string{"role": "swatch", "variant_id": "1-red"},
+			File:           []byte("image"),
+			ExpectedResult: &models.Media{ID: 2, ProductID: "1", VariantID: "1-red", Role: "swatch"},
+			Calls: []*gomock.Call{
+				mockMediaService.EXPECT().Upload(gomock.Any(), &models.Media{ProductID: "1", VariantID: "1-red", Role: "swatch"}, []byte("image")).
+					Return(&models.Media{ID: 2, ProductID: "1", VariantID: "1-red", Role: "swatch"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no file",
+			Fields:      map[string]string{"role": "swatch"},
+			ExpectedErr: errors.MissingParam{Param: []string{"file"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Upload(getUploadContext(t, test.Fields, test.File))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaService := media.NewMockMediaService(ctrl)
+	mockHandler := New(mockMediaService)
+
+	mockMediaService.EXPECT().GetFile(gomock.Any(), "ab12.jpg").Return([]byte("image"), "image/jpeg", nil)
+
+	res, err := mockHandler.GetFile(getContext("", map[string]string{"key": "ab12.jpg"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.File{Content: []byte("image"), ContentType: "image/jpeg"}, res)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(media.NewMockMediaService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "Upload", Handle: mockHandler.Upload},
+		{Desc: "Update", Handle: mockHandler.Update},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
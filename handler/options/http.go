@@ -0,0 +1,60 @@
+package options
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/options"
+)
+
+type Handler struct {
+	service options.OptionService
+}
+
+func New(service options.OptionService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.Get(ctx, id)
+}
+
+func (h *Handler) Set(ctx *krogo.Context) (interface{}, error) {
+	var body *models.ProductOptions
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Set(ctx, id, body.Options)
+}
+
+func (h *Handler) GenerateVariants(ctx *krogo.Context) (interface{}, error) {
+	pID := ctx.PathParam("pid")
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.GenerateVariants(ctx, pID)
+}
@@ -0,0 +1,130 @@
+package options
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/options"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, "/products", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOptionService := options.NewMockOptionService(ctrl)
+	mockHandler := New(mockOptionService)
+
+	axes := []models.OptionAxis{{Name: "Size", Values: []string{"S", "M"}}}
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ID             string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"options":[{"name":"Size","values":["S","M"]}]}`,
+			ID:             "1",
+			ExpectedResult: axes,
+			Calls: []*gomock.Call{
+				mockOptionService.EXPECT().Set(gomock.Any(), "1", axes).Return(axes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Body:        `{"options":[]}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ID:          "1",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Set(getContext(test.Body, map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GenerateVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOptionService := options.NewMockOptionService(ctrl)
+	mockHandler := New(mockOptionService)
+
+	generated := []models.Variant{{ID: "1-s", ProductID: "1", Name: "S", Details: "Size: S", Options: map[string]string{"Size": "S"}}}
+
+	testcases := []struct {
+		Desc           string
+		PID            string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PID:            "1",
+			ExpectedResult: generated,
+			Calls: []*gomock.Call{
+				mockOptionService.EXPECT().GenerateVariants(gomock.Any(), "1").Return(generated, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GenerateVariants(getContext("", map[string]string{"pid": test.PID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(options.NewMockOptionService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Set", Handle: mockHandler.Set},
+		{Desc: "GenerateVariants", Handle: mockHandler.GenerateVariants},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -4,11 +4,24 @@ import (
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
 	"practice-app/models"
+	"practice-app/nutrition"
 	"practice-app/service/products"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// includes lists the optional sections GET /products/{id} can add to a product with the include parameter.
+var includes = map[string]bool{"related": true}
+
+// statuses lists the lifecycle statuses GET /products can filter on with status=. Products that were never approved
+// are not listed; their owners and reviewers read them with a preview token.
+var statuses = map[string]bool{models.StatusActive: true, models.StatusDiscontinued: true, models.StatusArchived: true}
+
+// currencyPattern matches the currency codes prices can be converted into with currency=.
+var currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
 type Handler struct {
 	service products.ProductService
 }
@@ -24,6 +37,25 @@ func (h *Handler) GetByID(ctx *krogo.Context) (interface{}, error) {
 		return nil, errors.MissingParam{Param: []string{"id"}}
 	}
 
+	if include := ctx.Param("include"); include != "" {
+		for _, section := range strings.Split(include, ",") {
+			if !includes[strings.TrimSpace(section)] {
+				return nil, errors.InvalidParam{Param: []string{"include"}}
+			}
+		}
+	}
+
+	if currency := ctx.Param("currency"); currency != "" && !currencyPattern.MatchString(currency) {
+		return nil, errors.InvalidParam{Param: []string{"currency"}}
+	}
+
+	// as_of reads the product as it was at an RFC 3339 time
+	if asOf := ctx.Param("as_of"); asOf != "" {
+		if _, err := time.Parse(time.RFC3339, asOf); err != nil {
+			return nil, errors.InvalidParam{Param: []string{"as_of"}}
+		}
+	}
+
 	return h.service.GetByID(ctx, id)
 }
 
@@ -50,15 +82,196 @@ func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
 		return nil, errors.InvalidParam{Param: []string{"name"}}
 	}
 
+	inStock := ctx.Param("in_stock")
+	if _, err = strconv.ParseBool(inStock); inStock != "" && err != nil {
+		return nil, errors.InvalidParam{Param: []string{"in_stock"}}
+	}
+
+	if minRating := ctx.Param("min_rating"); minRating != "" {
+		if r, err := strconv.ParseFloat(minRating, 64); err != nil || r < 1 || r > 5 {
+			return nil, errors.InvalidParam{Param: []string{"min_rating"}}
+		}
+	}
+
+	if sort := ctx.Param("sort"); sort != "" && sort != "rating" && sort != "rating_asc" && sort != "newest" {
+		return nil, errors.InvalidParam{Param: []string{"sort"}}
+	}
+
+	// updated_since lists the products changed, themselves or through a variant, from an RFC 3339 time on
+	if since := ctx.Param("updated_since"); since != "" {
+		if _, err := time.Parse(time.RFC3339, since); err != nil {
+			return nil, errors.InvalidParam{Param: []string{"updated_since"}}
+		}
+	}
+
+	// tags=a,b matches products with all of the tags unless tags_match=any
+	if match := ctx.Param("tags_match"); match != "" && match != "all" && match != "any" {
+		return nil, errors.InvalidParam{Param: []string{"tags_match"}}
+	}
+
+	if exclude := ctx.Param("exclude_allergens"); exclude != "" {
+		for _, name := range strings.Split(exclude, ",") {
+			if _, ok := nutrition.NormalizeAllergen(name); !ok {
+				return nil, errors.InvalidParam{Param: []string{"exclude_allergens"}}
+			}
+		}
+	}
+
+	if currency := ctx.Param("currency"); currency != "" && !currencyPattern.MatchString(currency) {
+		return nil, errors.InvalidParam{Param: []string{"currency"}}
+	}
+
+	// only active products are listed unless status=all or a list of statuses is given
+	if status := ctx.Param("status"); status != "" && status != "all" {
+		for _, s := range strings.Split(status, ",") {
+			if !statuses[s] {
+				return nil, errors.InvalidParam{Param: []string{"status"}}
+			}
+		}
+	}
+
 	return h.service.GetAll(ctx)
 }
 
+// Create adds a product as a draft owned by the user in the X-User-ID header.
 func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
 	var product *models.Product
 
-	if err := ctx.Bind(&product); err != nil {
+	owner := ctx.Header(models.UserHeader)
+	if owner == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&product); err != nil || product == nil {
 		return nil, errors.InvalidParam{Param: []string{"body"}}
 	}
 
+	product.Owner = owner
+
 	return h.service.Create(ctx, product)
 }
+
+// SetAttributes replaces the attribute values of a product; the body is an object of attribute names to values.
+func (h *Handler) SetAttributes(ctx *krogo.Context) (interface{}, error) {
+	var body map[string]interface{}
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetAttributes(ctx, id, body)
+}
+
+// SetTaxClass changes the tax class of a product.
+func (h *Handler) SetTaxClass(ctx *krogo.Context) (interface{}, error) {
+	var body models.ProductTaxClass
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetTaxClass(ctx, id, body.TaxClass)
+}
+
+// SetSchedule sets when a product is published and withdrawn; the body is
+// {"publish_at": "2023-05-01T10:00:00Z", "unpublish_at": "2023-06-01T10:00:00Z"} and either time can be null.
+func (h *Handler) SetSchedule(ctx *krogo.Context) (interface{}, error) {
+	var body models.Schedule
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetSchedule(ctx, id, &body)
+}
+
+// SetStatus moves a product to another lifecycle status; the body is {"status": "discontinued", "reason": "..."}.
+// The user making the change is read from the X-User-ID header and recorded with it.
+func (h *Handler) SetStatus(ctx *krogo.Context) (interface{}, error) {
+	var body models.StatusChange
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	actor := ctx.Header(models.UserHeader)
+	if actor == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetStatus(ctx, id, &body, actor)
+}
+
+// SetVariantStatus moves a variant to another lifecycle status; the body is the same as for SetStatus.
+func (h *Handler) SetVariantStatus(ctx *krogo.Context) (interface{}, error) {
+	var body models.StatusChange
+
+	id := ctx.PathParam("id")
+	pID := ctx.PathParam("pid")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	actor := ctx.Header(models.UserHeader)
+	if actor == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetVariantStatus(ctx, pID, id, &body, actor)
+}
+
+// GetTransitions lists the status changes of a product and its variants.
+func (h *Handler) GetTransitions(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetTransitions(ctx, id)
+}
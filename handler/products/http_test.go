@@ -13,10 +13,12 @@ import (
 	"practice-app/models"
 	"practice-app/service/products"
 	"testing"
+	"time"
 )
 
 func getContext() *krogo.Context {
 	r := httptest.NewRequest(http.MethodGet, "/products/{id}", nil)
+	r.Header.Set(models.UserHeader, "u1")
 	req := request.NewHTTPRequest(r)
 	ctx := krogo.NewContext(nil, req, krogo.New())
 
@@ -88,13 +90,23 @@ func TestHandler_GetAll(t *testing.T) {
 		Pid            string
 		Vid            string
 		Name           string
+		InStock        string
+		TagsMatch      string
+		MinRating      string
+		Sort           string
+		Exclude        string
+		Currency       string
+		Status         string
+		UpdatedSince   string
 		Calls          []*gomock.Call
 	}{
 		{
-			Desc: "Success",
-			Pid:  "1",
-			Vid:  "1",
-			Name: "product_1",
+			Desc:         "Success",
+			Pid:          "1",
+			Vid:          "1",
+			Name:         "product_1",
+			Sort:         "newest",
+			UpdatedSince: "2023-05-01T00:00:00Z",
 			ExpectedResult: []models.ProductWithVariants{{
 				ID:        "1",
 				Name:      "product_1",
@@ -159,10 +171,93 @@ func TestHandler_GetAll(t *testing.T) {
 			ExpectedErr:    errors.InvalidParam{Param: []string{"name"}},
 			Calls:          []*gomock.Call{},
 		},
+		{
+			Desc:           "Failure: in_stock not valid",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			InStock:        "yes",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"in_stock"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: tags_match not valid",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			TagsMatch:      "none",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"tags_match"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: min_rating out of range",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			MinRating:      "6",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"min_rating"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: sort not valid",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			Sort:           "price",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"sort"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: unknown allergen excluded",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			Exclude:        "peanut,gluten",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"exclude_allergens"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: currency not valid",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			Currency:       "pesos",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"currency"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: unapproved status",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			Status:         "active,draft",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"status"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: updated_since not a time",
+			Pid:            "1",
+			Vid:            "1",
+			Name:           "product_1",
+			UpdatedSince:   "2023-05-01",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"updated_since"}},
+			Calls:          []*gomock.Call{},
+		},
 	}
 
 	for i, test := range testcases {
-		target := "/products?pid=" + test.Pid + "&vid=" + test.Vid + "&name=" + test.Name
+		target := "/products?pid=" + test.Pid + "&vid=" + test.Vid + "&name=" + test.Name + "&in_stock=" + test.InStock +
+			"&tags=organic&tags_match=" + test.TagsMatch + "&min_rating=" + test.MinRating + "&sort=" + test.Sort +
+			"&exclude_allergens=" + test.Exclude + "&currency=" + test.Currency + "&status=" + test.Status +
+			"&updated_since=" + test.UpdatedSince
 		r := httptest.NewRequest(http.MethodGet, target, nil)
 		req := request.NewHTTPRequest(r)
 		ctx := krogo.NewContext(nil, req, krogo.New())
@@ -183,6 +278,7 @@ func TestHandler_Create(t *testing.T) {
 		Desc           string
 		ExpectedResult interface{}
 		ExpectedErr    error
+		User           string
 		Body           *models.Product
 		Calls          []*gomock.Call
 	}{
@@ -194,8 +290,10 @@ func TestHandler_Create(t *testing.T) {
 				BrandName: "brand_1",
 				Details:   "details",
 				ImageUrl:  "url",
+				Owner:     "u1",
 			},
 			ExpectedErr: nil,
+			User:        "u1",
 			Body: &models.Product{
 				ID:        "1",
 				Name:      "product_1",
@@ -210,17 +308,26 @@ func TestHandler_Create(t *testing.T) {
 					BrandName: "brand_1",
 					Details:   "details",
 					ImageUrl:  "url",
+					Owner:     "u1",
 				}).Return(&models.Product{
 					ID:        "1",
 					Name:      "product_1",
 					BrandName: "brand_1",
 					Details:   "details",
 					ImageUrl:  "url",
+					Owner:     "u1",
 				}, nil),
 			},
 		},
+		{
+			Desc:           "Failure: missing user",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.MissingParam{Param: []string{"X-User-ID"}},
+			Body:           &models.Product{ID: "1"},
+		},
 		{
 			Desc:           "bind error",
+			User:           "u1",
 			ExpectedResult: nil,
 			ExpectedErr:    errors.InvalidParam{Param: []string{"body"}},
 			Calls:          []*gomock.Call{},
@@ -235,6 +342,7 @@ func TestHandler_Create(t *testing.T) {
 		}
 
 		r := httptest.NewRequest(http.MethodGet, "/products", bytes.NewBuffer(body))
+		r.Header.Set(models.UserHeader, test.User)
 		req := request.NewHTTPRequest(r)
 		ctx := krogo.NewContext(nil, req, krogo.New())
 
@@ -244,3 +352,386 @@ func TestHandler_Create(t *testing.T) {
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func TestHandler_SetAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "u1",
+			ID:             "1",
+			Body:           `{"wattage":1500,"finish":"matte"}`,
+			ExpectedResult: map[string]interface{}{"wattage": float64(1500), "finish": "matte"},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().SetAttributes(gomock.Any(), "1", map[string]interface{}{"wattage": float64(1500), "finish": "matte"}).
+					Return(map[string]interface{}{"wattage": float64(1500), "finish": "matte"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			User:        "u1",
+			ID:          "1",
+			Body:        `["wattage"]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Body:        `{"wattage":1500}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/attributes", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID})
+
+		res, err := mockHandler.SetAttributes(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetTaxClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "u1",
+			ID:             "1",
+			Body:           `{"tax_class":"food"}`,
+			ExpectedResult: &models.ProductTaxClass{TaxClass: "food"},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().SetTaxClass(gomock.Any(), "1", "food").Return(&models.ProductTaxClass{TaxClass: "food"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Body:        `{"tax_class":"food"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			User:        "u1",
+			ID:          "1",
+			Body:        `["food"]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Body:        `{"tax_class":"food"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/tax-class", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID})
+
+		res, err := mockHandler.SetTaxClass(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	publish := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	schedule := &models.Schedule{PublishAt: &publish}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "u1",
+			ID:             "1",
+			Body:           `{"publish_at":"2023-05-01T10:00:00Z","unpublish_at":null}`,
+			ExpectedResult: schedule,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().SetSchedule(gomock.Any(), "1", schedule).Return(schedule, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Body:        `{"publish_at":"2023-05-01T10:00:00Z"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			User:        "u1",
+			ID:          "1",
+			Body:        `{"publish_at":"tomorrow"}`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/schedule", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID})
+
+		res, err := mockHandler.SetSchedule(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	changes := []models.Transition{{ID: 1, ProductID: "1", From: "active", To: "discontinued", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		User           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			User:           "u1",
+			Body:           `{"status":"discontinued"}`,
+			ExpectedResult: changes,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().SetStatus(gomock.Any(), "1", &models.StatusChange{Status: "discontinued"}, "u1").
+					Return(changes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Body:        `{"status":"discontinued"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Body:        `{"status":"discontinued"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+		{
+			Desc:        "bind error",
+			ID:          "1",
+			User:        "u1",
+			Body:        `["discontinued"]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/status", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID})
+
+		res, err := mockHandler.SetStatus(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetVariantStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	changes := []models.Transition{{ID: 1, ProductID: "1", VariantID: "2", From: "active", To: "archived", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		Pid            string
+		User           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "2",
+			Pid:            "1",
+			User:           "u1",
+			ExpectedResult: changes,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().SetVariantStatus(gomock.Any(), "1", "2", &models.StatusChange{Status: "archived"}, "u1").
+					Return(changes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			ID:          "2",
+			User:        "u1",
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "2",
+			Pid:         "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/variant/2/status", bytes.NewBufferString(`{"status":"archived"}`))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID, "pid": test.Pid})
+
+		res, err := mockHandler.SetVariantStatus(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockService)
+
+	history := []models.Transition{{ID: 1, ProductID: "1", From: "draft", To: "active", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			ExpectedResult: history,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().GetTransitions(gomock.Any(), "1").Return(history, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext()
+		ctx.SetPathParams(map[string]string{"id": test.ID})
+
+		res, err := mockHandler.GetTransitions(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_GetByIDInclude(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductService := products.NewMockProductService(ctrl)
+	mockHandler := New(mockProductService)
+
+	related := &models.ProductWithVariants{ID: "1", Related: []models.Relationship{{ProductID: "1", RelatedID: "2", Type: "accessory"}}}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Target:         "/products/1?include=related",
+			ExpectedResult: related,
+			Calls: []*gomock.Call{
+				mockProductService.EXPECT().GetByID(gomock.Any(), "1").Return(related, nil),
+			},
+		},
+		{
+			Desc:        "Failure: unknown section",
+			Target:      "/products/1?include=related,reviews",
+			ExpectedErr: errors.InvalidParam{Param: []string{"include"}},
+		},
+		{
+			Desc:        "Failure: currency not valid",
+			Target:      "/products/1?currency=CA",
+			ExpectedErr: errors.InvalidParam{Param: []string{"currency"}},
+		},
+		{
+			Desc:        "Failure: as_of not a time",
+			Target:      "/products/1?as_of=2023-05-01",
+			ExpectedErr: errors.InvalidParam{Param: []string{"as_of"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodGet, test.Target, nil)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": "1"})
+
+		res, err := mockHandler.GetByID(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,80 @@
+package promotions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/promotions"
+	"strconv"
+)
+
+type Handler struct {
+	service promotions.PromotionService
+}
+
+func New(service promotions.PromotionService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+func (h *Handler) GetByID(ctx *krogo.Context) (interface{}, error) {
+	id, err := pathID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.GetByID(ctx, id)
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var promotion *models.Promotion
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&promotion); err != nil || promotion == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	promotion.ID = 0
+
+	return h.service.Create(ctx, promotion)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	id, err := pathID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, id)
+}
+
+// Evaluate prices a list of variants with the running promotions; the body is
+// {"items": [{"variant_id": "1", "quantity": 2}]}.
+func (h *Handler) Evaluate(ctx *krogo.Context) (interface{}, error) {
+	var request *models.EvaluationRequest
+
+	if err := ctx.Bind(&request); err != nil || request == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Evaluate(ctx, request)
+}
+
+func pathID(ctx *krogo.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return id, nil
+}
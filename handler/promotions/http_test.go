@@ -0,0 +1,200 @@
+package promotions
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/promotions"
+	"testing"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := promotions.NewMockPromotionService(ctrl)
+	mockHandler := New(mockService)
+
+	list := []models.Promotion{{ID: 1, Name: "Summer sale", Type: "percent_off", PercentOff: 10}}
+
+	mockService.EXPECT().GetAll(gomock.Any()).Return(list, nil)
+
+	res, err := mockHandler.GetAll(getContext("/promotions", "", nil))
+
+	assert.Equal(t, list, res)
+	assert.NoError(t, err)
+}
+
+func TestHandler_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := promotions.NewMockPromotionService(ctrl)
+	mockHandler := New(mockService)
+
+	promotion := &models.Promotion{ID: 1, Name: "Summer sale", Type: "percent_off", PercentOff: 10}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			ExpectedResult: promotion,
+			Calls:          []*gomock.Call{mockService.EXPECT().GetByID(gomock.Any(), 1).Return(promotion, nil)},
+		},
+		{
+			Desc:        "Failure: id not a number",
+			ID:          "one",
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.GetByID(getContext("/promotions/"+test.ID, "", map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := promotions.NewMockPromotionService(ctrl)
+	mockHandler := New(mockService)
+
+	targets := []models.PromotionTarget{{Type: "brand", ID: "acme"}}
+	created := &models.Promotion{ID: 3, Name: "Acme 10%", Type: "percent_off", PercentOff: 10, Targets: targets}
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"id":7,"name":"Acme 10%","type":"percent_off","percent_off":10,"targets":[{"type":"brand","id":"acme"}]}`,
+			ExpectedResult: created,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Create(gomock.Any(), &models.Promotion{Name: "Acme 10%", Type: "percent_off", PercentOff: 10,
+					Targets: targets}).Return(created, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Create(getContext("/promotions", test.Body, nil))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := promotions.NewMockPromotionService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+
+	res, err := mockHandler.Delete(getContext("/promotions/1", "", map[string]string{"id": "1"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Delete(getContext("/promotions/x", "", map[string]string{"id": "x"}))
+
+	assert.Equal(t, errors.InvalidParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Evaluate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := promotions.NewMockPromotionService(ctrl)
+	mockHandler := New(mockService)
+
+	evaluation := &models.Evaluation{
+		Items: []models.EvaluatedItem{{VariantID: "1-s", ProductID: "1", Quantity: 2, UnitPriceCents: 300, SubtotalCents: 600,
+			TotalCents: 600}},
+		Promotions:    []models.AppliedPromotion{},
+		SubtotalCents: 600,
+		TotalCents:    600,
+	}
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"items":[{"variant_id":"1-s","quantity":2}]}`,
+			ExpectedResult: evaluation,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Evaluate(gomock.Any(), &models.EvaluationRequest{
+					Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 2}},
+				}).Return(evaluation, nil),
+			},
+		},
+		{
+			Desc:        "bind error",
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Evaluate(getContext("/promotions/evaluate", test.Body, nil))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(promotions.NewMockPromotionService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,77 @@
+package relationships
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/relationships"
+)
+
+type Handler struct {
+	service relationships.RelationshipService
+}
+
+func New(service relationships.RelationshipService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.Get(ctx, id, ctx.Param("type"))
+}
+
+// Set links the product of the path to the related_id of the body; posting an existing link updates its position.
+func (h *Handler) Set(ctx *krogo.Context) (interface{}, error) {
+	var body *models.Relationship
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	body.ProductID = id
+
+	return h.service.Set(ctx, body)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	var missing []string
+
+	id, relType, relatedID := ctx.PathParam("id"), ctx.PathParam("type"), ctx.PathParam("related_id")
+
+	if id == "" {
+		missing = append(missing, "id")
+	}
+
+	if relType == "" {
+		missing = append(missing, "type")
+	}
+
+	if relatedID == "" {
+		missing = append(missing, "related_id")
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.MissingParam{Param: missing}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, id, relType, relatedID)
+}
@@ -0,0 +1,129 @@
+package relationships
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/relationships"
+	"testing"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := relationships.NewMockRelationshipService(ctrl)
+	mockHandler := New(mockService)
+
+	links := []models.Relationship{{ProductID: "1", RelatedID: "2", Type: "accessory"}}
+
+	mockService.EXPECT().Get(gomock.Any(), "1", "accessory").Return(links, nil)
+
+	res, err := mockHandler.Get(getContext("/products/1/relationships?type=accessory", "", map[string]string{"id": "1"}))
+
+	assert.Equal(t, links, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Get(getContext("/products//relationships", "", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := relationships.NewMockRelationshipService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "1"},
+			Body:           `{"product_id":"ignored","related_id":"2","type":"accessory","position":1}`,
+			ExpectedResult: &models.Relationship{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory", Position: 1},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Set(gomock.Any(), &models.Relationship{ProductID: "1", RelatedID: "2", Type: "accessory", Position: 1}).
+					Return(&models.Relationship{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory", Position: 1}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Body:        `{"related_id":"2","type":"accessory"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			PathParams:  map[string]string{"id": "1"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Set(getContext("/products/1/relationships", test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := relationships.NewMockRelationshipService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), "1", "accessory", "2").Return(nil)
+
+	res, err := mockHandler.Delete(getContext("/", "", map[string]string{"id": "1", "type": "accessory", "related_id": "2"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Delete(getContext("/", "", map[string]string{"id": "1"}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"type", "related_id"}}, err)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(relationships.NewMockRelationshipService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Set", Handle: mockHandler.Set},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
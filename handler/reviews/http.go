@@ -0,0 +1,100 @@
+package reviews
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/reviews"
+	"strconv"
+)
+
+type Handler struct {
+	service reviews.ReviewService
+}
+
+func New(service reviews.ReviewService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetByProductID lists the approved reviews of a product; moderators pass status=pending for the queue.
+func (h *Handler) GetByProductID(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetByProductID(ctx, id, ctx.Param("status"))
+}
+
+func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
+	var review *models.Review
+
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&review); err != nil || review == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	review.ID = 0
+	review.ProductID = id
+
+	return h.service.Create(ctx, review)
+}
+
+func (h *Handler) Approve(ctx *krogo.Context) (interface{}, error) {
+	return h.moderate(ctx, models.ReviewApproved)
+}
+
+func (h *Handler) Reject(ctx *krogo.Context) (interface{}, error) {
+	return h.moderate(ctx, models.ReviewRejected)
+}
+
+func (h *Handler) Delete(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, pID, id)
+}
+
+func (h *Handler) moderate(ctx *krogo.Context, status string) (interface{}, error) {
+	pID, id, err := pathParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.Moderate(ctx, pID, id, status)
+}
+
+func pathParams(ctx *krogo.Context) (pID string, id int, err error) {
+	pID = ctx.PathParam("pid")
+
+	if pID == "" {
+		return "", 0, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	id, err = strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return "", 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return pID, id, nil
+}
@@ -0,0 +1,157 @@
+package reviews
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/reviews"
+	"testing"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := reviews.NewMockReviewService(ctrl)
+	mockHandler := New(mockService)
+
+	list := []models.Review{{ID: 1, ProductID: "1", Rating: 2, Status: "pending"}}
+
+	mockService.EXPECT().GetByProductID(gomock.Any(), "1", "pending").Return(list, nil)
+
+	res, err := mockHandler.GetByProductID(getContext("/products/1/reviews?status=pending", "", map[string]string{"id": "1"}))
+
+	assert.Equal(t, list, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.GetByProductID(getContext("/products//reviews", "", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := reviews.NewMockReviewService(ctrl)
+	mockHandler := New(mockService)
+
+	created := &models.Review{ID: 5, ProductID: "1", Rating: 5, Title: "Great", Body: "Loved it", Status: "pending"}
+
+	testcases := []struct {
+		Desc           string
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			PathParams:     map[string]string{"id": "1"},
+			Body:           `{"id":9,"product_id":"2","rating":5,"title":"Great","body":"Loved it"}`,
+			ExpectedResult: created,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Create(gomock.Any(), &models.Review{ProductID: "1", Rating: 5, Title: "Great", Body: "Loved it"}).
+					Return(created, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Body:        `{"rating":5}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			PathParams:  map[string]string{"id": "1"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Create(getContext("/products/1/reviews", test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := reviews.NewMockReviewService(ctrl)
+	mockHandler := New(mockService)
+
+	approved := &models.Review{ID: 5, ProductID: "1", Status: "approved"}
+
+	mockService.EXPECT().Moderate(gomock.Any(), "1", 5, "approved").Return(approved, nil)
+	mockService.EXPECT().Moderate(gomock.Any(), "1", 5, "rejected").Return(nil, errors.EntityNotFound{ID: "5", Entity: "reviews"})
+
+	res, err := mockHandler.Approve(getContext("/products/1/reviews/5/approve", "", map[string]string{"pid": "1", "id": "5"}))
+
+	assert.Equal(t, approved, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Reject(getContext("/products/1/reviews/5/reject", "", map[string]string{"pid": "1", "id": "5"}))
+
+	assert.Equal(t, errors.EntityNotFound{ID: "5", Entity: "reviews"}, err)
+
+	_, err = mockHandler.Approve(getContext("/products/1/reviews/x/approve", "", map[string]string{"pid": "1", "id": "x"}))
+
+	assert.Equal(t, errors.InvalidParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := reviews.NewMockReviewService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), "1", 5).Return(nil)
+
+	res, err := mockHandler.Delete(getContext("/products/1/reviews/5", "", map[string]string{"pid": "1", "id": "5"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Delete(getContext("/products//reviews/5", "", map[string]string{"id": "5"}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"pid"}}, err)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(reviews.NewMockReviewService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "Create", Handle: mockHandler.Create},
+		{Desc: "Approve", Handle: mockHandler.Approve},
+		{Desc: "Reject", Handle: mockHandler.Reject},
+		{Desc: "Delete", Handle: mockHandler.Delete},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,84 @@
+package revisions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/revisions"
+	"strconv"
+)
+
+type Handler struct {
+	service revisions.RevisionService
+}
+
+func New(service revisions.RevisionService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetByProductID lists the revisions of a product and its variants.
+func (h *Handler) GetByProductID(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.GetByProductID(ctx, id)
+}
+
+// Diff compares the revisions given by from= and to= of a product or one of its variants.
+func (h *Handler) Diff(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	from, to := ctx.Param("from"), ctx.Param("to")
+
+	if err := revisionID("from", from); err != nil {
+		return nil, err
+	}
+
+	if err := revisionID("to", to); err != nil {
+		return nil, err
+	}
+
+	return h.service.Diff(ctx, id, from, to)
+}
+
+// Revert restores a product or one of its variants as it was in a revision. The user making the change is read from
+// the X-User-ID header.
+func (h *Handler) Revert(ctx *krogo.Context) (interface{}, error) {
+	pID := ctx.PathParam("pid")
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	id := ctx.PathParam("id")
+
+	if err := revisionID("id", id); err != nil {
+		return nil, err
+	}
+
+	actor := ctx.Header(models.UserHeader)
+	if actor == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return h.service.Revert(ctx, pID, id, actor)
+}
+
+func revisionID(param, id string) error {
+	if id == "" {
+		return errors.MissingParam{Param: []string{param}}
+	}
+
+	if _, err := strconv.Atoi(id); err != nil {
+		return errors.InvalidParam{Param: []string{param}}
+	}
+
+	return nil
+}
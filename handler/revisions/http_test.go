@@ -0,0 +1,131 @@
+package revisions
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/revisions"
+	"testing"
+)
+
+func getContext(target, user string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.Header.Set(models.UserHeader, user)
+
+	ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := revisions.NewMockRevisionService(ctrl)
+	mockHandler := New(mockService)
+
+	history := []models.Revision{{ID: 1, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}}}
+
+	mockService.EXPECT().GetByProductID(gomock.Any(), "1").Return(history, nil)
+
+	res, err := mockHandler.GetByProductID(getContext("/products/1/revisions", "", map[string]string{"id": "1"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, history, res)
+
+	_, err = mockHandler.GetByProductID(getContext("/products//revisions", "", map[string]string{"id": ""}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := revisions.NewMockRevisionService(ctrl)
+	mockHandler := New(mockService)
+
+	diff := &models.RevisionDiff{From: 1, To: 4, Changes: []models.FieldChange{{Field: "details", From: "whole", To: "skimmed"}}}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Target:         "/products/1/revisions/diff?from=1&to=4",
+			ExpectedResult: diff,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Diff(gomock.Any(), "1", "1", "4").Return(diff, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing from",
+			Target:      "/products/1/revisions/diff?to=4",
+			ExpectedErr: errors.MissingParam{Param: []string{"from"}},
+		},
+		{
+			Desc:        "Failure: to not a number",
+			Target:      "/products/1/revisions/diff?from=1&to=latest",
+			ExpectedErr: errors.InvalidParam{Param: []string{"to"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Diff(getContext(test.Target, "", map[string]string{"id": "1"}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := revisions.NewMockRevisionService(ctrl)
+	mockHandler := New(mockService)
+
+	reverted := &models.Revision{ID: 7, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		User           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			User:           "u1",
+			ExpectedResult: reverted,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Revert(gomock.Any(), "1", "1", "u1").Return(reverted, nil),
+			},
+		},
+		{
+			Desc:        "Failure: id not a number",
+			ID:          "first",
+			User:        "u1",
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Revert(getContext("/products/1/revisions/1/revert", test.User,
+			map[string]string{"pid": "1", "id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
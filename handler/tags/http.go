@@ -0,0 +1,103 @@
+package tags
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/tags"
+)
+
+type Handler struct {
+	service tags.TagService
+}
+
+func New(service tags.TagService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetAll(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx)
+}
+
+// AddProduct adds the tags of the body to the product of the path.
+func (h *Handler) AddProduct(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.add(ctx, id, "")
+}
+
+// AddVariant adds the tags of the body to the variant of the path.
+func (h *Handler) AddVariant(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := variantParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.add(ctx, pID, id)
+}
+
+func (h *Handler) RemoveProduct(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.remove(ctx, id, "")
+}
+
+func (h *Handler) RemoveVariant(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := variantParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.remove(ctx, pID, id)
+}
+
+func (h *Handler) add(ctx *krogo.Context, productID, variantID string) (interface{}, error) {
+	var body *models.Tags
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Add(ctx, productID, variantID, body.Tags)
+}
+
+func (h *Handler) remove(ctx *krogo.Context, productID, variantID string) (interface{}, error) {
+	tag := ctx.PathParam("tag")
+
+	if tag == "" {
+		return nil, errors.MissingParam{Param: []string{"tag"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Remove(ctx, productID, variantID, tag)
+}
+
+func variantParams(ctx *krogo.Context) (pID, id string, err error) {
+	pID = ctx.PathParam("pid")
+	id = ctx.PathParam("id")
+
+	if pID == "" {
+		return "", "", errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if id == "" {
+		return "", "", errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return pID, id, nil
+}
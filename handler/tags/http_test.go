@@ -0,0 +1,153 @@
+package tags
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/tags"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, "/products/1/tags", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tags.NewMockTagService(ctrl)
+	mockHandler := New(mockService)
+
+	vocabulary := []models.Tag{{Name: "organic", Products: 2}}
+
+	mockService.EXPECT().GetAll(gomock.Any()).Return(vocabulary, nil)
+
+	res, err := mockHandler.GetAll(getContext("", nil))
+
+	assert.Equal(t, vocabulary, res)
+	assert.NoError(t, err)
+}
+
+func TestHandler_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tags.NewMockTagService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: product",
+			Handle:         mockHandler.AddProduct,
+			PathParams:     map[string]string{"id": "1"},
+			Body:           `{"tags":["Organic","vegan"]}`,
+			ExpectedResult: &models.Tags{Tags: []string{"organic", "vegan"}},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Add(gomock.Any(), "1", "", []string{"Organic", "vegan"}).
+					Return(&models.Tags{Tags: []string{"organic", "vegan"}}, nil),
+			},
+		},
+		{
+			Desc:           "Success: variant",
+			Handle:         mockHandler.AddVariant,
+			PathParams:     map[string]string{"pid": "1", "id": "1-s"},
+			Body:           `{"tags":["new"]}`,
+			ExpectedResult: &models.Tags{Tags: []string{"new"}},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Add(gomock.Any(), "1", "1-s", []string{"new"}).Return(&models.Tags{Tags: []string{"new"}}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing variant id",
+			Handle:      mockHandler.AddVariant,
+			PathParams:  map[string]string{"pid": "1"},
+			Body:        `{"tags":["new"]}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing id",
+			Handle:      mockHandler.AddProduct,
+			Body:        `{"tags":["new"]}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.AddProduct,
+			PathParams:  map[string]string{"id": "1"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tags.NewMockTagService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Remove(gomock.Any(), "1", "", "organic").Return(nil)
+	mockService.EXPECT().Remove(gomock.Any(), "1", "1-s", "new").Return(errors.EntityNotFound{ID: "new", Entity: "tags"})
+
+	res, err := mockHandler.RemoveProduct(getContext("", map[string]string{"id": "1", "tag": "organic"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.RemoveVariant(getContext("", map[string]string{"pid": "1", "id": "1-s", "tag": "new"}))
+
+	assert.Equal(t, errors.EntityNotFound{ID: "new", Entity: "tags"}, err)
+
+	_, err = mockHandler.RemoveProduct(getContext("", map[string]string{"id": "1"}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"tag"}}, err)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(tags.NewMockTagService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "AddProduct", Handle: mockHandler.AddProduct},
+		{Desc: "AddVariant", Handle: mockHandler.AddVariant},
+		{Desc: "RemoveProduct", Handle: mockHandler.RemoveProduct},
+		{Desc: "RemoveVariant", Handle: mockHandler.RemoveVariant},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
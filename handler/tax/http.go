@@ -0,0 +1,92 @@
+package tax
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/tax"
+	"strconv"
+)
+
+type Handler struct {
+	service tax.TaxService
+}
+
+func New(service tax.TaxService) *Handler {
+	return &Handler{service: service}
+}
+
+// GetRates lists the tax rates, optionally of a single region with ?region=US-OH.
+func (h *Handler) GetRates(ctx *krogo.Context) (interface{}, error) {
+	return h.service.GetAll(ctx, ctx.Param("region"))
+}
+
+func (h *Handler) CreateRate(ctx *krogo.Context) (interface{}, error) {
+	var rate *models.TaxRate
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&rate); err != nil || rate == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	rate.ID = 0
+
+	return h.service.Create(ctx, rate)
+}
+
+func (h *Handler) UpdateRate(ctx *krogo.Context) (interface{}, error) {
+	var rate *models.TaxRate
+
+	id, err := pathID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err = ctx.Bind(&rate); err != nil || rate == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	rate.ID = id
+
+	return h.service.Update(ctx, rate)
+}
+
+func (h *Handler) DeleteRate(ctx *krogo.Context) (interface{}, error) {
+	id, err := pathID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, id)
+}
+
+// Quote computes the tax of a sale; the body is {"region": "US-OH", "items": [{"variant_id": "1", "quantity": 2}]}.
+func (h *Handler) Quote(ctx *krogo.Context) (interface{}, error) {
+	var request *models.TaxQuoteRequest
+
+	if err := ctx.Bind(&request); err != nil || request == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.Quote(ctx, request)
+}
+
+func pathID(ctx *krogo.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.PathParam("id"))
+	if err != nil {
+		return 0, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	return id, nil
+}
@@ -0,0 +1,205 @@
+package tax
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/tax"
+	"testing"
+	"time"
+)
+
+func getContext(target, body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPost, target, bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+var may = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestHandler_GetRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tax.NewMockTaxService(ctrl)
+	mockHandler := New(mockService)
+
+	list := []models.TaxRate{{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}}
+
+	mockService.EXPECT().GetAll(gomock.Any(), "US-OH").Return(list, nil)
+
+	res, err := mockHandler.GetRates(getContext("/tax/rates?region=US-OH", "", nil))
+
+	assert.Equal(t, list, res)
+	assert.NoError(t, err)
+}
+
+func TestHandler_CreateRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tax.NewMockTaxService(ctrl)
+	mockHandler := New(mockService)
+
+	rate := &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"id":9,"region":"US-OH","tax_class":"standard","rate":5.75,"effective_from":"2023-05-01T00:00:00Z"}`,
+			ExpectedResult: &models.TaxRate{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Create(gomock.Any(), rate).
+					Return(&models.TaxRate{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: bind error",
+			Body:        `[]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.CreateRate(getContext("/tax/rates", test.Body, nil))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_UpdateRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tax.NewMockTaxService(ctrl)
+	mockHandler := New(mockService)
+
+	rate := &models.TaxRate{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			Body:           `{"region":"US-OH","tax_class":"standard","rate":5.75,"effective_from":"2023-05-01T00:00:00Z"}`,
+			ExpectedResult: rate,
+			Calls:          []*gomock.Call{mockService.EXPECT().Update(gomock.Any(), rate).Return(rate, nil)},
+		},
+		{
+			Desc:        "Failure: id not a number",
+			ID:          "one",
+			Body:        `{}`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: bind error",
+			ID:          "1",
+			Body:        `[]`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.UpdateRate(getContext("/tax/rates/"+test.ID, test.Body, map[string]string{"id": test.ID}))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_DeleteRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tax.NewMockTaxService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+
+	res, err := mockHandler.DeleteRate(getContext("/tax/rates/1", "", map[string]string{"id": "1"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.DeleteRate(getContext("/tax/rates/one", "", map[string]string{"id": "one"}))
+
+	assert.Equal(t, errors.InvalidParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := tax.NewMockTaxService(ctrl)
+	mockHandler := New(mockService)
+
+	quote := &models.TaxQuote{Region: "US-OH", SubtotalCents: 1000, TaxCents: 58, TotalCents: 1058}
+
+	testcases := []struct {
+		Desc           string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           `{"region":"US-OH","items":[{"variant_id":"1","quantity":2}]}`,
+			ExpectedResult: quote,
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Quote(gomock.Any(), &models.TaxQuoteRequest{Region: "US-OH",
+					Items: []models.TaxQuoteItem{{VariantID: "1", Quantity: 2}}}).Return(quote, nil),
+			},
+		},
+		{
+			Desc:        "Failure: bind error",
+			Body:        `null`,
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockHandler.Quote(getContext("/tax/quote", test.Body, nil))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(tax.NewMockTaxService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "CreateRate", Handle: mockHandler.CreateRate},
+		{Desc: "UpdateRate", Handle: mockHandler.UpdateRate},
+		{Desc: "DeleteRate", Handle: mockHandler.DeleteRate},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("/", "{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
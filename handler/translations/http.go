@@ -0,0 +1,118 @@
+package translations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/translations"
+)
+
+type Handler struct {
+	service translations.TranslationService
+}
+
+func New(service translations.TranslationService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.service.Get(ctx, id)
+}
+
+// SetProduct stores the translation of a product's own text into the locale of the path.
+func (h *Handler) SetProduct(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.set(ctx, id, "")
+}
+
+// SetVariant stores the translation of a variant's text into the locale of the path.
+func (h *Handler) SetVariant(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := variantParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.set(ctx, pID, id)
+}
+
+func (h *Handler) DeleteProduct(ctx *krogo.Context) (interface{}, error) {
+	id := ctx.PathParam("id")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return h.delete(ctx, id, "")
+}
+
+func (h *Handler) DeleteVariant(ctx *krogo.Context) (interface{}, error) {
+	pID, id, err := variantParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.delete(ctx, pID, id)
+}
+
+func (h *Handler) set(ctx *krogo.Context, productID, variantID string) (interface{}, error) {
+	var body *models.Translation
+
+	locale := ctx.PathParam("locale")
+
+	if locale == "" {
+		return nil, errors.MissingParam{Param: []string{"locale"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil || body == nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	body.VariantID = variantID
+	body.Locale = locale
+
+	return h.service.Set(ctx, productID, body)
+}
+
+func (h *Handler) delete(ctx *krogo.Context, productID, variantID string) (interface{}, error) {
+	locale := ctx.PathParam("locale")
+
+	if locale == "" {
+		return nil, errors.MissingParam{Param: []string{"locale"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	return nil, h.service.Delete(ctx, productID, variantID, locale)
+}
+
+func variantParams(ctx *krogo.Context) (pID, id string, err error) {
+	pID = ctx.PathParam("pid")
+	id = ctx.PathParam("id")
+
+	if pID == "" {
+		return "", "", errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if id == "" {
+		return "", "", errors.MissingParam{Param: []string{"id"}}
+	}
+
+	return pID, id, nil
+}
@@ -0,0 +1,159 @@
+package translations
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/service/translations"
+	"testing"
+)
+
+func getContext(body string, pathParams map[string]string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/products/1/translations/es", bytes.NewBufferString(body))
+	r.Header.Set(models.UserHeader, "u1")
+	req := request.NewHTTPRequest(r)
+	ctx := krogo.NewContext(nil, req, krogo.New())
+	ctx.SetPathParams(pathParams)
+
+	return ctx
+}
+
+func TestHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := translations.NewMockTranslationService(ctrl)
+	mockHandler := New(mockService)
+
+	expected := &models.ProductTranslations{ProductID: "1", Translations: []models.Translation{}, MissingLocales: []string{"es"}}
+
+	mockService.EXPECT().Get(gomock.Any(), "1").Return(expected, nil)
+
+	res, err := mockHandler.Get(getContext("", map[string]string{"id": "1"}))
+
+	assert.Equal(t, expected, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.Get(getContext("", nil))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"id"}}, err)
+}
+
+func TestHandler_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := translations.NewMockTranslationService(ctrl)
+	mockHandler := New(mockService)
+
+	testcases := []struct {
+		Desc           string
+		Handle         func(ctx *krogo.Context) (interface{}, error)
+		PathParams     map[string]string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: product",
+			Handle:         mockHandler.SetProduct,
+			PathParams:     map[string]string{"id": "1", "locale": "es"},
+			Body:           `{"name":"Leche","variant_id":"ignored"}`,
+			ExpectedResult: &models.Translation{Locale: "es", Name: "Leche"},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Set(gomock.Any(), "1", &models.Translation{Locale: "es", Name: "Leche"}).
+					Return(&models.Translation{Locale: "es", Name: "Leche"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: variant",
+			Handle:         mockHandler.SetVariant,
+			PathParams:     map[string]string{"pid": "1", "id": "1-s", "locale": "es"},
+			Body:           `{"name":"Chica"}`,
+			ExpectedResult: &models.Translation{VariantID: "1-s", Locale: "es", Name: "Chica"},
+			Calls: []*gomock.Call{
+				mockService.EXPECT().Set(gomock.Any(), "1", &models.Translation{VariantID: "1-s", Locale: "es", Name: "Chica"}).
+					Return(&models.Translation{VariantID: "1-s", Locale: "es", Name: "Chica"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing locale",
+			Handle:      mockHandler.SetProduct,
+			PathParams:  map[string]string{"id": "1"},
+			Body:        `{"name":"Leche"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"locale"}},
+		},
+		{
+			Desc:        "Failure: missing variant id",
+			Handle:      mockHandler.SetVariant,
+			PathParams:  map[string]string{"pid": "1", "locale": "es"},
+			Body:        `{"name":"Chica"}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "bind error",
+			Handle:      mockHandler.SetProduct,
+			PathParams:  map[string]string{"id": "1", "locale": "es"},
+			Body:        "invalid body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Handle(getContext(test.Body, test.PathParams))
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := translations.NewMockTranslationService(ctrl)
+	mockHandler := New(mockService)
+
+	mockService.EXPECT().Delete(gomock.Any(), "1", "", "es").Return(nil)
+	mockService.EXPECT().Delete(gomock.Any(), "1", "1-s", "es").Return(errors.EntityNotFound{ID: "es", Entity: "translations"})
+
+	res, err := mockHandler.DeleteProduct(getContext("", map[string]string{"id": "1", "locale": "es"}))
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+
+	_, err = mockHandler.DeleteVariant(getContext("", map[string]string{"pid": "1", "id": "1-s", "locale": "es"}))
+
+	assert.Equal(t, errors.EntityNotFound{ID: "es", Entity: "translations"}, err)
+
+	_, err = mockHandler.DeleteVariant(getContext("", map[string]string{"id": "1-s", "locale": "es"}))
+
+	assert.Equal(t, errors.MissingParam{Param: []string{"pid"}}, err)
+}
+
+func TestHandler_MissingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := New(translations.NewMockTranslationService(ctrl))
+	params := map[string]string{"id": "1", "pid": "1", "code": "USD", "locale": "es", "type": "accessory", "related_id": "2", "tag": "vegan"}
+
+	testcases := []struct {
+		Desc   string
+		Handle func(ctx *krogo.Context) (interface{}, error)
+	}{
+		{Desc: "SetProduct", Handle: mockHandler.SetProduct},
+		{Desc: "SetVariant", Handle: mockHandler.SetVariant},
+		{Desc: "DeleteProduct", Handle: mockHandler.DeleteProduct},
+		{Desc: "DeleteVariant", Handle: mockHandler.DeleteVariant},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext("{}", params)
+		ctx.Request().Header.Del(models.UserHeader)
+
+		res, err := test.Handle(ctx)
+
+		assert.Nilf(t, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, errors.MissingParam{Param: []string{models.UserHeader}}, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
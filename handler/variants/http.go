@@ -30,6 +30,16 @@ func (h *Handler) GetByID(ctx *krogo.Context) (interface{}, error) {
 	return h.service.GetByID(ctx, id, pID)
 }
 
+func (h *Handler) GetByGTIN(ctx *krogo.Context) (interface{}, error) {
+	code := ctx.PathParam("code")
+
+	if code == "" {
+		return nil, errors.MissingParam{Param: []string{"code"}}
+	}
+
+	return h.service.GetByGTIN(ctx, code)
+}
+
 func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
 	var variant *models.Variant
 
@@ -39,6 +49,10 @@ func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
 		return nil, errors.MissingParam{Param: []string{"pid"}}
 	}
 
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
 	if err := ctx.Bind(&variant); err != nil {
 		return nil, errors.InvalidParam{Param: []string{"body"}}
 	}
@@ -49,3 +63,81 @@ func (h *Handler) Create(ctx *krogo.Context) (interface{}, error) {
 
 	return h.service.Create(ctx, variant)
 }
+
+// SetMeasurements replaces the net weight, volume and size of a variant.
+func (h *Handler) SetMeasurements(ctx *krogo.Context) (interface{}, error) {
+	var measurements models.Measurements
+
+	id := ctx.PathParam("id")
+	pID := ctx.PathParam("pid")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&measurements); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetMeasurements(ctx, id, pID, &measurements)
+}
+
+// SetNutrition replaces the nutrition facts panel of a variant.
+func (h *Handler) SetNutrition(ctx *krogo.Context) (interface{}, error) {
+	var panel *models.Nutrition
+
+	id := ctx.PathParam("id")
+	pID := ctx.PathParam("pid")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&panel); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetNutrition(ctx, id, pID, panel)
+}
+
+// SetAllergens replaces the allergens a variant contains; the body is {"allergens": ["milk", "peanut"]}.
+func (h *Handler) SetAllergens(ctx *krogo.Context) (interface{}, error) {
+	var body models.Allergens
+
+	id := ctx.PathParam("id")
+	pID := ctx.PathParam("pid")
+
+	if id == "" {
+		return nil, errors.MissingParam{Param: []string{"id"}}
+	}
+
+	if pID == "" {
+		return nil, errors.MissingParam{Param: []string{"pid"}}
+	}
+
+	if ctx.Header(models.UserHeader) == "" {
+		return nil, errors.MissingParam{Param: []string{models.UserHeader}}
+	}
+
+	if err := ctx.Bind(&body); err != nil {
+		return nil, errors.InvalidParam{Param: []string{"body"}}
+	}
+
+	return h.service.SetAllergens(ctx, id, pID, body.Allergens)
+}
@@ -17,6 +17,7 @@ import (
 
 func getContext() *krogo.Context {
 	r := httptest.NewRequest(http.MethodGet, "/products/{id}", nil)
+	r.Header.Set(models.UserHeader, "u1")
 	req := request.NewHTTPRequest(r)
 	ctx := krogo.NewContext(nil, req, krogo.New())
 
@@ -85,6 +86,45 @@ func TestHandler_GetByID(t *testing.T) {
 	}
 }
 
+func TestHandler_GetByGTIN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantService := variants.NewMockVariantService(ctrl)
+	mockHandler := New(mockVariantService)
+
+	ctx := getContext()
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Code:           "012345678905",
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", GTIN: "00012345678905"},
+			Calls: []*gomock.Call{
+				mockVariantService.EXPECT().GetByGTIN(ctx, "012345678905").
+					Return(&models.Variant{ID: "1", ProductID: "1", GTIN: "00012345678905"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing code",
+			ExpectedErr: errors.MissingParam{Param: []string{"code"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		ctx.SetPathParams(map[string]string{"code": test.Code})
+		res, err := mockHandler.GetByGTIN(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
 func TestHandler_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockVariantService := variants.NewMockVariantService(ctrl)
@@ -92,6 +132,7 @@ func TestHandler_Create(t *testing.T) {
 
 	testcases := []struct {
 		Desc           string
+		User           string
 		ExpectedResult interface{}
 		ExpectedErr    error
 		Pid            string
@@ -100,6 +141,7 @@ func TestHandler_Create(t *testing.T) {
 	}{
 		{
 			Desc: "Success",
+			User: "u1",
 			ExpectedResult: &models.Variant{
 				ID:        "1",
 				ProductID: "1",
@@ -130,6 +172,7 @@ func TestHandler_Create(t *testing.T) {
 		},
 		{
 			Desc:           "Failure: product id not provided",
+			User:           "u1",
 			ExpectedResult: nil,
 			ExpectedErr:    errors.MissingParam{Param: []string{"pid"}},
 			Pid:            "",
@@ -137,6 +180,7 @@ func TestHandler_Create(t *testing.T) {
 		},
 		{
 			Desc:           "bind error",
+			User:           "u1",
 			ExpectedResult: nil,
 			ExpectedErr:    errors.InvalidParam{Param: []string{"body"}},
 			Pid:            "1",
@@ -144,6 +188,7 @@ func TestHandler_Create(t *testing.T) {
 		},
 		{
 			Desc:           "pid invalid",
+			User:           "u1",
 			ExpectedResult: nil,
 			ExpectedErr:    errors.InvalidParam{Param: []string{"pid"}},
 			Pid:            "1",
@@ -155,6 +200,12 @@ func TestHandler_Create(t *testing.T) {
 			},
 			Calls: []*gomock.Call{},
 		},
+		{
+			Desc:        "Failure: missing user",
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+			Pid:         "1",
+			Body:        &models.Variant{ID: "1", ProductID: "1"},
+		},
 	}
 
 	for i, test := range testcases {
@@ -166,6 +217,7 @@ func TestHandler_Create(t *testing.T) {
 
 		target := "/products/" + test.Pid + "/variant"
 		r := httptest.NewRequest(http.MethodGet, target, bytes.NewBuffer(body))
+		r.Header.Set(models.UserHeader, test.User)
 		req := request.NewHTTPRequest(r)
 		ctx := krogo.NewContext(nil, req, krogo.New())
 		ctx.SetPathParams(map[string]string{"pid": test.Pid})
@@ -176,3 +228,219 @@ func TestHandler_Create(t *testing.T) {
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func TestHandler_SetMeasurements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantService := variants.NewMockVariantService(ctrl)
+	mockHandler := New(mockVariantService)
+
+	measurements := &models.Measurements{NetWeight: &models.Measurement{Value: 16, Unit: "oz"}}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Pid            string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "u1",
+			ID:             "1",
+			Pid:            "1",
+			Body:           `{"net_weight":{"value":16,"unit":"oz"}}`,
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", Measurements: measurements},
+			Calls: []*gomock.Call{
+				mockVariantService.EXPECT().SetMeasurements(gomock.Any(), "1", "1", measurements).
+					Return(&models.Variant{ID: "1", ProductID: "1", Measurements: measurements}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Pid:         "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			User:        "u1",
+			ID:          "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+		{
+			Desc:        "Failure: bind error",
+			User:        "u1",
+			ID:          "1",
+			Pid:         "1",
+			Body:        "invalid Body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Pid:         "1",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/variant/1/measurements", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID, "pid": test.Pid})
+
+		res, err := mockHandler.SetMeasurements(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetNutrition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantService := variants.NewMockVariantService(ctrl)
+	mockHandler := New(mockVariantService)
+
+	panel := &models.Nutrition{
+		ServingSize: models.Measurement{Value: 28, Unit: "g"},
+		Calories:    160,
+		Nutrients:   []models.Nutrient{{Name: "protein", Amount: 7, Unit: "g"}},
+	}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Pid            string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			User: "u1",
+			ID:   "1",
+			Pid:  "1",
+			Body: `{"serving_size":{"value":28,"unit":"g"},"calories":160,"nutrients":[{"name":"protein","amount":7,"unit":"g"}]}`,
+
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", Nutrition: panel},
+			Calls: []*gomock.Call{
+				mockVariantService.EXPECT().SetNutrition(gomock.Any(), "1", "1", panel).
+					Return(&models.Variant{ID: "1", ProductID: "1", Nutrition: panel}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Pid:         "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			User:        "u1",
+			ID:          "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+		{
+			Desc:        "Failure: bind error",
+			User:        "u1",
+			ID:          "1",
+			Pid:         "1",
+			Body:        "invalid Body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Pid:         "1",
+			Body:        `{}`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/variant/1/nutrition", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID, "pid": test.Pid})
+
+		res, err := mockHandler.SetNutrition(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestHandler_SetAllergens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantService := variants.NewMockVariantService(ctrl)
+	mockHandler := New(mockVariantService)
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ID             string
+		Pid            string
+		Body           string
+		ExpectedResult interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "u1",
+			ID:             "1",
+			Pid:            "1",
+			Body:           `{"allergens":["Milk","peanuts"]}`,
+			ExpectedResult: &models.Allergens{Allergens: []string{"milk", "peanut"}},
+			Calls: []*gomock.Call{
+				mockVariantService.EXPECT().SetAllergens(gomock.Any(), "1", "1", []string{"Milk", "peanuts"}).
+					Return(&models.Allergens{Allergens: []string{"milk", "peanut"}}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing id",
+			User:        "u1",
+			Pid:         "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"id"}},
+		},
+		{
+			Desc:        "Failure: missing pid",
+			User:        "u1",
+			ID:          "1",
+			ExpectedErr: errors.MissingParam{Param: []string{"pid"}},
+		},
+		{
+			Desc:        "Failure: bind error",
+			User:        "u1",
+			ID:          "1",
+			Pid:         "1",
+			Body:        "invalid Body",
+			ExpectedErr: errors.InvalidParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: missing user",
+			ID:          "1",
+			Pid:         "1",
+			Body:        `[]`,
+			ExpectedErr: errors.MissingParam{Param: []string{"X-User-ID"}},
+		},
+	}
+
+	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodPut, "/products/1/variant/1/allergens", bytes.NewBufferString(test.Body))
+		r.Header.Set(models.UserHeader, test.User)
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+		ctx.SetPathParams(map[string]string{"id": test.ID, "pid": test.Pid})
+
+		res, err := mockHandler.SetAllergens(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
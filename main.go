@@ -1,13 +1,70 @@
 package main
 
 import (
+	"strings"
+	"time"
+
 	"github.com/krogertechnology/krogo/pkg/krogo"
 
+	approvalsHandler "practice-app/handler/approvals"
+	auditHandler "practice-app/handler/audit"
+	brandsHandler "practice-app/handler/brands"
+	bundlesHandler "practice-app/handler/bundles"
+	categoriesHandler "practice-app/handler/categories"
+	currenciesHandler "practice-app/handler/currencies"
+	inventoryHandler "practice-app/handler/inventory"
+	locationsHandler "practice-app/handler/locations"
+	mediaHandler "practice-app/handler/media"
+	optionsHandler "practice-app/handler/options"
 	productsHandler "practice-app/handler/products"
+	promotionsHandler "practice-app/handler/promotions"
+	relationshipsHandler "practice-app/handler/relationships"
+	reviewsHandler "practice-app/handler/reviews"
+	revisionsHandler "practice-app/handler/revisions"
+	tagsHandler "practice-app/handler/tags"
+	taxHandler "practice-app/handler/tax"
+	translationsHandler "practice-app/handler/translations"
 	variantsHandler "practice-app/handler/variants"
+	"practice-app/scheduler"
+	approvalsService "practice-app/service/approvals"
+	auditService "practice-app/service/audit"
+	brandsService "practice-app/service/brands"
+	bundlesService "practice-app/service/bundles"
+	categoriesService "practice-app/service/categories"
+	currenciesService "practice-app/service/currencies"
+	inventoryService "practice-app/service/inventory"
+	locationsService "practice-app/service/locations"
+	mediaService "practice-app/service/media"
+	optionsService "practice-app/service/options"
 	productsService "practice-app/service/products"
+	promotionsService "practice-app/service/promotions"
+	relationshipsService "practice-app/service/relationships"
+	reviewsService "practice-app/service/reviews"
+	revisionsService "practice-app/service/revisions"
+	tagsService "practice-app/service/tags"
+	taxService "practice-app/service/tax"
+	translationsService "practice-app/service/translations"
 	variantsService "practice-app/service/variants"
+	approvalsStore "practice-app/store/approvals"
+	auditStore "practice-app/store/audit"
+	blobStore "practice-app/store/blob"
+	brandsStore "practice-app/store/brands"
+	bundlesStore "practice-app/store/bundles"
+	categoriesStore "practice-app/store/categories"
+	currenciesStore "practice-app/store/currencies"
+	inventoryStore "practice-app/store/inventory"
+	lifecycleStore "practice-app/store/lifecycle"
+	locationsStore "practice-app/store/locations"
+	mediaStore "practice-app/store/media"
+	optionsStore "practice-app/store/options"
 	productsStore "practice-app/store/products"
+	promotionsStore "practice-app/store/promotions"
+	relationshipsStore "practice-app/store/relationships"
+	reviewsStore "practice-app/store/reviews"
+	revisionsStore "practice-app/store/revisions"
+	tagsStore "practice-app/store/tags"
+	taxStore "practice-app/store/tax"
+	translationsStore "practice-app/store/translations"
 	variantsStore "practice-app/store/variants"
 )
 
@@ -17,19 +74,195 @@ func main() {
 
 	variantStore := variantsStore.New()
 	productStore := productsStore.New(variantStore)
+	invStore := inventoryStore.New()
+	locationStore := locationsStore.New()
+	categoryStore := categoriesStore.New()
+	brandStore := brandsStore.New()
+	optionStore := optionsStore.New()
+	galleryStore := mediaStore.New()
+	fileStore := blobStore.New(app.Config.GetOrDefault("BLOB_DIR", "./data/blobs"),
+		app.Config.GetOrDefault("BLOB_BASE_URL", "/media/files"))
+	translationStore := translationsStore.New()
+	relationshipStore := relationshipsStore.New()
+	bundleStore := bundlesStore.New()
+	tagStore := tagsStore.New()
+	reviewStore := reviewsStore.New()
+	promotionStore := promotionsStore.New()
+	rateStore := taxStore.New()
+	currencyStore := currenciesStore.New()
+	transitionStore := lifecycleStore.New()
+	approvalStore := approvalsStore.New()
+	revisionStore := revisionsStore.New()
+	entryStore := auditStore.New()
 
-	productService := productsService.New(productStore, variantStore)
-	variantService := variantsService.New(variantStore)
+	productService := productsService.New(productStore, variantStore, brandStore, galleryStore, translationStore,
+		categoryStore, relationshipStore, bundleStore, currencyStore, transitionStore)
+	variantService := variantsService.New(variantStore, productStore, optionStore, galleryStore, translationStore)
+	invService := inventoryService.New(invStore, productStore, variantStore)
+	locationService := locationsService.New(locationStore, productStore, variantStore)
+	categoryService := categoriesService.New(categoryStore, productStore)
+	brandService := brandsService.New(brandStore, productStore)
+	optionService := optionsService.New(optionStore, productStore, variantStore)
+	galleryService := mediaService.New(galleryStore, productStore, variantStore, fileStore)
+	translationService := translationsService.New(translationStore, productStore, variantStore,
+		strings.Split(app.Config.GetOrDefault("REQUIRED_LOCALES", "es"), ","))
+	relationshipService := relationshipsService.New(relationshipStore, productStore)
+	bundleService := bundlesService.New(bundleStore, productStore, variantStore)
+	tagService := tagsService.New(tagStore, productStore, variantStore)
+	reviewService := reviewsService.New(reviewStore, productStore, variantStore,
+		strings.Split(app.Config.GetOrDefault("MODERATORS", ""), ","))
+	promotionService := promotionsService.New(promotionStore, productStore, variantStore, brandStore, categoryStore)
+	rateService := taxService.New(rateStore, productStore, variantStore)
+	currencyService := currenciesService.New(currencyStore)
+	approvalService := approvalsService.New(approvalStore, productStore,
+		strings.Split(app.Config.GetOrDefault("REVIEWERS", ""), ","))
+	revisionService := revisionsService.New(revisionStore, productStore)
+	entryService := auditService.New(entryStore)
 
 	productHandler := productsHandler.New(productService)
 	variantHandler := variantsHandler.New(variantService)
+	invHandler := inventoryHandler.New(invService)
+	locationHandler := locationsHandler.New(locationService)
+	categoryHandler := categoriesHandler.New(categoryService)
+	brandHandler := brandsHandler.New(brandService)
+	optionHandler := optionsHandler.New(optionService)
+	galleryHandler := mediaHandler.New(galleryService)
+	translationHandler := translationsHandler.New(translationService)
+	relationshipHandler := relationshipsHandler.New(relationshipService)
+	bundleHandler := bundlesHandler.New(bundleService)
+	tagHandler := tagsHandler.New(tagService)
+	reviewHandler := reviewsHandler.New(reviewService)
+	promotionHandler := promotionsHandler.New(promotionService)
+	rateHandler := taxHandler.New(rateService)
+	currencyHandler := currenciesHandler.New(currencyService)
+	approvalHandler := approvalsHandler.New(approvalService)
+	revisionHandler := revisionsHandler.New(revisionService)
+	entryHandler := auditHandler.New(entryService)
 
 	app.GET("/products/{id}", productHandler.GetByID)
 	app.GET("/products", productHandler.GetAll)
 	app.POST("/products", productHandler.Create)
+	app.PUT("/products/{id}/attributes", productHandler.SetAttributes)
+	app.PUT("/products/{id}/tax-class", productHandler.SetTaxClass)
+	app.PUT("/products/{id}/schedule", productHandler.SetSchedule)
+	app.PUT("/products/{id}/status", productHandler.SetStatus)
+	app.GET("/products/{id}/transitions", productHandler.GetTransitions)
+	app.PUT("/products/{pid}/variant/{id}/status", productHandler.SetVariantStatus)
 
 	app.GET("/products/{pid}/variant/{id}", variantHandler.GetByID)
 	app.POST("/products/{pid}/variant", variantHandler.Create)
+	app.GET("/variants/by-gtin/{code}", variantHandler.GetByGTIN)
+	app.PUT("/products/{pid}/variant/{id}/measurements", variantHandler.SetMeasurements)
+	app.PUT("/products/{pid}/variant/{id}/nutrition", variantHandler.SetNutrition)
+	app.PUT("/products/{pid}/variant/{id}/allergens", variantHandler.SetAllergens)
+
+	app.GET("/products/{pid}/variant/{id}/inventory", invHandler.Get)
+	app.PUT("/products/{pid}/variant/{id}/inventory", invHandler.Update)
+	app.POST("/products/{pid}/variant/{id}/inventory/reserve", invHandler.Reserve)
+	app.POST("/products/{pid}/variant/{id}/inventory/release", invHandler.Release)
+	app.POST("/products/{pid}/variant/{id}/inventory/commit", invHandler.Commit)
+
+	app.GET("/locations/{code}", locationHandler.GetByCode)
+	app.GET("/locations", locationHandler.GetAll)
+	app.POST("/locations", locationHandler.Create)
+
+	app.GET("/products/{pid}/variant/{id}/availability", locationHandler.GetAvailability)
+	app.GET("/products/{pid}/variant/{id}/availability/nearest", locationHandler.GetNearest)
+	app.PUT("/products/{pid}/variant/{id}/availability/{code}", locationHandler.SetStock)
+
+	app.GET("/categories/{id}", categoryHandler.GetByID)
+	app.GET("/categories", categoryHandler.GetAll)
+	app.POST("/categories", categoryHandler.Create)
+	app.PUT("/categories/{id}", categoryHandler.Update)
+	app.DELETE("/categories/{id}", categoryHandler.Delete)
+
+	app.GET("/products/{id}/categories", categoryHandler.GetProductCategories)
+	app.PUT("/products/{id}/categories", categoryHandler.SetProductCategories)
+	app.GET("/categories/{id}/attributes", categoryHandler.GetAttributes)
+	app.PUT("/categories/{id}/attributes", categoryHandler.SetAttributes)
+
+	app.GET("/brands/{id}", brandHandler.GetByID)
+	app.GET("/brands", brandHandler.GetAll)
+	app.POST("/brands", brandHandler.Create)
+	app.PUT("/brands/{id}", brandHandler.Update)
+	app.DELETE("/brands/{id}", brandHandler.Delete)
+	app.GET("/brands/{id}/products", brandHandler.GetProducts)
+
+	app.GET("/products/{id}/options", optionHandler.Get)
+	app.PUT("/products/{id}/options", optionHandler.Set)
+	app.POST("/products/{pid}/variant/generate", optionHandler.GenerateVariants)
+
+	app.GET("/products/{pid}/media", galleryHandler.GetByProductID)
+	app.POST("/products/{pid}/media", galleryHandler.Create)
+	app.POST("/products/{pid}/media/upload", galleryHandler.Upload)
+	app.PUT("/products/{pid}/media/{id}", galleryHandler.Update)
+	app.DELETE("/products/{pid}/media/{id}", galleryHandler.Delete)
+	app.GET("/media/files/{key}", galleryHandler.GetFile)
+
+	app.GET("/products/{id}/translations", translationHandler.Get)
+	app.PUT("/products/{id}/translations/{locale}", translationHandler.SetProduct)
+	app.DELETE("/products/{id}/translations/{locale}", translationHandler.DeleteProduct)
+	app.PUT("/products/{pid}/variant/{id}/translations/{locale}", translationHandler.SetVariant)
+	app.DELETE("/products/{pid}/variant/{id}/translations/{locale}", translationHandler.DeleteVariant)
+
+	app.GET("/products/{id}/relationships", relationshipHandler.Get)
+	app.POST("/products/{id}/relationships", relationshipHandler.Set)
+	app.DELETE("/products/{id}/relationships/{type}/{related_id}", relationshipHandler.Delete)
+
+	app.GET("/products/{id}/bundle", bundleHandler.Get)
+	app.PUT("/products/{id}/bundle", bundleHandler.Set)
+
+	app.GET("/tags", tagHandler.GetAll)
+	app.POST("/products/{id}/tags", tagHandler.AddProduct)
+	app.DELETE("/products/{id}/tags/{tag}", tagHandler.RemoveProduct)
+	app.POST("/products/{pid}/variant/{id}/tags", tagHandler.AddVariant)
+	app.DELETE("/products/{pid}/variant/{id}/tags/{tag}", tagHandler.RemoveVariant)
+
+	app.GET("/products/{id}/reviews", reviewHandler.GetByProductID)
+	app.POST("/products/{id}/reviews", reviewHandler.Create)
+	app.POST("/products/{pid}/reviews/{id}/approve", reviewHandler.Approve)
+	app.POST("/products/{pid}/reviews/{id}/reject", reviewHandler.Reject)
+	app.DELETE("/products/{pid}/reviews/{id}", reviewHandler.Delete)
+
+	app.GET("/promotions", promotionHandler.GetAll)
+	app.POST("/promotions", promotionHandler.Create)
+	app.POST("/promotions/evaluate", promotionHandler.Evaluate)
+	app.GET("/promotions/{id}", promotionHandler.GetByID)
+	app.DELETE("/promotions/{id}", promotionHandler.Delete)
+
+	app.GET("/tax/rates", rateHandler.GetRates)
+	app.POST("/tax/rates", rateHandler.CreateRate)
+	app.PUT("/tax/rates/{id}", rateHandler.UpdateRate)
+	app.DELETE("/tax/rates/{id}", rateHandler.DeleteRate)
+	app.POST("/tax/quote", rateHandler.Quote)
+
+	app.GET("/currencies", currencyHandler.GetAll)
+	app.GET("/currencies/{code}", currencyHandler.GetByCode)
+	app.PUT("/currencies/{code}", currencyHandler.Set)
+	app.DELETE("/currencies/{code}", currencyHandler.Delete)
+
+	app.POST("/products/{id}/submit", approvalHandler.Submit)
+	app.GET("/products/{id}/approvals", approvalHandler.GetByProductID)
+	app.POST("/products/{id}/preview", approvalHandler.Preview)
+	app.GET("/approvals", approvalHandler.GetQueue)
+	app.POST("/approvals/{id}/comments", approvalHandler.Comment)
+	app.POST("/approvals/{id}/approve", approvalHandler.Approve)
+	app.POST("/approvals/{id}/reject", approvalHandler.Reject)
+
+	app.GET("/products/{id}/revisions", revisionHandler.GetByProductID)
+	app.GET("/products/{id}/revisions/diff", revisionHandler.Diff)
+	app.POST("/products/{pid}/revisions/{id}/revert", revisionHandler.Revert)
+
+	app.GET("/audit", entryHandler.GetAll)
+
+	interval, err := time.ParseDuration(app.Config.GetOrDefault("SCHEDULE_INTERVAL", "1m"))
+	if err != nil || interval <= 0 {
+		app.Logger.Warnf("invalid SCHEDULE_INTERVAL, applying product schedules every minute")
+
+		interval = time.Minute
+	}
+
+	scheduler.New(transitionStore, interval).Start(krogo.NewContext(nil, nil, app))
 
 	app.Start()
 }
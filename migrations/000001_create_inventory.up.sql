@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS inventory (
+    variant_id VARCHAR(255) PRIMARY KEY,
+    on_hand    INTEGER NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved   INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= on_hand)
+);
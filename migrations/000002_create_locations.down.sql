@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS location_inventory;
+DROP TABLE IF EXISTS locations;
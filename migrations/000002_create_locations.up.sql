@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS locations (
+    code      VARCHAR(64) PRIMARY KEY,
+    name      VARCHAR(255) NOT NULL,
+    type      VARCHAR(32) NOT NULL CHECK (type IN ('store', 'warehouse')),
+    latitude  DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS location_inventory (
+    variant_id    VARCHAR(255) NOT NULL,
+    location_code VARCHAR(64) NOT NULL REFERENCES locations(code) ON DELETE CASCADE,
+    on_hand       INTEGER NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    PRIMARY KEY (variant_id, location_code)
+);
+
+CREATE INDEX IF NOT EXISTS location_inventory_location_idx ON location_inventory(location_code);
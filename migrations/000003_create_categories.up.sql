@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id        VARCHAR(64) PRIMARY KEY,
+    name      VARCHAR(255) NOT NULL,
+    parent_id VARCHAR(64) REFERENCES categories(id),
+    path      TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS categories_parent_idx ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS categories_path_idx ON categories(path text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id  VARCHAR(255) NOT NULL,
+    category_id VARCHAR(64) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_categories_category_idx ON product_categories(category_id);
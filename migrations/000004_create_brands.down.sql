@@ -0,0 +1,7 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS brand_name VARCHAR(255);
+
+UPDATE products p SET brand_name = b.name FROM brands b WHERE b.id = p.brand_id;
+
+ALTER TABLE products DROP COLUMN brand_id;
+
+DROP TABLE IF EXISTS brands;
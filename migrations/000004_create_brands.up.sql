@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS brands (
+    id              VARCHAR(255) PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL UNIQUE
+);
+
+-- Normalization matches brands.Normalize in store/brands: lower case, and every run of characters that
+-- are not letters or digits (®, ™, punctuation, whitespace) collapsed to one space. "Kroger", "kroger"
+-- and "Kroger®" all become "kroger". Each normalized name becomes one brand whose ID is the normalized
+-- name with spaces replaced by dashes, and whose display name is the most used original spelling.
+INSERT INTO brands(id, name, normalized_name)
+SELECT DISTINCT ON (normalized_name) replace(normalized_name, ' ', '-'), brand_name, normalized_name
+FROM (
+    SELECT brand_name,
+           trim(regexp_replace(lower(brand_name), '[^[:alnum:]]+', ' ', 'g')) AS normalized_name,
+           count(*) AS uses
+    FROM products
+    GROUP BY brand_name
+) spellings
+WHERE normalized_name <> ''
+ORDER BY normalized_name, uses DESC, brand_name
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS brand_id VARCHAR(255) REFERENCES brands(id);
+
+UPDATE products
+SET brand_id = replace(trim(regexp_replace(lower(brand_name), '[^[:alnum:]]+', ' ', 'g')), ' ', '-')
+WHERE trim(regexp_replace(lower(brand_name), '[^[:alnum:]]+', ' ', 'g')) <> '';
+
+CREATE INDEX IF NOT EXISTS products_brand_idx ON products(brand_id);
+
+ALTER TABLE products DROP COLUMN brand_name;
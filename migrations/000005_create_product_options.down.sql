@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS variants_option_key_idx;
+
+ALTER TABLE variants DROP COLUMN IF EXISTS option_key;
+ALTER TABLE variants DROP COLUMN IF EXISTS options;
+
+DROP TABLE IF EXISTS product_options;
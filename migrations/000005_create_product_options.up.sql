@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS product_options (
+    product_id    VARCHAR(255) NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    position      INT          NOT NULL,
+    option_values JSONB        NOT NULL,
+    PRIMARY KEY (product_id, name)
+);
+
+-- option_key is the option combination serialized with sorted keys (see variants.OptionKey), so two
+-- variants of one product can never carry the same combination. Variants without options stay NULL.
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS options JSONB;
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS option_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS variants_option_key_idx ON variants(product_id, option_key) WHERE option_key IS NOT NULL;
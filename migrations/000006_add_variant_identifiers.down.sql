@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS variants_gtin_idx;
+DROP INDEX IF EXISTS variants_sku_idx;
+
+ALTER TABLE variants DROP COLUMN IF EXISTS gtin;
+ALTER TABLE variants DROP COLUMN IF EXISTS sku;
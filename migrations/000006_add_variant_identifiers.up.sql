@@ -0,0 +1,7 @@
+-- gtin holds every barcode (UPC-A, EAN-13, GTIN-14) in its zero-padded 14-digit form, so the same item
+-- scanned as a UPC-A or as an EAN-13 resolves to one variant.
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS gtin CHAR(14);
+
+CREATE UNIQUE INDEX IF NOT EXISTS variants_sku_idx ON variants(sku) WHERE sku IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS variants_gtin_idx ON variants(gtin) WHERE gtin IS NOT NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_url VARCHAR(255);
+
+UPDATE products p SET image_url = (
+    SELECT m.url FROM media m
+    WHERE m.product_id = p.id AND m.variant_id IS NULL
+    ORDER BY m.role = 'primary' DESC, m.position, m.id
+    LIMIT 1
+);
+
+DROP TABLE IF EXISTS media;
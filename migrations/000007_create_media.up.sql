@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS media (
+    id         SERIAL PRIMARY KEY,
+    product_id VARCHAR(255) NOT NULL,
+    variant_id VARCHAR(255),
+    url        TEXT         NOT NULL,
+    alt_text   TEXT         NOT NULL DEFAULT '',
+    role       VARCHAR(16)  NOT NULL,
+    width      INT          NOT NULL DEFAULT 0,
+    height     INT          NOT NULL DEFAULT 0,
+    position   INT          NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS media_product_idx ON media(product_id, variant_id, position);
+
+-- The single image a product had becomes its primary image. products.image_url is computed from the
+-- gallery from now on (see selectQuery in store/products).
+INSERT INTO media(product_id, url, alt_text, role, position)
+SELECT id, image_url, name, 'primary', 0 FROM products WHERE image_url IS NOT NULL AND image_url <> '';
+
+ALTER TABLE products DROP COLUMN image_url;
@@ -0,0 +1,2 @@
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnails;
+ALTER TABLE media DROP COLUMN IF EXISTS blob_key;
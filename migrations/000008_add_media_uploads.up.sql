@@ -0,0 +1,2 @@
+ALTER TABLE media ADD COLUMN IF NOT EXISTS blob_key VARCHAR(255);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnails JSONB;
@@ -0,0 +1,11 @@
+-- translations holds the localized name and details of products and their variants. The text stored on
+-- products and variants themselves is the English base content. variant_id is '' for product-level rows so
+-- that it can take part in the primary key. An empty field means the field is not translated for the locale.
+CREATE TABLE IF NOT EXISTS translations (
+    product_id VARCHAR(255) NOT NULL,
+    variant_id VARCHAR(255) NOT NULL DEFAULT '',
+    locale     VARCHAR(35)  NOT NULL,
+    name       TEXT         NOT NULL DEFAULT '',
+    details    TEXT         NOT NULL DEFAULT '',
+    PRIMARY KEY (product_id, variant_id, locale)
+);
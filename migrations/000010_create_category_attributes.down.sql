@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS products_attributes_idx;
+
+ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+
+DROP TABLE IF EXISTS category_attributes;
@@ -0,0 +1,16 @@
+-- category_attributes declares the structured attributes products of a category carry. A category inherits
+-- the attributes of its ancestors; a definition on a deeper category overrides one of the same name above it.
+CREATE TABLE IF NOT EXISTS category_attributes (
+    category_id VARCHAR(64)  NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    name        VARCHAR(64)  NOT NULL,
+    type        VARCHAR(16)  NOT NULL,
+    required    BOOLEAN      NOT NULL DEFAULT FALSE,
+    enum_values JSONB,
+    unit        VARCHAR(32)  NOT NULL DEFAULT '',
+    position    INT          NOT NULL,
+    PRIMARY KEY (category_id, name)
+);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS products_attributes_idx ON products USING GIN (attributes);
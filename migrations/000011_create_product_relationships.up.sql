@@ -0,0 +1,12 @@
+-- product_relationships links a product to another one, e.g. an accessory or the product replacing it.
+-- Links are directional: (A, B, 'accessory') says B is an accessory of A, not the other way round.
+CREATE TABLE IF NOT EXISTS product_relationships (
+    product_id VARCHAR(255) NOT NULL,
+    related_id VARCHAR(255) NOT NULL,
+    type       VARCHAR(32)  NOT NULL,
+    position   INT          NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, type, related_id),
+    CHECK (product_id <> related_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_relationships_related_idx ON product_relationships(related_id);
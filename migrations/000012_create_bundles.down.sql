@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bundle_components;
+DROP TABLE IF EXISTS bundles;
+
+ALTER TABLE products DROP COLUMN IF EXISTS type;
+ALTER TABLE variants DROP COLUMN IF EXISTS price_cents;
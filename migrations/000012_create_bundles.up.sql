@@ -0,0 +1,23 @@
+-- Variants get a unit price in cents. Bundles price themselves from it when they are not sold at a fixed price.
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS price_cents BIGINT CHECK (price_cents >= 0);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'standard';
+
+-- A bundle is a product of type 'bundle' made of other products' variants. It has no stock of its own:
+-- its availability follows from the stock of its components.
+CREATE TABLE IF NOT EXISTS bundles (
+    product_id     VARCHAR(255) PRIMARY KEY,
+    pricing        VARCHAR(8)   NOT NULL,
+    price_cents    BIGINT       NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+    discount_cents BIGINT       NOT NULL DEFAULT 0 CHECK (discount_cents >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS bundle_components (
+    bundle_id  VARCHAR(255) NOT NULL REFERENCES bundles(product_id) ON DELETE CASCADE,
+    variant_id VARCHAR(255) NOT NULL,
+    quantity   INT          NOT NULL CHECK (quantity > 0),
+    position   INT          NOT NULL,
+    PRIMARY KEY (bundle_id, variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS bundle_components_variant_idx ON bundle_components(variant_id);
@@ -0,0 +1,15 @@
+-- The tag vocabulary. Names are stored normalized (lower case words joined by hyphens), so "Gluten Free"
+-- and "gluten-free" are the same tag.
+CREATE TABLE IF NOT EXISTS tags (
+    name VARCHAR(64) PRIMARY KEY
+);
+
+-- Tags of products (variant_id '') and of their variants.
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id VARCHAR(255) NOT NULL,
+    variant_id VARCHAR(255) NOT NULL DEFAULT '',
+    tag        VARCHAR(64)  NOT NULL REFERENCES tags(name),
+    PRIMARY KEY (product_id, variant_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_idx ON product_tags(tag);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS products_rating_idx;
+
+ALTER TABLE products DROP COLUMN IF EXISTS rating_average;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_sum;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_count;
+
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id         SERIAL PRIMARY KEY,
+    product_id VARCHAR(255)  NOT NULL,
+    variant_id VARCHAR(255)  NOT NULL DEFAULT '',
+    rating     SMALLINT      NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title      VARCHAR(200)  NOT NULL,
+    body       TEXT          NOT NULL,
+    status     VARCHAR(16)   NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS reviews_product_status_idx ON reviews(product_id, status, created_at DESC);
+
+-- Running totals of the approved reviews of each product, kept up to date as reviews are moderated and deleted
+-- so that reading the average never has to scan the reviews.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_average NUMERIC(3, 2) GENERATED ALWAYS AS
+    (CASE WHEN rating_count > 0 THEN round(rating_sum::numeric / rating_count, 2) ELSE 0 END) STORED;
+
+CREATE INDEX IF NOT EXISTS products_rating_idx ON products(rating_average DESC, rating_count DESC);
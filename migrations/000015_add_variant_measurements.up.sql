@@ -0,0 +1,2 @@
+-- Net weight, volume and size of a variant, each stored as {"value": 16, "unit": "oz"} under its name.
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS measurements JSONB;
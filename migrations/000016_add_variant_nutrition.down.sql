@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS variants_allergens_idx;
+
+ALTER TABLE variants DROP COLUMN IF EXISTS allergens;
+ALTER TABLE variants DROP COLUMN IF EXISTS nutrition;
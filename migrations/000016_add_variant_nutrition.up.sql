@@ -0,0 +1,7 @@
+-- The nutrition facts panel of a variant, per serving. Percent daily values are computed when it is read.
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS nutrition JSONB;
+
+-- The major food allergens a variant contains, as a JSON array of names such as ["milk","peanut"].
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS allergens JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS variants_allergens_idx ON variants USING GIN (allergens);
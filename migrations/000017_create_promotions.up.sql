@@ -0,0 +1,19 @@
+-- Promotions discount the variants they target while they run. Only the fields of a promotion's type are set;
+-- targets is a JSON array of {"type": "brand", "id": "acme"} objects.
+CREATE TABLE IF NOT EXISTS promotions (
+    id               SERIAL PRIMARY KEY,
+    name             VARCHAR(255) NOT NULL,
+    type             VARCHAR(16)  NOT NULL CHECK (type IN ('percent_off', 'amount_off', 'buy_x_get_y', 'multi_buy')),
+    percent_off      INT          NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    amount_off_cents BIGINT       NOT NULL DEFAULT 0 CHECK (amount_off_cents >= 0),
+    buy_quantity     INT          NOT NULL DEFAULT 0,
+    get_quantity     INT          NOT NULL DEFAULT 0,
+    price_cents      BIGINT       NOT NULL DEFAULT 0,
+    targets          JSONB        NOT NULL DEFAULT '[]',
+    starts_at        TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    ends_at          TIMESTAMPTZ,
+    priority         INT          NOT NULL DEFAULT 0,
+    exclusive        BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS promotions_window_idx ON promotions(starts_at, ends_at);
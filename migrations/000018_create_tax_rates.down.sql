@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tax_rates;
+
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
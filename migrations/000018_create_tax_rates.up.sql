@@ -0,0 +1,16 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_class VARCHAR(16) NOT NULL DEFAULT 'standard'
+    CHECK (tax_class IN ('standard', 'food', 'exempt'));
+
+-- Tax rates, in percent, of the taxed classes per region (e.g. US-OH). The periods of the rates of a class in a
+-- region do not overlap, so at most one of them is in effect at any time.
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id             SERIAL PRIMARY KEY,
+    region         VARCHAR(16)  NOT NULL,
+    tax_class      VARCHAR(16)  NOT NULL CHECK (tax_class IN ('standard', 'food')),
+    rate           NUMERIC(7,4) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    effective_from TIMESTAMPTZ  NOT NULL,
+    effective_to   TIMESTAMPTZ,
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS tax_rates_region_idx ON tax_rates(region, tax_class, effective_from);
@@ -0,0 +1,9 @@
+-- Exchange rates from USD, the currency prices are stored in, to the currencies prices can be shown in. Converted
+-- prices are rounded to a multiple of increment minor units, half up, up or down.
+CREATE TABLE IF NOT EXISTS currency_rates (
+    currency   CHAR(3)       PRIMARY KEY,
+    rate       NUMERIC(18,8) NOT NULL CHECK (rate > 0),
+    rounding   VARCHAR(8)    NOT NULL DEFAULT 'half_up' CHECK (rounding IN ('half_up', 'up', 'down')),
+    increment  INT           NOT NULL DEFAULT 1 CHECK (increment > 0),
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS status_transitions;
+
+ALTER TABLE variants DROP COLUMN IF EXISTS status;
+
+ALTER TABLE products DROP COLUMN IF EXISTS status;
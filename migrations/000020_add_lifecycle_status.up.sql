@@ -0,0 +1,22 @@
+-- Existing products and variants were live, so they start out active.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('draft', 'active', 'discontinued', 'archived'));
+
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('draft', 'active', 'discontinued', 'archived'));
+
+CREATE INDEX IF NOT EXISTS products_status_idx ON products(status);
+
+-- Every change of status of a product, or of one of its variants when variant_id is set.
+CREATE TABLE IF NOT EXISTS status_transitions (
+    id          SERIAL PRIMARY KEY,
+    product_id  VARCHAR(255) NOT NULL,
+    variant_id  VARCHAR(255),
+    from_status VARCHAR(16)  NOT NULL,
+    to_status   VARCHAR(16)  NOT NULL,
+    actor       VARCHAR(255) NOT NULL,
+    reason      TEXT         NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS status_transitions_product_idx ON status_transitions(product_id, created_at);
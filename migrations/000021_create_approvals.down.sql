@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS preview_tokens;
+
+DROP TABLE IF EXISTS approval_comments;
+
+DROP TABLE IF EXISTS approvals;
+
+UPDATE products SET status = 'draft' WHERE status = 'in_review';
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'active', 'discontinued', 'archived'));
+
+ALTER TABLE products DROP COLUMN IF EXISTS owner;
@@ -0,0 +1,36 @@
+-- Products created before reviews were required have no owner.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS owner VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'in_review', 'active', 'discontinued', 'archived'));
+
+CREATE TABLE IF NOT EXISTS approvals (
+    id           SERIAL PRIMARY KEY,
+    product_id   VARCHAR(255) NOT NULL,
+    state        VARCHAR(16)  NOT NULL DEFAULT 'pending' CHECK (state IN ('pending', 'approved', 'rejected')),
+    submitted_by VARCHAR(255) NOT NULL,
+    submitted_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    reviewer     VARCHAR(255),
+    decided_at   TIMESTAMPTZ
+);
+
+-- A product waits in the queue once, however many times it is submitted.
+CREATE UNIQUE INDEX IF NOT EXISTS approvals_pending_idx ON approvals(product_id) WHERE state = 'pending';
+
+CREATE TABLE IF NOT EXISTS approval_comments (
+    id          SERIAL PRIMARY KEY,
+    approval_id INTEGER      NOT NULL REFERENCES approvals(id) ON DELETE CASCADE,
+    author      VARCHAR(255) NOT NULL,
+    body        TEXT         NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS approval_comments_approval_idx ON approval_comments(approval_id, created_at);
+
+CREATE TABLE IF NOT EXISTS preview_tokens (
+    token      VARCHAR(64)  PRIMARY KEY,
+    product_id VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL DEFAULT now() + INTERVAL '24 hours'
+);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS products_unpublish_at_idx;
+
+DROP INDEX IF EXISTS products_publish_at_idx;
+
+UPDATE products SET status = 'in_review' WHERE status = 'scheduled';
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'in_review', 'active', 'discontinued', 'archived'));
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_schedule_check;
+
+ALTER TABLE products DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,13 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+
+ALTER TABLE products ADD CONSTRAINT products_schedule_check
+    CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'in_review', 'scheduled', 'active', 'discontinued', 'archived'));
+
+-- The scheduler looks for products waiting to go live and live products due to be taken down.
+CREATE INDEX IF NOT EXISTS products_publish_at_idx ON products(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS products_unpublish_at_idx ON products(unpublish_at) WHERE status = 'active';
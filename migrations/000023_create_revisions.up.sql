@@ -0,0 +1,26 @@
+-- A copy of a product, or of one of its variants when variant_id is set, after each change to it.
+CREATE TABLE IF NOT EXISTS revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    product_id VARCHAR(255) NOT NULL,
+    variant_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor      VARCHAR(255) NOT NULL DEFAULT '',
+    snapshot   JSONB        NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS revisions_product_idx ON revisions(product_id, variant_id, created_at);
+
+-- Revisions are history: they are only ever added.
+CREATE OR REPLACE FUNCTION revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'revisions cannot be changed or removed';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS revisions_immutable ON revisions;
+CREATE TRIGGER revisions_immutable BEFORE UPDATE OR DELETE ON revisions
+    FOR EACH ROW EXECUTE FUNCTION revisions_immutable();
+
+-- Existing products and variants start their history as they are now.
+INSERT INTO revisions(product_id, snapshot) SELECT p.id, to_jsonb(p) FROM products p;
+INSERT INTO revisions(product_id, variant_id, snapshot) SELECT v.product_id, v.id, to_jsonb(v) FROM variants v;
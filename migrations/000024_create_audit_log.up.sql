@@ -0,0 +1,29 @@
+-- Who changed which product or variant, when and how, for compliance. Each entry is written in the transaction of the
+-- change it records, next to the revision holding the result.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    action      VARCHAR(32)  NOT NULL,
+    entity      VARCHAR(32)  NOT NULL,
+    entity_id   VARCHAR(255) NOT NULL,
+    product_id  VARCHAR(255) NOT NULL,
+    revision_id BIGINT       NOT NULL REFERENCES revisions(id),
+    actor       VARCHAR(255) NOT NULL DEFAULT '',
+    request_id  VARCHAR(255) NOT NULL DEFAULT '',
+    before      JSONB,
+    after       JSONB        NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log(entity, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log(actor, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_created_idx ON audit_log(created_at);
+
+CREATE OR REPLACE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log entries cannot be changed or removed';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_immutable ON audit_log;
+CREATE TRIGGER audit_log_immutable BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
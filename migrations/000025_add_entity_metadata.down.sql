@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS variants_metadata ON variants;
+DROP TRIGGER IF EXISTS products_metadata ON products;
+
+DROP FUNCTION IF EXISTS touch_metadata();
+
+DROP INDEX IF EXISTS variants_updated_at_idx;
+DROP INDEX IF EXISTS products_created_at_idx;
+DROP INDEX IF EXISTS products_updated_at_idx;
+
+ALTER TABLE variants DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE variants DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE variants DROP COLUMN IF EXISTS created_by;
+ALTER TABLE variants DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE products DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE products DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE products DROP COLUMN IF EXISTS created_by;
+ALTER TABLE products DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,56 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE products ADD COLUMN IF NOT EXISTS created_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE products ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS created_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Existing products and variants take their times and actors from their first and latest revisions.
+UPDATE products p SET created_at = r.first_at, created_by = r.first_by, updated_at = r.last_at, updated_by = r.last_by
+FROM (SELECT DISTINCT product_id,
+             first_value(created_at) OVER w AS first_at, first_value(actor) OVER w AS first_by,
+             last_value(created_at) OVER w AS last_at, last_value(actor) OVER w AS last_by
+      FROM revisions WHERE variant_id = ''
+      WINDOW w AS (PARTITION BY product_id ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)) r
+WHERE r.product_id = p.id;
+
+UPDATE variants v SET created_at = r.first_at, created_by = r.first_by, updated_at = r.last_at, updated_by = r.last_by
+FROM (SELECT DISTINCT product_id, variant_id,
+             first_value(created_at) OVER w AS first_at, first_value(actor) OVER w AS first_by,
+             last_value(created_at) OVER w AS last_at, last_value(actor) OVER w AS last_by
+      FROM revisions WHERE variant_id <> ''
+      WINDOW w AS (PARTITION BY product_id, variant_id ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)) r
+WHERE r.product_id = v.product_id AND r.variant_id = v.id;
+
+CREATE INDEX IF NOT EXISTS products_updated_at_idx ON products(updated_at);
+CREATE INDEX IF NOT EXISTS products_created_at_idx ON products(created_at);
+CREATE INDEX IF NOT EXISTS variants_updated_at_idx ON variants(product_id, updated_at);
+
+-- Times are the database's own and creations cannot be rewritten; only updated_by is left to each change to set.
+-- Any change counts as a modification, including rating updates from reviews, which keep the last editor.
+CREATE OR REPLACE FUNCTION touch_metadata() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_at := now();
+        NEW.updated_by := NEW.created_by;
+    ELSE
+        NEW.created_at := OLD.created_at;
+        NEW.created_by := OLD.created_by;
+    END IF;
+
+    NEW.updated_at := now();
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_metadata ON products;
+CREATE TRIGGER products_metadata BEFORE INSERT OR UPDATE ON products
+    FOR EACH ROW EXECUTE FUNCTION touch_metadata();
+
+DROP TRIGGER IF EXISTS variants_metadata ON variants;
+CREATE TRIGGER variants_metadata BEFORE INSERT OR UPDATE ON variants
+    FOR EACH ROW EXECUTE FUNCTION touch_metadata();
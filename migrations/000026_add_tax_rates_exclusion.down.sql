@@ -0,0 +1 @@
+ALTER TABLE tax_rates DROP CONSTRAINT IF EXISTS tax_rates_no_overlap;
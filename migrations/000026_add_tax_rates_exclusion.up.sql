@@ -0,0 +1,9 @@
+-- The periods of the rates of a class in a region must not overlap. The service checks this before writing, and the
+-- constraint keeps two concurrent writes from both passing that check.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE tax_rates ADD CONSTRAINT tax_rates_no_overlap EXCLUDE USING gist (
+    region WITH =,
+    tax_class WITH =,
+    tstzrange(effective_from, effective_to, '[)') WITH &&
+);
@@ -0,0 +1 @@
+ALTER TABLE audit_log ALTER COLUMN revision_id SET NOT NULL;
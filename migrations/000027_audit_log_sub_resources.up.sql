@@ -0,0 +1,3 @@
+-- Changes to what belongs to a product, such as its tags, media or stock, are audited too. They are not kept as
+-- revisions, so their entries hold the rows they changed, before and after, without a revision.
+ALTER TABLE audit_log ALTER COLUMN revision_id DROP NOT NULL;
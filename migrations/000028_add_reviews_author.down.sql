@@ -0,0 +1 @@
+ALTER TABLE reviews DROP COLUMN IF EXISTS author;
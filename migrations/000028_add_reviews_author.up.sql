@@ -0,0 +1,3 @@
+-- Reviews keep who wrote them, who can delete them along with moderators. Reviews written before have no author
+-- and can only be deleted by moderators.
+ALTER TABLE reviews ADD COLUMN author VARCHAR(255) NOT NULL DEFAULT '';
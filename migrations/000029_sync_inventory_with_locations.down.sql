@@ -0,0 +1 @@
+-- The on-hand quantities set from the stock at locations are kept, as the ones they replaced are gone.
@@ -0,0 +1,5 @@
+-- The on-hand quantity of a variant stocked by location is the sum of its stock at every location. Reservations
+-- above that sum are cut down to it, as the stock they held is no longer there.
+INSERT INTO inventory(variant_id, on_hand, reserved)
+SELECT variant_id, SUM(on_hand), 0 FROM location_inventory GROUP BY variant_id
+ON CONFLICT (variant_id) DO UPDATE SET on_hand=EXCLUDED.on_hand, reserved=LEAST(inventory.reserved, EXCLUDED.on_hand);
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// The states of a request to publish a draft. A product has at most one pending request at a time.
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+)
+
+// Approval is a request to publish a draft product, from its submission by the owner to a reviewer's decision.
+type Approval struct {
+	ID          int        `json:"id"`
+	ProductID   string     `json:"product_id"`
+	ProductName string     `json:"product_name,omitempty"`
+	State       string     `json:"state"`
+	SubmittedBy string     `json:"submitted_by"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	Reviewer    string     `json:"reviewer,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+
+	Comments []ApprovalComment `json:"comments,omitempty"`
+}
+
+// ApprovalComment is a note left on an approval request by its submitter or a reviewer.
+type ApprovalComment struct {
+	ID         int       `json:"id"`
+	ApprovalID int       `json:"approval_id"`
+	Author     string    `json:"author"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Decision is the body of requests approving or rejecting a product; rejections must say why.
+type Decision struct {
+	Comment string `json:"comment"`
+}
+
+// PreviewToken lets whoever holds it read a product that is not published yet, until it expires.
+type PreviewToken struct {
+	Token     string    `json:"token"`
+	ProductID string    `json:"product_id"`
+	CreatedBy string    `json:"created_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
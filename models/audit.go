@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// RequestIDHeader carries the ID of a request, set by the gateway, so that audit entries can be traced back to it.
+const RequestIDHeader = "X-Request-ID"
+
+// The changes recorded in the audit log.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditStatus = "status"
+	AuditRevert = "revert"
+	AuditDelete = "delete"
+)
+
+// AuditEntry records a change to a product, to one of its variants when Entity is "variants", or to something that
+// belongs to them, such as their tags or stock: who made it, in which request, and what changed before and after it.
+// Before is null for creations. Only changes to products and variants have a revision.
+type AuditEntry struct {
+	ID         int                    `json:"id"`
+	Action     string                 `json:"action"`
+	Entity     string                 `json:"entity"`
+	EntityID   string                 `json:"entity_id"`
+	ProductID  string                 `json:"product_id"`
+	RevisionID int                    `json:"revision_id,omitempty"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type Brand struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
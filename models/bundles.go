@@ -0,0 +1,36 @@
+package models
+
+// The types of product. A bundle is sold as one item but is made of variants of other products.
+const (
+	ProductStandard = "standard"
+	ProductBundle   = "bundle"
+)
+
+// How a bundle is priced: at a fixed price, or at the sum of its components' prices minus a discount.
+const (
+	BundlePricingFixed = "fixed"
+	BundlePricingSum   = "sum"
+)
+
+// BundleComponent is a variant included in a bundle. ProductID, Name, PriceCents, Available and Status describe the
+// variant and are filled in on reads; Status is the one of its product when the product is not active.
+type BundleComponent struct {
+	VariantID  string `json:"variant_id"`
+	Quantity   int    `json:"quantity"`
+	ProductID  string `json:"product_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	PriceCents int64  `json:"price_cents,omitempty"`
+	Available  int    `json:"available"`
+	Status     string `json:"status,omitempty"`
+}
+
+// Bundle lists the components of a bundle product and how it is priced. For sum pricing PriceCents is computed
+// on reads, and left at zero while any component has no price. Available is the number of complete bundles
+// the components' stock can make up.
+type Bundle struct {
+	Components    []BundleComponent `json:"components"`
+	Pricing       string            `json:"pricing"`
+	PriceCents    int64             `json:"price_cents,omitempty"`
+	DiscountCents int64             `json:"discount_cents,omitempty"`
+	Available     int               `json:"available"`
+}
@@ -0,0 +1,37 @@
+package models
+
+type Category struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	ParentID string     `json:"parent_id,omitempty"`
+	Path     string     `json:"path"`
+	Children []Category `json:"children,omitempty"`
+}
+
+type ProductCategories struct {
+	CategoryIDs []string `json:"category_ids"`
+}
+
+// The types an attribute value can have. Enum values are strings restricted to the definition's Values.
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeInteger = "integer"
+	AttributeBoolean = "boolean"
+	AttributeEnum    = "enum"
+)
+
+// AttributeDefinition declares a structured attribute of the products in a category, e.g. wattage for
+// appliances. CategoryID is the category the definition comes from, which may be an ancestor.
+type AttributeDefinition struct {
+	CategoryID string   `json:"category_id,omitempty"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Required   bool     `json:"required"`
+	Values     []string `json:"values,omitempty"`
+	Unit       string   `json:"unit,omitempty"`
+}
+
+type CategoryAttributes struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// BaseCurrency is the currency prices are stored in.
+const BaseCurrency = "USD"
+
+// The rounding rules of converted prices.
+const (
+	RoundHalfUp = "half_up"
+	RoundUp     = "up"
+	RoundDown   = "down"
+)
+
+// CurrencyRate converts prices from the base currency: one USD is Rate units of Currency. Converted prices are
+// rounded with Rounding to a multiple of Increment minor units, e.g. 5 to show MXN prices in 5 centavo steps.
+type CurrencyRate struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	Rounding  string    `json:"rounding"`
+	Increment int64     `json:"increment"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Conversion tells which rate, set at what time, the prices of a product were converted with.
+type Conversion struct {
+	Currency      string    `json:"currency"`
+	Rate          float64   `json:"rate"`
+	RateUpdatedAt time.Time `json:"rate_updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type Inventory struct {
+	VariantID string `json:"variant_id"`
+	OnHand    int    `json:"on_hand"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+}
+
+type StockAdjustment struct {
+	Quantity int `json:"quantity"`
+	// Location is the code of the location a committed quantity ships from, for variants stocked by location.
+	Location string `json:"location,omitempty"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// The lifecycle statuses of products and variants. Only active products are listed; archived ones are kept for
+// history and never come back. Drafts go live through review, which only products go through, and approved
+// products with a publication time wait for it as scheduled.
+const (
+	StatusDraft        = "draft"
+	StatusInReview     = "in_review"
+	StatusScheduled    = "scheduled"
+	StatusActive       = "active"
+	StatusDiscontinued = "discontinued"
+	StatusArchived     = "archived"
+)
+
+// UserHeader carries the ID of the user making a request, set by the gateway that authenticates users.
+const UserHeader = "X-User-ID"
+
+// StatusChange is the body of requests moving a product or a variant to another status.
+type StatusChange struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// Schedule sets when a product goes live and when it is taken down again; either can be left out.
+type Schedule struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// Transition records a change of status of a product, or of one of its variants when VariantID is set, with who
+// made it and why.
+type Transition struct {
+	ID        int       `json:"id"`
+	ProductID string    `json:"product_id"`
+	VariantID string    `json:"variant_id,omitempty"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+type Location struct {
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type LocationStock struct {
+	Location
+	VariantID  string   `json:"variant_id"`
+	OnHand     int      `json:"on_hand"`
+	DistanceKM *float64 `json:"distance_km,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Measurement is a quantity with its unit of measure, e.g. 16 oz or 1.5 l.
+type Measurement struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// Measurements are the physical size and contents of a variant, for shipping and unit pricing.
+type Measurements struct {
+	NetWeight *Measurement `json:"net_weight,omitempty"`
+	Volume    *Measurement `json:"volume,omitempty"`
+	Length    *Measurement `json:"length,omitempty"`
+	Width     *Measurement `json:"width,omitempty"`
+	Height    *Measurement `json:"height,omitempty"`
+}
+
+// UnitPrice is the price of one unit of a variant's contents, in cents, e.g. 21.81 cents per oz.
+type UnitPrice struct {
+	Cents float64 `json:"cents"`
+	Per   string  `json:"per"`
+}
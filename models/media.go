@@ -0,0 +1,24 @@
+package models
+
+// Media is one image in the gallery of a product, or of one of its variants when VariantID is set.
+type Media struct {
+	ID         int         `json:"id"`
+	ProductID  string      `json:"product_id"`
+	VariantID  string      `json:"variant_id,omitempty"`
+	URL        string      `json:"url"`
+	AltText    string      `json:"alt_text"`
+	Role       string      `json:"role"`
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	Position   int         `json:"position"`
+	Thumbnails []Thumbnail `json:"thumbnails,omitempty"`
+
+	// BlobKey names the uploaded original in the blob store. It is empty for images hosted elsewhere.
+	BlobKey string `json:"-"`
+}
+
+type Thumbnail struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// Nutrition is the nutrition facts panel of a food variant, per serving.
+type Nutrition struct {
+	ServingSize          Measurement `json:"serving_size"`
+	ServingsPerContainer float64     `json:"servings_per_container,omitempty"`
+	Calories             int         `json:"calories"`
+	Nutrients            []Nutrient  `json:"nutrients,omitempty"`
+}
+
+// Nutrient is the amount of a nutrient in a serving. DailyValue is the percent of the daily value it covers,
+// computed on reads for the nutrients that have a daily value.
+type Nutrient struct {
+	Name       string  `json:"name"`
+	Amount     float64 `json:"amount"`
+	Unit       string  `json:"unit"`
+	DailyValue *int    `json:"daily_value,omitempty"`
+}
+
+// Allergens is the list of major food allergens a variant contains, and the body of requests declaring them.
+type Allergens struct {
+	Allergens []string `json:"allergens"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// OptionAxis is one dimension a product's variants vary along, e.g. Size with the values S, M and L.
+type OptionAxis struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type ProductOptions struct {
+	Options []OptionAxis `json:"options"`
+}
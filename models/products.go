@@ -1,24 +1,90 @@
 package models
 
+import "time"
+
 type Product struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
+	BrandID   string `json:"brand_id"`
 	BrandName string `json:"brand_name"`
 	Details   string `json:"details"`
 	ImageUrl  string `json:"image_url"`
+	Type      string `json:"type,omitempty"`
+	TaxClass  string `json:"tax_class,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+
+	// PublishAt and UnpublishAt schedule when the product goes live once approved, and when it is taken down.
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+
+	// CategoryIDs assigns the product to categories on creation; Attributes are checked against their schema.
+	CategoryIDs []string               `json:"category_ids,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+
+	// CreatedAt and UpdatedAt are kept by the database, along with who created the product and who last changed it.
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
 }
 
 type ProductWithVariants struct {
 	ID        string        `json:"id"`
 	Name      string        `json:"name"`
+	BrandID   string        `json:"brand_id"`
 	BrandName string        `json:"brand_name"`
 	Details   string        `json:"details"`
 	ImageUrl  string        `json:"image_url"`
+	Type      string        `json:"type,omitempty"`
+	TaxClass  string        `json:"tax_class,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	Owner     string        `json:"owner,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
 	Variant   []VariantInfo `json:"variant,omitempty"`
+	Media     []Media       `json:"media,omitempty"`
+
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Rating     Rating                 `json:"rating"`
+	Related    []Relationship         `json:"related,omitempty"`
+	Bundle     *Bundle                `json:"bundle,omitempty"`
+
+	// Conversion is set when prices were converted into another currency with currency=; they are in its minor units.
+	Conversion *Conversion `json:"conversion,omitempty"`
+
+	// CreatedAt and UpdatedAt are kept by the database, along with who created the product and who last changed it.
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
 }
 
 type VariantInfo struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Details string `json:"details"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Details   string `json:"details"`
+	SKU       string `json:"sku,omitempty"`
+	GTIN      string `json:"gtin,omitempty"`
+	Available int    `json:"available"`
+	Status    string `json:"status,omitempty"`
+
+	PriceCents   int64         `json:"price_cents,omitempty"`
+	Measurements *Measurements `json:"measurements,omitempty"`
+	UnitPrice    *UnitPrice    `json:"unit_price,omitempty"`
+
+	Nutrition *Nutrition `json:"nutrition,omitempty"`
+	Allergens []string   `json:"allergens,omitempty"`
+
+	Options map[string]string `json:"options,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Media   []Media           `json:"media,omitempty"`
+
+	// CreatedAt and UpdatedAt are kept by the database, along with who created the variant and who last changed it.
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
 }
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// The kinds of promotion. Buy X get Y discounts Y of every X+Y units, by PercentOff or free when it is 0;
+// multi buy sells every BuyQuantity units for PriceCents, e.g. 3 for $5.
+const (
+	PromotionPercentOff = "percent_off"
+	PromotionAmountOff  = "amount_off"
+	PromotionBuyXGetY   = "buy_x_get_y"
+	PromotionMultiBuy   = "multi_buy"
+)
+
+// What a promotion can target. A category target also covers the categories below it.
+const (
+	TargetProduct  = "product"
+	TargetVariant  = "variant"
+	TargetBrand    = "brand"
+	TargetCategory = "category"
+)
+
+type Promotion struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	PercentOff     int   `json:"percent_off,omitempty"`
+	AmountOffCents int64 `json:"amount_off_cents,omitempty"`
+	BuyQuantity    int   `json:"buy_quantity,omitempty"`
+	GetQuantity    int   `json:"get_quantity,omitempty"`
+	PriceCents     int64 `json:"price_cents,omitempty"`
+
+	Targets []PromotionTarget `json:"targets"`
+
+	// A promotion runs from StartsAt until EndsAt, or indefinitely without an end.
+	StartsAt time.Time  `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+
+	// Priority orders the promotions applied to the same items, highest first. An exclusive promotion is not
+	// combined with any other on the items it discounts.
+	Priority  int  `json:"priority"`
+	Exclusive bool `json:"exclusive"`
+}
+
+type PromotionTarget struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// EvaluationRequest lists the variants to price, optionally at another time than now.
+type EvaluationRequest struct {
+	Items []EvaluationItem `json:"items"`
+	At    *time.Time       `json:"at,omitempty"`
+}
+
+type EvaluationItem struct {
+	VariantID string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// Evaluation is the result of applying the running promotions to a list of variants.
+type Evaluation struct {
+	Items      []EvaluatedItem    `json:"items"`
+	Promotions []AppliedPromotion `json:"promotions"`
+
+	SubtotalCents int64 `json:"subtotal_cents"`
+	DiscountCents int64 `json:"discount_cents"`
+	TotalCents    int64 `json:"total_cents"`
+}
+
+type EvaluatedItem struct {
+	VariantID      string `json:"variant_id"`
+	ProductID      string `json:"product_id"`
+	Quantity       int    `json:"quantity"`
+	UnitPriceCents int64  `json:"unit_price_cents"`
+	SubtotalCents  int64  `json:"subtotal_cents"`
+	DiscountCents  int64  `json:"discount_cents"`
+	TotalCents     int64  `json:"total_cents"`
+	Promotions     []int  `json:"promotions,omitempty"`
+}
+
+// AppliedPromotion is a promotion that discounted at least one item, with its discount over all items.
+type AppliedPromotion struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	DiscountCents int64  `json:"discount_cents"`
+}
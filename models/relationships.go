@@ -0,0 +1,18 @@
+package models
+
+// The kinds of links a product can have to other products.
+const (
+	RelationshipRelated    = "related"
+	RelationshipAccessory  = "accessory"
+	RelationshipReplacedBy = "replaced_by"
+	RelationshipUpSell     = "up_sell"
+)
+
+// Relationship is a directional link from a product to a related one. Links of one type are listed by Position.
+type Relationship struct {
+	ProductID   string `json:"product_id"`
+	RelatedID   string `json:"related_id"`
+	RelatedName string `json:"related_name,omitempty"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// The moderation states of a review. Reviews start out pending; only approved ones are shown and rated.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+type Review struct {
+	ID        int       `json:"id"`
+	ProductID string    `json:"product_id"`
+	VariantID string    `json:"variant_id,omitempty"`
+	Rating    int       `json:"rating"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Status    string    `json:"status"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Rating is the average and number of the approved reviews of a product.
+type Rating struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
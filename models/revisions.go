@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Revision is a copy of a product, or of one of its variants when VariantID is set, as it was after a change.
+// Snapshot holds its columns as stored; revisions are never changed once made.
+type Revision struct {
+	ID        int                    `json:"id"`
+	ProductID string                 `json:"product_id"`
+	VariantID string                 `json:"variant_id,omitempty"`
+	Actor     string                 `json:"actor"`
+	Snapshot  map[string]interface{} `json:"snapshot"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// RevisionDiff lists the fields that differ between two revisions of the same product or variant.
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange is a field of a revision diff with its value in both revisions; a value missing from one is null.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
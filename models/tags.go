@@ -0,0 +1,13 @@
+package models
+
+// Tag is an entry of the tag vocabulary with the number of products and variants carrying it.
+type Tag struct {
+	Name     string `json:"name"`
+	Products int    `json:"products"`
+	Variants int    `json:"variants"`
+}
+
+// Tags is the list of tags of a product or variant, and the body of requests adding tags to one.
+type Tags struct {
+	Tags []string `json:"tags"`
+}
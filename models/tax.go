@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// The tax classes of products. Exempt products are never taxed; the others are taxed at the rate of their class
+// in the region of the sale.
+const (
+	TaxStandard = "standard"
+	TaxFood     = "food"
+	TaxExempt   = "exempt"
+)
+
+// ProductTaxClass is the body of requests changing the tax class of a product.
+type ProductTaxClass struct {
+	TaxClass string `json:"tax_class"`
+}
+
+// TaxRate is the rate, in percent, of a tax class in a region from EffectiveFrom until EffectiveTo, or
+// indefinitely without an end.
+type TaxRate struct {
+	ID            int        `json:"id"`
+	Region        string     `json:"region"`
+	TaxClass      string     `json:"tax_class"`
+	Rate          float64    `json:"rate"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+}
+
+// TaxQuoteRequest lists the variants of a sale in a region, optionally at another time than now. An item's
+// unit price defaults to the variant's price, and can be given to quote discounted prices.
+type TaxQuoteRequest struct {
+	Region string         `json:"region"`
+	At     *time.Time     `json:"at,omitempty"`
+	Items  []TaxQuoteItem `json:"items"`
+}
+
+type TaxQuoteItem struct {
+	VariantID      string `json:"variant_id"`
+	Quantity       int    `json:"quantity"`
+	UnitPriceCents *int64 `json:"unit_price_cents,omitempty"`
+}
+
+// TaxQuote is the tax of each line of a sale and of the sale as a whole, which is the sum of its lines.
+type TaxQuote struct {
+	Region string    `json:"region"`
+	Lines  []TaxLine `json:"lines"`
+
+	SubtotalCents int64 `json:"subtotal_cents"`
+	TaxCents      int64 `json:"tax_cents"`
+	TotalCents    int64 `json:"total_cents"`
+}
+
+type TaxLine struct {
+	VariantID      string  `json:"variant_id"`
+	ProductID      string  `json:"product_id"`
+	Quantity       int     `json:"quantity"`
+	UnitPriceCents int64   `json:"unit_price_cents"`
+	AmountCents    int64   `json:"amount_cents"`
+	TaxClass       string  `json:"tax_class"`
+	Rate           float64 `json:"rate"`
+	TaxCents       int64   `json:"tax_cents"`
+}
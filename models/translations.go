@@ -0,0 +1,18 @@
+package models
+
+// Translation is the localized text of a product, or of one of its variants when VariantID is set.
+// A field left empty falls back to the next locale of the requested chain.
+type Translation struct {
+	VariantID string `json:"variant_id,omitempty"`
+	Locale    string `json:"locale"`
+	Name      string `json:"name,omitempty"`
+	Details   string `json:"details,omitempty"`
+}
+
+// ProductTranslations lists the translations of a product and its variants, along with the required
+// locales for which some text would still be shown in the base language.
+type ProductTranslations struct {
+	ProductID      string        `json:"product_id"`
+	Translations   []Translation `json:"translations"`
+	MissingLocales []string      `json:"missing_locales"`
+}
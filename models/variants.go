@@ -1,8 +1,35 @@
 package models
 
+import "time"
+
 type Variant struct {
 	ID        string `json:"id"`
 	ProductID string `json:"product_id"`
 	Name      string `json:"name"`
 	Details   string `json:"details"`
+	SKU       string `json:"sku,omitempty"`
+	GTIN      string `json:"gtin,omitempty"`
+	Available int    `json:"available"`
+	Status    string `json:"status,omitempty"`
+
+	// PriceCents is the unit price in cents; zero means the variant has no price yet.
+	PriceCents int64 `json:"price_cents,omitempty"`
+
+	// Measurements are checked against their kind of quantity; UnitPrice is computed from them on reads.
+	Measurements *Measurements `json:"measurements,omitempty"`
+	UnitPrice    *UnitPrice    `json:"unit_price,omitempty"`
+
+	// Nutrition is the per-serving panel of food variants; Allergens are the major allergens they contain.
+	Nutrition *Nutrition `json:"nutrition,omitempty"`
+	Allergens []string   `json:"allergens,omitempty"`
+
+	Options map[string]string `json:"options,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Media   []Media           `json:"media,omitempty"`
+
+	// CreatedAt and UpdatedAt are kept by the database, along with who created the variant and who last changed it.
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
 }
@@ -0,0 +1,100 @@
+// Package nutrition knows the nutrients of a nutrition facts panel with their daily values, and the major
+// food allergens products are declared against.
+package nutrition
+
+import (
+	"math"
+	"practice-app/models"
+	"strings"
+)
+
+// Nutrient describes a nutrient of the panel: the unit its amount is given in and its daily value in that unit.
+// Nutrients without a daily value, such as trans fat, have a DailyValue of 0.
+type Nutrient struct {
+	Name       string
+	Unit       string
+	DailyValue float64
+}
+
+// nutrients lists the nutrients in the order they appear on the label, with the FDA daily values for adults
+// and children 4 years and older.
+var nutrients = []Nutrient{
+	{Name: "total_fat", Unit: "g", DailyValue: 78},
+	{Name: "saturated_fat", Unit: "g", DailyValue: 20},
+	{Name: "trans_fat", Unit: "g"},
+	{Name: "cholesterol", Unit: "mg", DailyValue: 300},
+	{Name: "sodium", Unit: "mg", DailyValue: 2300},
+	{Name: "total_carbohydrate", Unit: "g", DailyValue: 275},
+	{Name: "dietary_fiber", Unit: "g", DailyValue: 28},
+	{Name: "total_sugars", Unit: "g"},
+	{Name: "added_sugars", Unit: "g", DailyValue: 50},
+	{Name: "protein", Unit: "g", DailyValue: 50},
+	{Name: "vitamin_d", Unit: "mcg", DailyValue: 20},
+	{Name: "calcium", Unit: "mg", DailyValue: 1300},
+	{Name: "iron", Unit: "mg", DailyValue: 18},
+	{Name: "potassium", Unit: "mg", DailyValue: 4700},
+}
+
+// Allergens are the major food allergens named by the FDA, in the order they are listed.
+var Allergens = []string{"milk", "egg", "fish", "shellfish", "tree-nut", "peanut", "wheat", "soy", "sesame"}
+
+// allergenAliases maps other common names to the name of a major allergen.
+var allergenAliases = map[string]string{
+	"dairy": "milk", "eggs": "egg", "crustacean": "shellfish", "crustacean-shellfish": "shellfish",
+	"tree-nuts": "tree-nut", "nuts": "tree-nut", "peanuts": "peanut", "soya": "soy", "soybean": "soy", "soybeans": "soy",
+}
+
+// Lookup finds a nutrient by name, accepting any case and spaces for underscores, e.g. "Total Fat".
+func Lookup(name string) (Nutrient, int, bool) {
+	name = strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "_", " "))), "_")
+
+	for i := range nutrients {
+		if nutrients[i].Name == name {
+			return nutrients[i], i, true
+		}
+	}
+
+	return Nutrient{}, 0, false
+}
+
+// NormalizeAllergen returns the name of a major allergen, accepting any case and common variants of its name,
+// e.g. "Tree Nuts" becomes tree-nut.
+func NormalizeAllergen(name string) (string, bool) {
+	name = strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "-", " "))), "-")
+
+	if alias, ok := allergenAliases[name]; ok {
+		name = alias
+	}
+
+	for _, a := range Allergens {
+		if a == name {
+			return a, true
+		}
+	}
+
+	return "", false
+}
+
+// DailyValue is the percent of the daily value a nutrient amount covers, rounded to a whole percent.
+// It is nil for nutrients without a daily value.
+func DailyValue(n models.Nutrient) *int {
+	nutrient, _, ok := Lookup(n.Name)
+	if !ok || nutrient.DailyValue == 0 {
+		return nil
+	}
+
+	percent := int(math.Round(n.Amount / nutrient.DailyValue * 100))
+
+	return &percent
+}
+
+// Complete fills in the percent daily values of a panel's nutrients.
+func Complete(n *models.Nutrition) {
+	if n == nil {
+		return
+	}
+
+	for i := range n.Nutrients {
+		n.Nutrients[i].DailyValue = DailyValue(n.Nutrients[i])
+	}
+}
@@ -0,0 +1,77 @@
+package nutrition
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_Lookup(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Expected string
+		Position int
+		OK       bool
+	}{
+		{"total_fat", "total_fat", 0, true},
+		{"Saturated Fat", "saturated_fat", 1, true},
+		{"VITAMIN_D", "vitamin_d", 10, true},
+		{"caffeine", "", 0, false},
+	}
+
+	for i, test := range testcases {
+		res, position, ok := Lookup(test.Name)
+
+		assert.Equalf(t, test.Expected, res.Name, "TEST[%v] FAILED - %s", i, test.Name)
+		assert.Equalf(t, test.Position, position, "TEST[%v] FAILED - %s", i, test.Name)
+		assert.Equalf(t, test.OK, ok, "TEST[%v] FAILED - %s", i, test.Name)
+	}
+}
+
+func Test_NormalizeAllergen(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Expected string
+		OK       bool
+	}{
+		{"peanut", "peanut", true},
+		{"Peanuts", "peanut", true},
+		{"Tree Nuts", "tree-nut", true},
+		{"soybeans", "soy", true},
+		{"Crustacean Shellfish", "shellfish", true},
+		{"gluten", "", false},
+	}
+
+	for i, test := range testcases {
+		res, ok := NormalizeAllergen(test.Name)
+
+		assert.Equalf(t, test.Expected, res, "TEST[%v] FAILED - %s", i, test.Name)
+		assert.Equalf(t, test.OK, ok, "TEST[%v] FAILED - %s", i, test.Name)
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	sodium, fat, fiber := 20, 10, 4
+
+	n := &models.Nutrition{
+		ServingSize: models.Measurement{Value: 28, Unit: "g"},
+		Calories:    160,
+		Nutrients: []models.Nutrient{
+			{Name: "total_fat", Amount: 8, Unit: "g"},
+			{Name: "trans_fat", Amount: 0, Unit: "g"},
+			{Name: "sodium", Amount: 460, Unit: "mg"},
+			{Name: "dietary_fiber", Amount: 1, Unit: "g"},
+		},
+	}
+
+	Complete(n)
+
+	assert.Equal(t, []models.Nutrient{
+		{Name: "total_fat", Amount: 8, Unit: "g", DailyValue: &fat},
+		{Name: "trans_fat", Amount: 0, Unit: "g"},
+		{Name: "sodium", Amount: 460, Unit: "mg", DailyValue: &sodium},
+		{Name: "dietary_fiber", Amount: 1, Unit: "g", DailyValue: &fiber},
+	}, n.Nutrients)
+
+	Complete(nil)
+}
@@ -0,0 +1,45 @@
+// Package pricing works out prices from what the stores read, with no access to the database of its own.
+package pricing
+
+import "practice-app/models"
+
+// Derive works out what a bundle's components add up to. The bundle is available as many times as its scarcest
+// component allows, and not at all while any component is no longer for sale. A bundle priced as a sum costs its
+// components' prices times their quantities, less the discount and never below zero; it has no price while any
+// component is unpriced or not for sale.
+func Derive(b *models.Bundle) {
+	b.Available = 0
+
+	var total int64
+
+	priced := len(b.Components) > 0
+
+	for i, c := range b.Components {
+		forSale := c.Status == models.StatusActive
+
+		n := 0
+		if forSale && c.Quantity > 0 && c.Available > 0 {
+			n = c.Available / c.Quantity
+		}
+
+		if i == 0 || n < b.Available {
+			b.Available = n
+		}
+
+		if c.PriceCents == 0 || !forSale {
+			priced = false
+		}
+
+		total += c.PriceCents * int64(c.Quantity)
+	}
+
+	if b.Pricing != models.BundlePricingSum {
+		return
+	}
+
+	b.PriceCents = 0
+
+	if priced && total > b.DiscountCents {
+		b.PriceCents = total - b.DiscountCents
+	}
+}
@@ -0,0 +1,78 @@
+package pricing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_Derive(t *testing.T) {
+	testcases := []struct {
+		Desc      string
+		Bundle    models.Bundle
+		Price     int64
+		Available int
+	}{
+		{
+			Desc: "fixed price is kept",
+			Bundle: models.Bundle{Pricing: models.BundlePricingFixed, PriceCents: 4500,
+				Components: []models.BundleComponent{{Quantity: 1, Status: "active", PriceCents: 2999, Available: 3}}},
+			Price:     4500,
+			Available: 3,
+		},
+		{
+			Desc: "sum less discount, limited by the scarcest component",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum, DiscountCents: 100,
+				Components: []models.BundleComponent{
+					{Quantity: 1, Status: "active", PriceCents: 1000, Available: 9},
+					{Quantity: 4, Status: "active", PriceCents: 250, Available: 7},
+				}},
+			Price:     1900,
+			Available: 1,
+		},
+		{
+			Desc: "discount larger than the sum",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum, DiscountCents: 5000,
+				Components: []models.BundleComponent{{Quantity: 1, Status: "active", PriceCents: 1000, Available: 2}}},
+			Price:     0,
+			Available: 2,
+		},
+		{
+			Desc: "unpriced component leaves the bundle unpriced",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum,
+				Components: []models.BundleComponent{{Quantity: 1, Status: "active", PriceCents: 1000, Available: 2}, {Quantity: 1, Status: "active", Available: 2}}},
+			Price:     0,
+			Available: 2,
+		},
+		{
+			Desc: "oversold component",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum,
+				Components: []models.BundleComponent{{Quantity: 1, Status: "active", PriceCents: 1000, Available: -2}, {Quantity: 1, Status: "active", PriceCents: 1, Available: 2}}},
+			Price:     1001,
+			Available: 0,
+		},
+		{
+			Desc: "component no longer for sale",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum,
+				Components: []models.BundleComponent{
+					{Quantity: 1, Status: "active", PriceCents: 1000, Available: 2},
+					{Quantity: 1, Status: "discontinued", PriceCents: 500, Available: 2},
+				}},
+			Price:     0,
+			Available: 0,
+		},
+		{
+			Desc:   "no components",
+			Bundle: models.Bundle{Pricing: models.BundlePricingSum},
+		},
+	}
+
+	for i, test := range testcases {
+		b := test.Bundle
+
+		Derive(&b)
+
+		assert.Equalf(t, test.Price, b.PriceCents, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.Available, b.Available, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,86 @@
+package pricing
+
+import (
+	"math"
+	"math/big"
+	"practice-app/models"
+)
+
+// rateScale is the precision rates are stored with, so that conversions are exact on integers.
+const rateScale = 100000000
+
+// Convert converts a price in cents into the minor units of a rate's currency, rounded to the rate's increment.
+func Convert(cents int64, rate *models.CurrencyRate) int64 {
+	increment := rate.Increment
+	if increment < 1 {
+		increment = 1
+	}
+
+	num := new(big.Int).Mul(big.NewInt(cents), big.NewInt(int64(math.Round(rate.Rate*rateScale))))
+	den := big.NewInt(rateScale * increment)
+
+	// Euclidean division: the remainder is never negative, so q is the floor of the exact value.
+	q, r := new(big.Int).DivMod(num, den, new(big.Int))
+
+	if r.Sign() != 0 {
+		switch rate.Rounding {
+		case models.RoundUp:
+			q.Add(q, big.NewInt(1))
+		case models.RoundDown:
+		default:
+			if r.Lsh(r, 1).Cmp(den) >= 0 {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	return q.Int64() * increment
+}
+
+// ConvertProduct converts the prices of a product, its variants and its bundle into a rate's currency, and records
+// the rate used on the product.
+func ConvertProduct(p *models.ProductWithVariants, rate *models.CurrencyRate) {
+	for i := range p.Variant {
+		v := &p.Variant[i]
+
+		v.PriceCents = Convert(v.PriceCents, rate)
+
+		// unit prices are shown to a hundredth of a cent and are not rounded to the increment
+		if v.UnitPrice != nil {
+			v.UnitPrice.Cents = math.Round(v.UnitPrice.Cents*rate.Rate*100) / 100
+		}
+	}
+
+	if b := p.Bundle; b != nil {
+		convertBundle(b, rate)
+	}
+
+	p.Conversion = &models.Conversion{Currency: rate.Currency, Rate: rate.Rate, RateUpdatedAt: rate.UpdatedAt}
+}
+
+// convertBundle converts the price of a bundle once, from the amount it was derived or set at, so that rounding
+// its components and its discount on their own cannot change what it costs. The discount of a bundle priced as a
+// sum is then what is left between its converted components and its converted price.
+func convertBundle(b *models.Bundle, rate *models.CurrencyRate) {
+	var total int64
+
+	for i := range b.Components {
+		c := &b.Components[i]
+		c.PriceCents = Convert(c.PriceCents, rate)
+		total += c.PriceCents * int64(c.Quantity)
+	}
+
+	b.PriceCents = Convert(b.PriceCents, rate)
+
+	if b.Pricing != models.BundlePricingSum || b.PriceCents == 0 {
+		b.DiscountCents = Convert(b.DiscountCents, rate)
+
+		return
+	}
+
+	b.DiscountCents = 0
+
+	if total > b.PriceCents {
+		b.DiscountCents = total - b.PriceCents
+	}
+}
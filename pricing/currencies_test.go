@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func Test_Convert(t *testing.T) {
+	testcases := []struct {
+		Desc           string
+		Cents          int64
+		Rate           models.CurrencyRate
+		ExpectedResult int64
+	}{
+		{Desc: "half up", Cents: 1999, Rate: models.CurrencyRate{Rate: 1.35, Rounding: "half_up", Increment: 1}, ExpectedResult: 2699},
+		{Desc: "exact half rounds up", Cents: 150, Rate: models.CurrencyRate{Rate: 1.35, Rounding: "half_up"}, ExpectedResult: 203},
+		{Desc: "up", Cents: 1999, Rate: models.CurrencyRate{Rate: 1.35, Rounding: "up", Increment: 1}, ExpectedResult: 2699},
+		{Desc: "down", Cents: 1999, Rate: models.CurrencyRate{Rate: 1.35, Rounding: "down", Increment: 1}, ExpectedResult: 2698},
+		{Desc: "increment", Cents: 1999, Rate: models.CurrencyRate{Rate: 17.0512, Rounding: "half_up", Increment: 5},
+			ExpectedResult: 34085},
+		{Desc: "whole units up", Cents: 1999, Rate: models.CurrencyRate{Rate: 17.0512, Rounding: "up", Increment: 100},
+			ExpectedResult: 34100},
+		{Desc: "exact", Cents: 1000, Rate: models.CurrencyRate{Rate: 1.5, Rounding: "up", Increment: 1}, ExpectedResult: 1500},
+		{Desc: "no price", Cents: 0, Rate: models.CurrencyRate{Rate: 1.35, Rounding: "up", Increment: 5}, ExpectedResult: 0},
+	}
+
+	for i, test := range testcases {
+		res := Convert(test.Cents, &test.Rate)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_ConvertProduct(t *testing.T) {
+	updated := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	rate := &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}
+
+	p := &models.ProductWithVariants{
+		ID: "1",
+		Variant: []models.VariantInfo{
+			{ID: "1", PriceCents: 1999, UnitPrice: &models.UnitPrice{Cents: 124.94, Per: "oz"}},
+			{ID: "2"},
+		},
+		Bundle: &models.Bundle{Pricing: "sum", PriceCents: 900, DiscountCents: 100,
+			Components: []models.BundleComponent{{VariantID: "1", Quantity: 1, PriceCents: 1000}}},
+	}
+
+	ConvertProduct(p, rate)
+
+	assert.Equal(t, &models.ProductWithVariants{
+		ID: "1",
+		Variant: []models.VariantInfo{
+			{ID: "1", PriceCents: 2699, UnitPrice: &models.UnitPrice{Cents: 168.67, Per: "oz"}},
+			{ID: "2"},
+		},
+		Bundle: &models.Bundle{Pricing: "sum", PriceCents: 1215, DiscountCents: 135,
+			Components: []models.BundleComponent{{VariantID: "1", Quantity: 1, PriceCents: 1350}}},
+		Conversion: &models.Conversion{Currency: "CAD", Rate: 1.35, RateUpdatedAt: updated},
+	}, p)
+}
+
+func Test_ConvertBundle(t *testing.T) {
+	rate := &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 5}
+
+	testcases := []struct {
+		Desc     string
+		Bundle   models.Bundle
+		Expected models.Bundle
+	}{
+		{
+			Desc: "sum converted once, the discount making up the difference",
+			Bundle: models.Bundle{Pricing: "sum", PriceCents: 1898, DiscountCents: 100,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 2, PriceCents: 999}}},
+			Expected: models.Bundle{Pricing: "sum", PriceCents: 2560, DiscountCents: 140,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 2, PriceCents: 1350}}},
+		},
+		{
+			Desc: "unpriced sum",
+			Bundle: models.Bundle{Pricing: "sum", DiscountCents: 100,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 1}}},
+			Expected: models.Bundle{Pricing: "sum", DiscountCents: 135,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 1}}},
+		},
+		{
+			Desc: "fixed price",
+			Bundle: models.Bundle{Pricing: "fixed", PriceCents: 1500,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 2, PriceCents: 999}}},
+			Expected: models.Bundle{Pricing: "fixed", PriceCents: 2025,
+				Components: []models.BundleComponent{{VariantID: "1", Quantity: 2, PriceCents: 1350}}},
+		},
+	}
+
+	for i, test := range testcases {
+		b := test.Bundle
+
+		convertBundle(&b, rate)
+
+		assert.Equalf(t, test.Expected, b, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,231 @@
+package pricing
+
+import (
+	"practice-app/models"
+	"sort"
+)
+
+// Line is an item being priced, with what promotions can target it by.
+type Line struct {
+	VariantID string
+	ProductID string
+	BrandID   string
+	// CategoryIDs are the categories the product is in and every category above them.
+	CategoryIDs    []string
+	Quantity       int
+	UnitPriceCents int64
+}
+
+// Matches reports whether a promotion targets a line.
+func Matches(p *models.Promotion, l *Line) bool {
+	for _, t := range p.Targets {
+		switch t.Type {
+		case models.TargetVariant:
+			if t.ID == l.VariantID {
+				return true
+			}
+		case models.TargetProduct:
+			if t.ID == l.ProductID {
+				return true
+			}
+		case models.TargetBrand:
+			if t.ID == l.BrandID && t.ID != "" {
+				return true
+			}
+		case models.TargetCategory:
+			if contains(l.CategoryIDs, t.ID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// unit is one unit of a line, priced separately since quantity promotions discount some units and not others.
+type unit struct {
+	line  int
+	price int64
+}
+
+// Evaluate prices lines with the given promotions. Promotions are applied from the highest priority down, the
+// lowest ID first among equal priorities, each to the prices left by the ones before it. An exclusive promotion
+// skips the lines another promotion has already discounted, and no further promotion applies to the lines it
+// discounts. Units pooled by buy X get Y and multi buy are taken most expensive first, so the cheapest units
+// are the free ones.
+func Evaluate(promotions []models.Promotion, lines []Line) *models.Evaluation {
+	ordered := make([]models.Promotion, len(promotions))
+	copy(ordered, promotions)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	var units []unit
+
+	for i, l := range lines {
+		for n := 0; n < l.Quantity; n++ {
+			units = append(units, unit{line: i, price: l.UnitPriceCents})
+		}
+	}
+
+	res := &models.Evaluation{Items: make([]models.EvaluatedItem, len(lines)), Promotions: []models.AppliedPromotion{}}
+	locked := make([]bool, len(lines))
+
+	for i := range ordered {
+		p := &ordered[i]
+
+		var eligible []*unit
+
+		for j := range units {
+			item := &res.Items[units[j].line]
+			l := &lines[units[j].line]
+
+			if locked[units[j].line] || (p.Exclusive && item.DiscountCents > 0) || !Matches(p, l) {
+				continue
+			}
+
+			eligible = append(eligible, &units[j])
+		}
+
+		discounts := discount(p, eligible)
+
+		var total int64
+
+		for j, d := range discounts {
+			if d == 0 {
+				continue
+			}
+
+			u := eligible[j]
+			u.price -= d
+			total += d
+
+			item := &res.Items[u.line]
+			item.DiscountCents += d
+
+			if len(item.Promotions) == 0 || item.Promotions[len(item.Promotions)-1] != p.ID {
+				item.Promotions = append(item.Promotions, p.ID)
+			}
+
+			if p.Exclusive {
+				locked[u.line] = true
+			}
+		}
+
+		if total > 0 {
+			res.Promotions = append(res.Promotions, models.AppliedPromotion{ID: p.ID, Name: p.Name, DiscountCents: total})
+		}
+	}
+
+	for i, l := range lines {
+		item := &res.Items[i]
+		item.VariantID, item.ProductID, item.Quantity = l.VariantID, l.ProductID, l.Quantity
+		item.UnitPriceCents = l.UnitPriceCents
+		item.SubtotalCents = l.UnitPriceCents * int64(l.Quantity)
+		item.TotalCents = item.SubtotalCents - item.DiscountCents
+
+		res.SubtotalCents += item.SubtotalCents
+		res.DiscountCents += item.DiscountCents
+	}
+
+	res.TotalCents = res.SubtotalCents - res.DiscountCents
+
+	return res
+}
+
+// discount works out what a promotion takes off each of the units it applies to.
+func discount(p *models.Promotion, units []*unit) []int64 {
+	res := make([]int64, len(units))
+
+	switch p.Type {
+	case models.PromotionPercentOff:
+		for i, u := range units {
+			res[i] = percent(u.price, p.PercentOff)
+		}
+	case models.PromotionAmountOff:
+		for i, u := range units {
+			res[i] = p.AmountOffCents
+			if u.price < res[i] {
+				res[i] = u.price
+			}
+		}
+	case models.PromotionBuyXGetY:
+		off := p.PercentOff
+		if off == 0 {
+			off = 100
+		}
+
+		forGroups(units, p.BuyQuantity+p.GetQuantity, func(group []int) {
+			for _, i := range group[p.BuyQuantity:] {
+				res[i] = percent(units[i].price, off)
+			}
+		})
+	case models.PromotionMultiBuy:
+		forGroups(units, p.BuyQuantity, func(group []int) {
+			var total int64
+
+			for _, i := range group {
+				total += units[i].price
+			}
+
+			if total <= p.PriceCents {
+				return
+			}
+
+			// the saving is shared out in proportion to the unit prices, the rounding left on the last unit
+			saving, left := total-p.PriceCents, total-p.PriceCents
+
+			for n, i := range group {
+				if n == len(group)-1 {
+					res[i] = left
+
+					break
+				}
+
+				res[i] = units[i].price * saving / total
+				left -= res[i]
+			}
+		})
+	}
+
+	return res
+}
+
+// forGroups pools units most expensive first and calls f with the indexes of every complete group of size units.
+func forGroups(units []*unit, size int, f func(group []int)) {
+	if size <= 0 {
+		return
+	}
+
+	order := make([]int, len(units))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return units[order[i]].price > units[order[j]].price })
+
+	for start := 0; start+size <= len(order); start += size {
+		f(order[start : start+size])
+	}
+}
+
+// percent is a percentage of a price in cents, rounded half up.
+func percent(price int64, pct int) int64 {
+	return (price*int64(pct) + 50) / 100
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,146 @@
+package pricing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_Matches(t *testing.T) {
+	l := &Line{VariantID: "1-s", ProductID: "1", CategoryIDs: []string{"food", "snacks"}}
+
+	testcases := []struct {
+		Desc     string
+		Target   models.PromotionTarget
+		Expected bool
+	}{
+		{Desc: "variant", Target: models.PromotionTarget{Type: "variant", ID: "1-s"}, Expected: true},
+		{Desc: "product", Target: models.PromotionTarget{Type: "product", ID: "1"}, Expected: true},
+		{Desc: "category", Target: models.PromotionTarget{Type: "category", ID: "snacks"}, Expected: true},
+		{Desc: "parent category", Target: models.PromotionTarget{Type: "category", ID: "food"}, Expected: true},
+		{Desc: "other category", Target: models.PromotionTarget{Type: "category", ID: "dairy"}, Expected: false},
+		{Desc: "category path", Target: models.PromotionTarget{Type: "category", ID: "food/snacks"}, Expected: false},
+		{Desc: "no brand", Target: models.PromotionTarget{Type: "brand", ID: ""}, Expected: false},
+		{Desc: "other product", Target: models.PromotionTarget{Type: "product", ID: "2"}, Expected: false},
+	}
+
+	for i, test := range testcases {
+		p := &models.Promotion{Targets: []models.PromotionTarget{test.Target}}
+
+		assert.Equalf(t, test.Expected, Matches(p, l), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Evaluate(t *testing.T) {
+	lines := []Line{
+		{VariantID: "1-s", ProductID: "1", BrandID: "acme", CategoryIDs: []string{"food", "snacks"}, Quantity: 2, UnitPriceCents: 300},
+		{VariantID: "2-s", ProductID: "2", BrandID: "other", CategoryIDs: []string{"dairy", "food"}, Quantity: 1, UnitPriceCents: 500},
+	}
+
+	food := []models.PromotionTarget{{Type: "category", ID: "food"}}
+
+	// item builds the expected line of an evaluation from its discount
+	item := func(l int, discount int64, promotions ...int) models.EvaluatedItem {
+		subtotal := lines[l].UnitPriceCents * int64(lines[l].Quantity)
+
+		return models.EvaluatedItem{VariantID: lines[l].VariantID, ProductID: lines[l].ProductID, Quantity: lines[l].Quantity,
+			UnitPriceCents: lines[l].UnitPriceCents, SubtotalCents: subtotal, DiscountCents: discount, TotalCents: subtotal - discount,
+			Promotions: promotions}
+	}
+
+	testcases := []struct {
+		Desc       string
+		Promotions []models.Promotion
+		Expected   *models.Evaluation
+	}{
+		{
+			Desc: "no promotions",
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 0), item(1, 0)},
+				Promotions: []models.AppliedPromotion{}, SubtotalCents: 1100, TotalCents: 1100},
+		},
+		{
+			Desc: "percent off a brand",
+			Promotions: []models.Promotion{{ID: 1, Name: "Acme 10%", Type: "percent_off", PercentOff: 10,
+				Targets: []models.PromotionTarget{{Type: "brand", ID: "acme"}}}},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 60, 1), item(1, 0)},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "Acme 10%", DiscountCents: 60}},
+				SubtotalCents: 1100, DiscountCents: 60, TotalCents: 1040},
+		},
+		{
+			Desc: "stacked by priority",
+			Promotions: []models.Promotion{
+				{ID: 1, Name: "Food 10%", Type: "percent_off", PercentOff: 10, Targets: food, Priority: 1},
+				{ID: 2, Name: "50c off", Type: "amount_off", AmountOffCents: 50, Priority: 5,
+					Targets: []models.PromotionTarget{{Type: "variant", ID: "2-s"}}},
+			},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 60, 1), item(1, 95, 2, 1)},
+				Promotions: []models.AppliedPromotion{
+					{ID: 2, Name: "50c off", DiscountCents: 50},
+					{ID: 1, Name: "Food 10%", DiscountCents: 105},
+				},
+				SubtotalCents: 1100, DiscountCents: 155, TotalCents: 945},
+		},
+		{
+			Desc: "exclusive promotion locks its items",
+			Promotions: []models.Promotion{
+				{ID: 1, Name: "Product 20%", Type: "percent_off", PercentOff: 20, Priority: 10, Exclusive: true,
+					Targets: []models.PromotionTarget{{Type: "product", ID: "1"}}},
+				{ID: 2, Name: "Food 10%", Type: "percent_off", PercentOff: 10, Targets: food},
+			},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 120, 1), item(1, 50, 2)},
+				Promotions: []models.AppliedPromotion{
+					{ID: 1, Name: "Product 20%", DiscountCents: 120},
+					{ID: 2, Name: "Food 10%", DiscountCents: 50},
+				},
+				SubtotalCents: 1100, DiscountCents: 170, TotalCents: 930},
+		},
+		{
+			Desc: "exclusive promotion skips discounted items",
+			Promotions: []models.Promotion{
+				{ID: 1, Name: "Food 10%", Type: "percent_off", PercentOff: 10, Targets: food, Priority: 10},
+				{ID: 2, Name: "Product 50%", Type: "percent_off", PercentOff: 50, Exclusive: true,
+					Targets: []models.PromotionTarget{{Type: "product", ID: "1"}}},
+			},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 60, 1), item(1, 50, 1)},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "Food 10%", DiscountCents: 110}},
+				SubtotalCents: 1100, DiscountCents: 110, TotalCents: 990},
+		},
+		{
+			Desc: "buy 2 get 1 free takes the cheapest unit",
+			Promotions: []models.Promotion{{ID: 1, Name: "3 for 2", Type: "buy_x_get_y", BuyQuantity: 2, GetQuantity: 1,
+				Targets: food}},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 300, 1), item(1, 0)},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "3 for 2", DiscountCents: 300}},
+				SubtotalCents: 1100, DiscountCents: 300, TotalCents: 800},
+		},
+		{
+			Desc: "buy 1 get 1 half off leaves an incomplete group alone",
+			Promotions: []models.Promotion{{ID: 1, Name: "BOGO 50%", Type: "buy_x_get_y", BuyQuantity: 1, GetQuantity: 1,
+				PercentOff: 50, Targets: food}},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 150, 1), item(1, 0)},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "BOGO 50%", DiscountCents: 150}},
+				SubtotalCents: 1100, DiscountCents: 150, TotalCents: 950},
+		},
+		{
+			Desc: "multi buy shares the saving out",
+			Promotions: []models.Promotion{{ID: 1, Name: "3 for $10", Type: "multi_buy", BuyQuantity: 3, PriceCents: 1000,
+				Targets: food}},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 55, 1), item(1, 45, 1)},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "3 for $10", DiscountCents: 100}},
+				SubtotalCents: 1100, DiscountCents: 100, TotalCents: 1000},
+		},
+		{
+			Desc: "multi buy dearer than the items",
+			Promotions: []models.Promotion{{ID: 1, Name: "3 for $12", Type: "multi_buy", BuyQuantity: 3, PriceCents: 1200,
+				Targets: food}},
+			Expected: &models.Evaluation{Items: []models.EvaluatedItem{item(0, 0), item(1, 0)},
+				Promotions: []models.AppliedPromotion{}, SubtotalCents: 1100, TotalCents: 1100},
+		},
+	}
+
+	for i, test := range testcases {
+		res := Evaluate(test.Promotions, lines)
+
+		assert.Equalf(t, test.Expected, res, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,15 @@
+package pricing
+
+import "math"
+
+// Tax is the tax in cents on an amount in cents at a rate in percent, rounded half up to the cent. Rates have
+// at most four decimals, so they are exact in millionths and the tax is computed on integers.
+func Tax(amountCents int64, rate float64) int64 {
+	millionths := int64(math.Round(rate * 10000))
+
+	if amountCents < 0 {
+		return -Tax(-amountCents, rate)
+	}
+
+	return (amountCents*millionths + 500000) / 1000000
+}
@@ -0,0 +1,29 @@
+package pricing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Tax(t *testing.T) {
+	testcases := []struct {
+		Desc           string
+		AmountCents    int64
+		Rate           float64
+		ExpectedResult int64
+	}{
+		{Desc: "whole cents", AmountCents: 1000, Rate: 5, ExpectedResult: 50},
+		{Desc: "half a cent rounds up", AmountCents: 150, Rate: 5, ExpectedResult: 8},
+		{Desc: "below half a cent rounds down", AmountCents: 149, Rate: 5, ExpectedResult: 7},
+		{Desc: "four decimals", AmountCents: 1000, Rate: 8.875, ExpectedResult: 89},
+		{Desc: "float error in the rate", AmountCents: 10000, Rate: 7.0001, ExpectedResult: 700},
+		{Desc: "zero rate", AmountCents: 1999, Rate: 0, ExpectedResult: 0},
+		{Desc: "refund", AmountCents: -150, Rate: 5, ExpectedResult: -8},
+	}
+
+	for i, test := range testcases {
+		res := Tax(test.AmountCents, test.Rate)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
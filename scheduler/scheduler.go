@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/lifecycle"
+	"time"
+)
+
+// Actor is recorded as the author of the status changes the scheduler makes.
+const Actor = "scheduler"
+
+// Scheduler publishes and withdraws products at the times set on them. Every replica of the service runs one;
+// the store makes sure only one of them applies the schedules at a time.
+type Scheduler struct {
+	store    lifecycle.LifecycleStore
+	interval time.Duration
+}
+
+// New returns a scheduler applying the due schedules every interval.
+func New(store lifecycle.LifecycleStore, interval time.Duration) *Scheduler {
+	return &Scheduler{store: store, interval: interval}
+}
+
+// Start applies the schedules that came due while the service was down, then keeps applying them in the
+// background until the context is done.
+func (s *Scheduler) Start(ctx *krogo.Context) {
+	s.Run(ctx)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Run(ctx)
+			}
+		}
+	}()
+}
+
+// Run applies the schedules that are due and returns the status changes it made. Failures are logged and
+// retried on the next run.
+func (s *Scheduler) Run(ctx *krogo.Context) []models.Transition {
+	changes, err := s.store.ApplySchedules(ctx, Actor)
+	if err != nil {
+		ctx.Logger.Errorf("applying product schedules failed: %v", err)
+
+		return nil
+	}
+
+	for i := range changes {
+		if changes[i].VariantID == "" {
+			ctx.Logger.Infof("product %s moved from %s to %s: %s", changes[i].ProductID, changes[i].From, changes[i].To,
+				changes[i].Reason)
+		}
+	}
+
+	return changes
+}
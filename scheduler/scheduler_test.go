@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"practice-app/store/lifecycle"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := lifecycle.NewMockLifecycleStore(ctrl)
+	s := New(mockStore, time.Minute)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	at := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	changes := []models.Transition{
+		{ID: 1, ProductID: "1", From: "scheduled", To: "active", Actor: "scheduler", Reason: "scheduled publication", CreatedAt: at},
+		{ID: 2, ProductID: "2", From: "active", To: "discontinued", Actor: "scheduler", Reason: "scheduled withdrawal", CreatedAt: at},
+		{ID: 3, ProductID: "2", VariantID: "5", From: "active", To: "discontinued", Actor: "scheduler",
+			Reason: "scheduled withdrawal", CreatedAt: at},
+	}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Transition
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: changes,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().ApplySchedules(ctx, "scheduler").Return(changes, nil),
+			},
+		},
+		{
+			Desc: "Success: another replica holds the lock",
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().ApplySchedules(ctx, "scheduler").Return(nil, nil),
+			},
+		},
+		{
+			Desc: "Failure: DB error",
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().ApplySchedules(ctx, "scheduler").Return(nil, errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res := s.Run(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestScheduler_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := lifecycle.NewMockLifecycleStore(ctrl)
+	s := New(mockStore, time.Hour)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	var cancel context.CancelFunc
+
+	ctx.Context, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	// the schedules missed while the service was down are applied before Start returns
+	mockStore.EXPECT().ApplySchedules(ctx, "scheduler").Return(nil, nil).Times(1)
+
+	s.Start(ctx)
+}
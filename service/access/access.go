@@ -0,0 +1,116 @@
+// Package access looks up the products and variants a request refers to and checks what can be done with them.
+package access
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+)
+
+// Product reads a product for a request that reads it or anything that belongs to it. A product that was not
+// published yet is hidden, as if it did not exist, from readers without a preview token for it.
+func Product(ctx *krogo.Context, store products.ProductStore, id string) (*models.ProductWithVariants, error) {
+	p, err := product(ctx, store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Preview(ctx, store, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Editable reads a product for a request that changes it or anything that belongs to it, and makes sure the user
+// making the request may change it.
+func Editable(ctx *krogo.Context, store products.ProductStore, id string) (*models.ProductWithVariants, error) {
+	p, err := product(ctx, store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = CheckEditable(p, ctx.Header(models.UserHeader)); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Preview hides a product that was not published yet from readers without a preview token for it in the
+// preview_token query parameter.
+func Preview(ctx *krogo.Context, store products.ProductStore, p *models.ProductWithVariants) error {
+	if Published(p.Status) {
+		return nil
+	}
+
+	if token := ctx.Param("preview_token"); token != "" {
+		ok, err := store.CheckPreview(ctx, p.ID, token)
+		if err != nil || ok {
+			return err
+		}
+	}
+
+	return errors.EntityNotFound{ID: p.ID, Entity: "products"}
+}
+
+// CheckEditable makes sure a product can be changed by a user: drafts only by their owner, and products in review
+// by nobody until a reviewer decides on them.
+func CheckEditable(p *models.ProductWithVariants, user string) error {
+	switch {
+	case p.Status == models.StatusInReview:
+		return &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+			Reason: "the product is in review and cannot change until it is approved or rejected"}
+	case p.Status == models.StatusDraft && (user == "" || user != p.Owner):
+		return &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"}
+	}
+
+	return nil
+}
+
+// Published reports whether a product in a status can be read by anyone. Drafts, products in review and
+// scheduled products can only be previewed.
+func Published(status string) bool {
+	return status != models.StatusDraft && status != models.StatusInReview && status != models.StatusScheduled
+}
+
+func product(ctx *krogo.Context, store products.ProductStore, id string) (*models.ProductWithVariants, error) {
+	p, err := store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "products"}
+		}
+
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Variant reads a variant, making sure it exists and belongs to the product in the path.
+func Variant(ctx *krogo.Context, store variants.VariantStore, id, pID string) (*models.Variant, error) {
+	v, err := store.GetByID(ctx, id, pID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "variants"}
+		}
+
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// ForSale makes sure a product or variant in a status can be sold: one that is discontinued, archived or not
+// published yet cannot be priced, taxed or bundled.
+func ForSale(id, status string) error {
+	if status == models.StatusActive {
+		return nil
+	}
+
+	return &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE", Reason: id + " is " + status + " and cannot be sold"}
+}
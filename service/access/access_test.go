@@ -0,0 +1,73 @@
+package access
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/variants"
+	"testing"
+)
+
+func Test_Variant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1-s",
+			ExpectedResult: &models.Variant{ID: "1-s", ProductID: "1"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetByID(ctx, "1-s", "1").Return(&models.Variant{ID: "1-s", ProductID: "1"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ID:          "2-s",
+			ExpectedErr: errors.EntityNotFound{ID: "2-s", Entity: "variants"},
+			Calls:       []*gomock.Call{mockVariantStore.EXPECT().GetByID(ctx, "2-s", "1").Return(nil, sql.ErrNoRows)},
+		},
+		{
+			Desc:        "Failure: db error",
+			ID:          "3-s",
+			ExpectedErr: errors.DB{},
+			Calls:       []*gomock.Call{mockVariantStore.EXPECT().GetByID(ctx, "3-s", "1").Return(nil, errors.DB{})},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := Variant(ctx, mockVariantStore, test.ID, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_ForSale(t *testing.T) {
+	testcases := []struct {
+		Status      string
+		ExpectedErr error
+	}{
+		{Status: "active"},
+		{Status: "discontinued", ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE",
+			Reason: "1-s is discontinued and cannot be sold"}},
+		{Status: "draft", ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE",
+			Reason: "1-s is draft and cannot be sold"}},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.ExpectedErr, ForSale("1-s", test.Status), "TEST[%v] FAILED - %s", i, test.Status)
+	}
+}
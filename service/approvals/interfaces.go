@@ -0,0 +1,16 @@
+package approvals
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type ApprovalService interface {
+	Submit(ctx *krogo.Context, productID, user string) (*models.Approval, error)
+	GetQueue(ctx *krogo.Context, user string) ([]models.Approval, error)
+	GetByProductID(ctx *krogo.Context, productID, user string) ([]models.Approval, error)
+	Comment(ctx *krogo.Context, id, user, body string) (*models.ApprovalComment, error)
+	Approve(ctx *krogo.Context, id, user, comment string) (*models.Approval, error)
+	Reject(ctx *krogo.Context, id, user, comment string) (*models.Approval, error)
+	Preview(ctx *krogo.Context, productID, user string) (*models.PreviewToken, error)
+}
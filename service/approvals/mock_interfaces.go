@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package approvals is a generated GoMock package.
+package approvals
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockApprovalService is a mock of ApprovalService interface.
+type MockApprovalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalServiceMockRecorder
+}
+
+// MockApprovalServiceMockRecorder is the mock recorder for MockApprovalService.
+type MockApprovalServiceMockRecorder struct {
+	mock *MockApprovalService
+}
+
+// NewMockApprovalService creates a new mock instance.
+func NewMockApprovalService(ctrl *gomock.Controller) *MockApprovalService {
+	mock := &MockApprovalService{ctrl: ctrl}
+	mock.recorder = &MockApprovalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalService) EXPECT() *MockApprovalServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockApprovalService) Approve(ctx *krogo.Context, id, user, comment string) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, user, comment)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockApprovalServiceMockRecorder) Approve(ctx, id, user, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockApprovalService)(nil).Approve), ctx, id, user, comment)
+}
+
+// Comment mocks base method.
+func (m *MockApprovalService) Comment(ctx *krogo.Context, id, user, body string) (*models.ApprovalComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, id, user, body)
+	ret0, _ := ret[0].(*models.ApprovalComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockApprovalServiceMockRecorder) Comment(ctx, id, user, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockApprovalService)(nil).Comment), ctx, id, user, body)
+}
+
+// GetByProductID mocks base method.
+func (m *MockApprovalService) GetByProductID(ctx *krogo.Context, productID, user string) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID, user)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockApprovalServiceMockRecorder) GetByProductID(ctx, productID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockApprovalService)(nil).GetByProductID), ctx, productID, user)
+}
+
+// GetQueue mocks base method.
+func (m *MockApprovalService) GetQueue(ctx *krogo.Context, user string) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueue", ctx, user)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueue indicates an expected call of GetQueue.
+func (mr *MockApprovalServiceMockRecorder) GetQueue(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueue", reflect.TypeOf((*MockApprovalService)(nil).GetQueue), ctx, user)
+}
+
+// Preview mocks base method.
+func (m *MockApprovalService) Preview(ctx *krogo.Context, productID, user string) (*models.PreviewToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", ctx, productID, user)
+	ret0, _ := ret[0].(*models.PreviewToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockApprovalServiceMockRecorder) Preview(ctx, productID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockApprovalService)(nil).Preview), ctx, productID, user)
+}
+
+// Reject mocks base method.
+func (m *MockApprovalService) Reject(ctx *krogo.Context, id, user, comment string) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, user, comment)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockApprovalServiceMockRecorder) Reject(ctx, id, user, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockApprovalService)(nil).Reject), ctx, id, user, comment)
+}
+
+// Submit mocks base method.
+func (m *MockApprovalService) Submit(ctx *krogo.Context, productID, user string) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, productID, user)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockApprovalServiceMockRecorder) Submit(ctx, productID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockApprovalService)(nil).Submit), ctx, productID, user)
+}
@@ -0,0 +1,220 @@
+package approvals
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/approvals"
+	"practice-app/store/products"
+	"strings"
+)
+
+type Service struct {
+	store        approvals.ApprovalStore
+	productStore products.ProductStore
+	reviewers    map[string]bool
+}
+
+// New takes the IDs of the users allowed to review products; anyone else can only submit their own drafts.
+func New(store approvals.ApprovalStore, productStore products.ProductStore, reviewers []string) *Service {
+	s := &Service{store: store, productStore: productStore, reviewers: make(map[string]bool, len(reviewers))}
+
+	for _, r := range reviewers {
+		if r = strings.TrimSpace(r); r != "" {
+			s.reviewers[r] = true
+		}
+	}
+
+	return s
+}
+
+// Submit puts a draft in the review queue. Only its owner can submit it, and it cannot change while in review.
+func (s *Service) Submit(ctx *krogo.Context, productID, user string) (*models.Approval, error) {
+	p, err := s.product(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != models.StatusDraft {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+			Reason: "only drafts can be submitted for review"}
+	}
+
+	if p.Owner != user {
+		return nil, forbidden("NOT_OWNER", "only the owner of a draft can submit it")
+	}
+
+	a, err := s.store.Submit(ctx, models.Transition{ProductID: productID, From: models.StatusDraft, To: models.StatusInReview,
+		Actor: user, Reason: "submitted for review"})
+	if err == sql.ErrNoRows {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "STATUS_CHANGED",
+			Reason: "the status was changed by another request, read it again before retrying"}
+	}
+
+	return a, err
+}
+
+// GetQueue lists the products waiting for a reviewer, longest waiting first, with the comments left on them.
+func (s *Service) GetQueue(ctx *krogo.Context, user string) ([]models.Approval, error) {
+	if !s.reviewers[user] {
+		return nil, forbidden("NOT_REVIEWER", "only reviewers can see the review queue")
+	}
+
+	return s.store.GetPending(ctx)
+}
+
+// GetByProductID lists the reviews of a product, for its owner and reviewers.
+func (s *Service) GetByProductID(ctx *krogo.Context, productID, user string) ([]models.Approval, error) {
+	p, err := s.product(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Owner != user && !s.reviewers[user] {
+		return nil, forbidden("NOT_REVIEWER", "only the owner of a product and reviewers can see its reviews")
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// Comment adds a note to a request, from the user who submitted it or a reviewer.
+func (s *Service) Comment(ctx *krogo.Context, id, user, body string) (*models.ApprovalComment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, errors.MissingParam{Param: []string{"body"}}
+	}
+
+	a, err := s.approval(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.SubmittedBy != user && !s.reviewers[user] {
+		return nil, forbidden("NOT_REVIEWER", "only the submitter and reviewers can comment on a review")
+	}
+
+	return s.store.AddComment(ctx, &models.ApprovalComment{ApprovalID: a.ID, Author: user, Body: body})
+}
+
+// Approve publishes the product of a pending request, or schedules it when it has a publication time. The request
+// is closed and the product changes status together.
+func (s *Service) Approve(ctx *krogo.Context, id, user, comment string) (*models.Approval, error) {
+	return s.decide(ctx, id, user, models.ApprovalApproved, models.StatusActive, comment)
+}
+
+// Reject sends the product of a pending request back to its owner as a draft; the comment says what to change.
+func (s *Service) Reject(ctx *krogo.Context, id, user, comment string) (*models.Approval, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, errors.MissingParam{Param: []string{"comment"}}
+	}
+
+	return s.decide(ctx, id, user, models.ApprovalRejected, models.StatusDraft, comment)
+}
+
+// Preview hands out a token letting its holder read a product before it is published, with preview_token=.
+func (s *Service) Preview(ctx *krogo.Context, productID, user string) (*models.PreviewToken, error) {
+	p, err := s.product(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Owner != user && !s.reviewers[user] {
+		return nil, forbidden("NOT_REVIEWER", "only the owner of a product and reviewers can preview it")
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.productStore.CreatePreview(ctx, &models.PreviewToken{Token: token, ProductID: productID, CreatedBy: user})
+}
+
+func (s *Service) decide(ctx *krogo.Context, id, user, state, status, comment string) (*models.Approval, error) {
+	if !s.reviewers[user] {
+		return nil, forbidden("NOT_REVIEWER", "only reviewers can approve or reject products")
+	}
+
+	a, err := s.approval(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.State != models.ApprovalPending {
+		return nil, alreadyDecided()
+	}
+
+	if a.SubmittedBy == user {
+		return nil, forbidden("SELF_REVIEW", "products cannot be reviewed by whoever submitted them")
+	}
+
+	// approved products with a publication time wait for it, the scheduler publishes them then
+	if state == models.ApprovalApproved {
+		p, err := s.product(ctx, a.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.PublishAt != nil {
+			status = models.StatusScheduled
+		}
+	}
+
+	a.State = state
+	a.Reviewer = user
+
+	res, err := s.store.Decide(ctx, a, models.Transition{ProductID: a.ProductID, From: models.StatusInReview, To: status,
+		Actor: user, Reason: comment}, comment)
+	if err == sql.ErrNoRows {
+		return nil, alreadyDecided()
+	}
+
+	return res, err
+}
+
+func (s *Service) product(ctx *krogo.Context, id string) (*models.ProductWithVariants, error) {
+	p, err := s.productStore.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "products"}
+		}
+
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *Service) approval(ctx *krogo.Context, id string) (*models.Approval, error) {
+	a, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "approvals"}
+		}
+
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func forbidden(code, reason string) error {
+	return &errors.Response{StatusCode: http.StatusForbidden, Code: code, Reason: reason}
+}
+
+func alreadyDecided() error {
+	return &errors.Response{StatusCode: http.StatusConflict, Code: "ALREADY_DECIDED", Reason: "the review was already decided"}
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,419 @@
+package approvals
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/approvals"
+	"practice-app/store/products"
+	"testing"
+	"time"
+)
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestService_Submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, []string{"r1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	submission := models.Transition{ProductID: "1", From: "draft", To: "in_review", Actor: "u1", Reason: "submitted for review"}
+	pending := &models.Approval{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1", SubmittedAt: at}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: pending,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockStore.EXPECT().Submit(ctx, submission).Return(pending, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc: "Failure: already published",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+				Reason: "only drafts can be submitted for review"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active", Owner: "u1"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: draft of another user",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER",
+				Reason: "only the owner of a draft can submit it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u2"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: submitted meanwhile",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "STATUS_CHANGED",
+				Reason: "the status was changed by another request, read it again before retrying"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockStore.EXPECT().Submit(ctx, submission).Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Submit(ctx, "1", "u1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), []string{" r1", ""})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	queue := []models.Approval{{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1", SubmittedAt: at}}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ExpectedResult []models.Approval
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "r1",
+			ExpectedResult: queue,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetPending(ctx).Return(queue, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not a reviewer",
+			User:        "u1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER", Reason: "only reviewers can see the review queue"},
+		},
+		{
+			Desc:        "Failure: no user",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER", Reason: "only reviewers can see the review queue"},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetQueue(ctx, test.User)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, []string{"r1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	history := []models.Approval{{ID: 1, ProductID: "1", State: "rejected", SubmittedBy: "u1", SubmittedAt: at, Reviewer: "r1"}}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ExpectedResult []models.Approval
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: owner",
+			User:           "u1",
+			ExpectedResult: history,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return(history, nil),
+			},
+		},
+		{
+			Desc:           "Success: reviewer",
+			User:           "r1",
+			ExpectedResult: history,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return(history, nil),
+			},
+		},
+		{
+			Desc: "Failure: someone else",
+			User: "u2",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER",
+				Reason: "only the owner of a product and reviewers can see its reviews"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByProductID(ctx, "1", test.User)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Comment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), []string{"r1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	pending := &models.Approval{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1", SubmittedAt: at}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		Body           string
+		ExpectedResult *models.ApprovalComment
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "r1",
+			Body:           "needs a photo",
+			ExpectedResult: &models.ApprovalComment{ID: 1, ApprovalID: 1, Author: "r1", Body: "needs a photo", CreatedAt: at},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending, nil),
+				mockStore.EXPECT().AddComment(ctx, &models.ApprovalComment{ApprovalID: 1, Author: "r1", Body: "needs a photo"}).
+					Return(&models.ApprovalComment{ID: 1, ApprovalID: 1, Author: "r1", Body: "needs a photo", CreatedAt: at}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: empty comment",
+			User:        "r1",
+			Body:        " ",
+			ExpectedErr: errors.MissingParam{Param: []string{"body"}},
+		},
+		{
+			Desc:        "Failure: review not found",
+			User:        "r1",
+			Body:        "needs a photo",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "approvals"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc: "Failure: someone else",
+			User: "u2",
+			Body: "nice",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER",
+				Reason: "only the submitter and reviewers can comment on a review"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Comment(ctx, "1", test.User, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Approve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, []string{"r1", "u1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	publish := models.Transition{ProductID: "1", From: "in_review", To: "active", Actor: "r1"}
+	schedule := models.Transition{ProductID: "1", From: "in_review", To: "scheduled", Actor: "r1"}
+	approved := &models.Approval{ID: 1, ProductID: "1", State: "approved", SubmittedBy: "u1", SubmittedAt: at, Reviewer: "r1", DecidedAt: &at}
+
+	pending := func() *models.Approval {
+		return &models.Approval{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1", SubmittedAt: at}
+	}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "r1",
+			ExpectedResult: approved,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending(), nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+				mockStore.EXPECT().Decide(ctx, &models.Approval{ID: 1, ProductID: "1", State: "approved", SubmittedBy: "u1",
+					SubmittedAt: at, Reviewer: "r1"}, publish, "").Return(approved, nil),
+			},
+		},
+		{
+			Desc:           "Success: scheduled until its publication time",
+			User:           "r1",
+			ExpectedResult: approved,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending(), nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review", PublishAt: &at}, nil),
+				mockStore.EXPECT().Decide(ctx, gomock.Any(), schedule, "").Return(approved, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not a reviewer",
+			User:        "u2",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER", Reason: "only reviewers can approve or reject products"},
+		},
+		{
+			Desc: "Failure: own submission",
+			User: "u1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "SELF_REVIEW",
+				Reason: "products cannot be reviewed by whoever submitted them"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending(), nil),
+			},
+		},
+		{
+			Desc:        "Failure: already decided",
+			User:        "r1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "ALREADY_DECIDED", Reason: "the review was already decided"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(approved, nil),
+			},
+		},
+		{
+			Desc:        "Failure: decided meanwhile",
+			User:        "r1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "ALREADY_DECIDED", Reason: "the review was already decided"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(pending(), nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+				mockStore.EXPECT().Decide(ctx, gomock.Any(), publish, "").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Approve(ctx, "1", test.User, "")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := approvals.NewMockApprovalStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), []string{"r1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	rejected := &models.Approval{ID: 1, ProductID: "1", State: "rejected", SubmittedBy: "u1", Reviewer: "r1", DecidedAt: &at}
+
+	testcases := []struct {
+		Desc           string
+		Comment        string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Comment:        "needs a photo",
+			ExpectedResult: rejected,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1").Return(&models.Approval{ID: 1, ProductID: "1", State: "pending", SubmittedBy: "u1"}, nil),
+				mockStore.EXPECT().Decide(ctx, &models.Approval{ID: 1, ProductID: "1", State: "rejected", SubmittedBy: "u1", Reviewer: "r1"},
+					models.Transition{ProductID: "1", From: "in_review", To: "draft", Actor: "r1", Reason: "needs a photo"}, "needs a photo").
+					Return(rejected, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no reason given",
+			ExpectedErr: errors.MissingParam{Param: []string{"comment"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Reject(ctx, "1", "r1", test.Comment)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(approvals.NewMockApprovalStore(ctrl), mockProductStore, []string{"r1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	token := &models.PreviewToken{Token: "abc", ProductID: "1", CreatedBy: "r1", ExpiresAt: at}
+
+	testcases := []struct {
+		Desc           string
+		User           string
+		ExpectedResult *models.PreviewToken
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			User:           "r1",
+			ExpectedResult: token,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review", Owner: "u1"}, nil),
+				mockProductStore.EXPECT().CreatePreview(ctx, gomock.Any()).Return(token, nil),
+			},
+		},
+		{
+			Desc: "Failure: someone else",
+			User: "u2",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_REVIEWER",
+				Reason: "only the owner of a product and reviewers can preview it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Preview(ctx, "1", test.User)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_randomToken(t *testing.T) {
+	a, err := randomToken()
+	assert.NoError(t, err)
+
+	b, err := randomToken()
+	assert.NoError(t, err)
+
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
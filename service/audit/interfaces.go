@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type AuditService interface {
+	GetAll(ctx *krogo.Context) ([]models.AuditEntry, error)
+}
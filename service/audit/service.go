@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+)
+
+type Service struct {
+	store audit.AuditStore
+}
+
+func New(store audit.AuditStore) *Service {
+	return &Service{store: store}
+}
+
+// GetAll lists the audit entries matching the query parameters of the request, newest first.
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.AuditEntry, error) {
+	return s.store.GetAll(ctx, ctx.Params())
+}
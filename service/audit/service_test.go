@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"testing"
+)
+
+func TestService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := audit.NewMockAuditStore(ctrl)
+	mockService := New(mockStore)
+
+	r := httptest.NewRequest(http.MethodGet, "/audit?actor=u1", nil)
+	ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	entries := []models.AuditEntry{{ID: 1, Action: models.AuditCreate, Entity: "products", EntityID: "1", ProductID: "1", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.AuditEntry
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: entries,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetAll(ctx, map[string]string{"actor": "u1"}).Return(entries, nil),
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetAll(ctx, map[string]string{"actor": "u1"}).Return(nil, errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
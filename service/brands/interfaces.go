@@ -0,0 +1,15 @@
+package brands
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type BrandService interface {
+	GetByID(ctx *krogo.Context, id string) (*models.Brand, error)
+	GetAll(ctx *krogo.Context) ([]models.Brand, error)
+	Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error)
+	Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error)
+	Delete(ctx *krogo.Context, id string) error
+	GetProducts(ctx *krogo.Context, id string) ([]models.ProductWithVariants, error)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package brands is a generated GoMock package.
+package brands
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockBrandService is a mock of BrandService interface.
+type MockBrandService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandServiceMockRecorder
+}
+
+// MockBrandServiceMockRecorder is the mock recorder for MockBrandService.
+type MockBrandServiceMockRecorder struct {
+	mock *MockBrandService
+}
+
+// NewMockBrandService creates a new mock instance.
+func NewMockBrandService(ctrl *gomock.Controller) *MockBrandService {
+	mock := &MockBrandService{ctrl: ctrl}
+	mock.recorder = &MockBrandServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandService) EXPECT() *MockBrandServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBrandService) Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, brand)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBrandServiceMockRecorder) Create(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBrandService)(nil).Create), ctx, brand)
+}
+
+// Delete mocks base method.
+func (m *MockBrandService) Delete(ctx *krogo.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBrandServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBrandService)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockBrandService) GetAll(ctx *krogo.Context) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBrandServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBrandService)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockBrandService) GetByID(ctx *krogo.Context, id string) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBrandServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBrandService)(nil).GetByID), ctx, id)
+}
+
+// GetProducts mocks base method.
+func (m *MockBrandService) GetProducts(ctx *krogo.Context, id string) ([]models.ProductWithVariants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProducts", ctx, id)
+	ret0, _ := ret[0].([]models.ProductWithVariants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProducts indicates an expected call of GetProducts.
+func (mr *MockBrandServiceMockRecorder) GetProducts(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockBrandService)(nil).GetProducts), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockBrandService) Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, brand)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBrandServiceMockRecorder) Update(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBrandService)(nil).Update), ctx, brand)
+}
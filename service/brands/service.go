@@ -0,0 +1,127 @@
+package brands
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/brands"
+	"practice-app/store/products"
+)
+
+type Service struct {
+	store        brands.BrandStore
+	productStore products.ProductStore
+}
+
+func New(store brands.BrandStore, productStore products.ProductStore) *Service {
+	return &Service{store: store, productStore: productStore}
+}
+
+func (s *Service) GetByID(ctx *krogo.Context, id string) (*models.Brand, error) {
+	b, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "brands"}
+		}
+
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.Brand, error) {
+	return s.store.GetAll(ctx)
+}
+
+func (s *Service) Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	if brand.Name == "" {
+		return nil, errors.MissingParam{Param: []string{"name"}}
+	}
+
+	if brands.Normalize(brand.Name) == "" {
+		return nil, errors.InvalidParam{Param: []string{"name"}}
+	}
+
+	if brand.ID == "" {
+		brand.ID = brands.Slug(brand.Name)
+	}
+
+	if err := s.checkNameFree(ctx, brand); err != nil {
+		return nil, err
+	}
+
+	return s.store.Create(ctx, brand)
+}
+
+// Update renames a brand. Products only reference the brand ID, so every product picks up the new name.
+func (s *Service) Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	if brand.Name == "" {
+		return nil, errors.MissingParam{Param: []string{"name"}}
+	}
+
+	if brands.Normalize(brand.Name) == "" {
+		return nil, errors.InvalidParam{Param: []string{"name"}}
+	}
+
+	if _, err := s.GetByID(ctx, brand.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNameFree(ctx, brand); err != nil {
+		return nil, err
+	}
+
+	return s.store.Update(ctx, brand)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, id string) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	count, err := s.store.CountProducts(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "BRAND_IN_USE",
+			Reason:     "brand " + id + " is still used by products",
+		}
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+func (s *Service) GetProducts(ctx *krogo.Context, id string) ([]models.ProductWithVariants, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.productStore.GetAll(ctx, map[string]string{"brand": id})
+}
+
+// checkNameFree rejects a name that normalizes to the name of a different brand, e.g. "kroger" next to "Kroger®".
+func (s *Service) checkNameFree(ctx *krogo.Context, brand *models.Brand) error {
+	existing, err := s.store.GetByName(ctx, brand.Name)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return err
+	case existing.ID == brand.ID:
+		return nil
+	default:
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "BRAND_EXISTS",
+			Reason:     "brand name " + brand.Name + " is already used by brand " + existing.ID,
+		}
+	}
+}
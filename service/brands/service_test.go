@@ -0,0 +1,174 @@
+package brands
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/brands"
+	"practice-app/store/products"
+	"testing"
+)
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockService := New(mockBrandStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Brand
+		ExpectedResult *models.Brand
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: id derived from name",
+			Body:           &models.Brand{Name: "Simple Truth"},
+			ExpectedResult: &models.Brand{ID: "simple-truth", Name: "Simple Truth"},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByName(ctx, "Simple Truth").Return(nil, sql.ErrNoRows),
+				mockBrandStore.EXPECT().Create(ctx, &models.Brand{ID: "simple-truth", Name: "Simple Truth"}).
+					Return(&models.Brand{ID: "simple-truth", Name: "Simple Truth"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: duplicate after normalization",
+			Body: &models.Brand{Name: "kroger®"},
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "BRAND_EXISTS",
+				Reason:     "brand name kroger® is already used by brand kroger-co",
+			},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByName(ctx, "kroger®").Return(&models.Brand{ID: "kroger-co", Name: "Kroger"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing name",
+			Body:        &models.Brand{},
+			ExpectedErr: errors.MissingParam{Param: []string{"name"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: name without letters or digits",
+			Body:        &models.Brand{Name: "®"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"name"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockService := New(mockBrandStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Brand
+		ExpectedResult *models.Brand
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: restyle own name",
+			Body:           &models.Brand{ID: "kroger", Name: "KROGER"},
+			ExpectedResult: &models.Brand{ID: "kroger", Name: "KROGER"},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(ctx, "kroger").Return(&models.Brand{ID: "kroger", Name: "Kroger"}, nil),
+				mockBrandStore.EXPECT().GetByName(ctx, "KROGER").Return(&models.Brand{ID: "kroger", Name: "Kroger"}, nil),
+				mockBrandStore.EXPECT().Update(ctx, &models.Brand{ID: "kroger", Name: "KROGER"}).
+					Return(&models.Brand{ID: "kroger", Name: "KROGER"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Body:        &models.Brand{ID: "nope", Name: "Nope"},
+			ExpectedErr: errors.EntityNotFound{ID: "nope", Entity: "brands"},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(ctx, "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Update(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockService := New(mockBrandStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(ctx, "kroger").Return(&models.Brand{ID: "kroger"}, nil),
+				mockBrandStore.EXPECT().CountProducts(ctx, "kroger").Return(0, nil),
+				mockBrandStore.EXPECT().Delete(ctx, "kroger").Return(nil),
+			},
+		},
+		{
+			Desc: "Failure: in use",
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "BRAND_IN_USE",
+				Reason:     "brand kroger is still used by products",
+			},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(ctx, "kroger").Return(&models.Brand{ID: "kroger"}, nil),
+				mockBrandStore.EXPECT().CountProducts(ctx, "kroger").Return(2, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, "kroger")
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockBrandStore, mockProductStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	mockBrandStore.EXPECT().GetByID(ctx, "kroger").Return(&models.Brand{ID: "kroger"}, nil)
+	mockProductStore.EXPECT().GetAll(ctx, map[string]string{"brand": "kroger"}).
+		Return([]models.ProductWithVariants{{ID: "1", BrandID: "kroger", BrandName: "Kroger"}}, nil)
+
+	res, err := mockService.GetProducts(ctx, "kroger")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ProductWithVariants{{ID: "1", BrandID: "kroger", BrandName: "Kroger"}}, res)
+}
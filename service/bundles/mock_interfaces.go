@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package bundles is a generated GoMock package.
+package bundles
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockBundleService is a mock of BundleService interface.
+type MockBundleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleServiceMockRecorder
+}
+
+// MockBundleServiceMockRecorder is the mock recorder for MockBundleService.
+type MockBundleServiceMockRecorder struct {
+	mock *MockBundleService
+}
+
+// NewMockBundleService creates a new mock instance.
+func NewMockBundleService(ctrl *gomock.Controller) *MockBundleService {
+	mock := &MockBundleService{ctrl: ctrl}
+	mock.recorder = &MockBundleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleService) EXPECT() *MockBundleServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBundleService) Get(ctx *krogo.Context, productID string) (*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID)
+	ret0, _ := ret[0].(*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBundleServiceMockRecorder) Get(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBundleService)(nil).Get), ctx, productID)
+}
+
+// Set mocks base method.
+func (m *MockBundleService) Set(ctx *krogo.Context, productID string, bundle *models.Bundle) (*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, bundle)
+	ret0, _ := ret[0].(*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockBundleServiceMockRecorder) Set(ctx, productID, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockBundleService)(nil).Set), ctx, productID, bundle)
+}
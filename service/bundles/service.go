@@ -0,0 +1,167 @@
+package bundles
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/pricing"
+	"practice-app/service/access"
+	"practice-app/store/bundles"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+)
+
+type Service struct {
+	store        bundles.BundleStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store bundles.BundleStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+// Get returns the components of a bundle product with the price and availability they add up to.
+func (s *Service) Get(ctx *krogo.Context, productID string) (*models.Bundle, error) {
+	p, err := access.Product(ctx, s.productStore, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkBundle(p); err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, productID)
+}
+
+// Set replaces the components and pricing of a bundle product. Components are variants of standard products that
+// are for sale; bundles cannot contain other bundles.
+func (s *Service) Set(ctx *krogo.Context, productID string, bundle *models.Bundle) (*models.Bundle, error) {
+	if err := validate(bundle); err != nil {
+		return nil, err
+	}
+
+	bp, err := access.Editable(ctx, s.productStore, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkBundle(bp); err != nil {
+		return nil, err
+	}
+
+	for _, c := range bundle.Components {
+		v, err := s.variantStore.Lookup(ctx, c.VariantID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.InvalidParam{Param: []string{"components"}}
+			}
+
+			return nil, err
+		}
+
+		p, err := s.productStore.GetByID(ctx, v.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.Type == models.ProductBundle {
+			return nil, errors.InvalidParam{Param: []string{"components"}}
+		}
+
+		if err = access.ForSale(p.ID, p.Status); err != nil {
+			return nil, err
+		}
+
+		if err = access.ForSale(v.ID, v.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.Set(ctx, productID, bundle); err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, productID)
+}
+
+// get reads a bundle and derives its price and availability from its components.
+func (s *Service) get(ctx *krogo.Context, productID string) (*models.Bundle, error) {
+	b, err := s.store.Get(ctx, productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: productID, Entity: "bundles"}
+		}
+
+		return nil, err
+	}
+
+	pricing.Derive(b)
+
+	return b, nil
+}
+
+func checkBundle(p *models.ProductWithVariants) error {
+	if p.Type != models.ProductBundle {
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "NOT_A_BUNDLE",
+			Reason:     "product " + p.ID + " is not a bundle",
+		}
+	}
+
+	return nil
+}
+
+func validate(b *models.Bundle) error {
+	var missing []string
+
+	if b.Pricing == "" {
+		missing = append(missing, "pricing")
+	}
+
+	if len(b.Components) == 0 {
+		missing = append(missing, "components")
+	}
+
+	if len(missing) > 0 {
+		return errors.MissingParam{Param: missing}
+	}
+
+	switch b.Pricing {
+	case models.BundlePricingFixed:
+		if b.PriceCents <= 0 {
+			return errors.InvalidParam{Param: []string{"price_cents"}}
+		}
+
+		if b.DiscountCents != 0 {
+			return errors.InvalidParam{Param: []string{"discount_cents"}}
+		}
+	case models.BundlePricingSum:
+		// the price of a sum priced bundle is computed from its components
+		if b.PriceCents != 0 {
+			return errors.InvalidParam{Param: []string{"price_cents"}}
+		}
+
+		if b.DiscountCents < 0 {
+			return errors.InvalidParam{Param: []string{"discount_cents"}}
+		}
+	default:
+		return errors.InvalidParam{Param: []string{"pricing"}}
+	}
+
+	seen := make(map[string]bool, len(b.Components))
+
+	for _, c := range b.Components {
+		if c.VariantID == "" || c.Quantity <= 0 || seen[c.VariantID] {
+			return errors.InvalidParam{Param: []string{"components"}}
+		}
+
+		seen[c.VariantID] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package bundles
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/bundles"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := bundles.NewMockBundleStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	bundle := &models.Bundle{
+		Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 1, PriceCents: 2999, Available: 4}},
+		Pricing:    models.BundlePricingFixed,
+		PriceCents: 2500,
+		Available:  4,
+	}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Bundle
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: bundle,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Type: "bundle"}, nil),
+				mockStore.EXPECT().Get(ctx, "set").Return(bundle, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no components set yet",
+			ExpectedErr: errors.EntityNotFound{ID: "set", Entity: "bundles"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Type: "bundle"}, nil),
+				mockStore.EXPECT().Get(ctx, "set").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc: "Failure: standard product",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_A_BUNDLE",
+				Reason: "product set is not a bundle"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Type: "standard"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "set", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "set", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Type: "bundle", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Get(ctx, "set")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := bundles.NewMockBundleStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+
+	set := &models.ProductWithVariants{ID: "set", Type: "bundle"}
+	body := &models.Bundle{
+		Components:    []models.BundleComponent{{VariantID: "k-1", Quantity: 1}},
+		Pricing:       models.BundlePricingSum,
+		DiscountCents: 500,
+	}
+	result := &models.Bundle{
+		Components: []models.BundleComponent{
+			{VariantID: "k-1", Quantity: 1, ProductID: "k", Name: "kettle", PriceCents: 2999, Available: 4, Status: "active"},
+		},
+		Pricing:       models.BundlePricingSum,
+		PriceCents:    2499,
+		DiscountCents: 500,
+		Available:     4,
+	}
+	// the store reads the components; the price and availability are derived from them by the service
+	stored := &models.Bundle{
+		Components: []models.BundleComponent{
+			{VariantID: "k-1", Quantity: 1, ProductID: "k", Name: "kettle", PriceCents: 2999, Available: 4, Status: "active"},
+		},
+		Pricing:       models.BundlePricingSum,
+		DiscountCents: 500,
+	}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Bundle
+		ExpectedResult *models.Bundle
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           body,
+			ExpectedResult: result,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(set, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "k-1").Return(&models.Variant{ID: "k-1", ProductID: "k", Status: "active"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "k").Return(&models.ProductWithVariants{ID: "k", Type: "standard", Status: "active"}, nil),
+				mockStore.EXPECT().Set(ctx, "set", body).Return(nil),
+				mockStore.EXPECT().Get(ctx, "set").Return(stored, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing pricing and components",
+			Body:        &models.Bundle{},
+			ExpectedErr: errors.MissingParam{Param: []string{"pricing", "components"}},
+		},
+		{
+			Desc:        "Failure: unknown pricing",
+			Body:        &models.Bundle{Pricing: "tiered", Components: body.Components},
+			ExpectedErr: errors.InvalidParam{Param: []string{"pricing"}},
+		},
+		{
+			Desc:        "Failure: fixed pricing without a price",
+			Body:        &models.Bundle{Pricing: models.BundlePricingFixed, Components: body.Components},
+			ExpectedErr: errors.InvalidParam{Param: []string{"price_cents"}},
+		},
+		{
+			Desc:        "Failure: sum pricing with a price",
+			Body:        &models.Bundle{Pricing: models.BundlePricingSum, PriceCents: 100, Components: body.Components},
+			ExpectedErr: errors.InvalidParam{Param: []string{"price_cents"}},
+		},
+		{
+			Desc:        "Failure: negative discount",
+			Body:        &models.Bundle{Pricing: models.BundlePricingSum, DiscountCents: -1, Components: body.Components},
+			ExpectedErr: errors.InvalidParam{Param: []string{"discount_cents"}},
+		},
+		{
+			Desc: "Failure: duplicate component",
+			Body: &models.Bundle{Pricing: models.BundlePricingSum,
+				Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 1}, {VariantID: "k-1", Quantity: 2}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"components"}},
+		},
+		{
+			Desc: "Failure: zero quantity",
+			Body: &models.Bundle{Pricing: models.BundlePricingSum,
+				Components: []models.BundleComponent{{VariantID: "k-1"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"components"}},
+		},
+		{
+			Desc:        "Failure: unknown variant",
+			Body:        body,
+			ExpectedErr: errors.InvalidParam{Param: []string{"components"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(set, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "k-1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: nested bundle",
+			Body:        body,
+			ExpectedErr: errors.InvalidParam{Param: []string{"components"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(set, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "k-1").Return(&models.Variant{ID: "k-1", ProductID: "other"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "other").Return(&models.ProductWithVariants{ID: "other", Type: "bundle"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: archived component",
+			Body: body,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE",
+				Reason: "k-1 is archived and cannot be sold"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(set, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "k-1").Return(&models.Variant{ID: "k-1", ProductID: "k", Status: "archived"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "k").Return(&models.ProductWithVariants{ID: "k", Type: "standard", Status: "active"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: standard product",
+			Body: body,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_A_BUNDLE",
+				Reason: "product set is not a bundle"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Type: "standard"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Body:        body,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").Return(set, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "k-1").Return(&models.Variant{ID: "k-1", ProductID: "k", Status: "active"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "k").Return(&models.ProductWithVariants{ID: "k", Type: "standard", Status: "active"}, nil),
+				mockStore.EXPECT().Set(ctx, "set", body).Return(errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        body,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "set").
+					Return(&models.ProductWithVariants{ID: "set", Type: "bundle", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Set(ctx, "set", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
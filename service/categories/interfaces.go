@@ -0,0 +1,18 @@
+package categories
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type CategoryService interface {
+	GetByID(ctx *krogo.Context, id string) (*models.Category, error)
+	GetAll(ctx *krogo.Context) ([]models.Category, error)
+	Create(ctx *krogo.Context, category *models.Category) (*models.Category, error)
+	Update(ctx *krogo.Context, category *models.Category) (*models.Category, error)
+	Delete(ctx *krogo.Context, id string) error
+	GetProductCategories(ctx *krogo.Context, productID string) ([]models.Category, error)
+	SetProductCategories(ctx *krogo.Context, productID string, categoryIDs []string) ([]models.Category, error)
+	GetAttributes(ctx *krogo.Context, id string) ([]models.AttributeDefinition, error)
+	SetAttributes(ctx *krogo.Context, id string, definitions []models.AttributeDefinition) ([]models.AttributeDefinition, error)
+}
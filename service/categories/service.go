@@ -0,0 +1,269 @@
+package categories
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/categories"
+	"practice-app/store/products"
+	"regexp"
+	"strings"
+)
+
+// pathSeparator delimits the ancestor IDs in a category's materialized path, e.g. "dairy/milk/".
+const pathSeparator = "/"
+
+type Service struct {
+	store        categories.CategoryStore
+	productStore products.ProductStore
+}
+
+func New(store categories.CategoryStore, productStore products.ProductStore) *Service {
+	return &Service{store: store, productStore: productStore}
+}
+
+func (s *Service) GetByID(ctx *krogo.Context, id string) (*models.Category, error) {
+	c, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "categories"}
+		}
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// GetAll returns the whole taxonomy as a tree of root categories with their children nested.
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.Category, error) {
+	all, err := s.store.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTree(all), nil
+}
+
+func (s *Service) Create(ctx *krogo.Context, category *models.Category) (*models.Category, error) {
+	missingAttributes := findMissingAttributes(category)
+
+	if len(missingAttributes) > 0 {
+		return nil, errors.MissingParam{Param: missingAttributes}
+	}
+
+	re := regexp.MustCompile("^[A-Za-z0-9_-]{1,64}$")
+	if !re.MatchString(category.ID) {
+		return nil, errors.InvalidParam{Param: []string{"id"}}
+	}
+
+	parentPath, err := s.parentPath(ctx, category.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	category.Path = parentPath + category.ID + pathSeparator
+
+	return s.store.Create(ctx, category)
+}
+
+// Update renames a category and/or moves it under a new parent, carrying its descendants along.
+func (s *Service) Update(ctx *krogo.Context, category *models.Category) (*models.Category, error) {
+	if category.Name == "" {
+		return nil, errors.MissingParam{Param: []string{"name"}}
+	}
+
+	existing, err := s.GetByID(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentPath, err := s.parentPath(ctx, category.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a category cannot become a child of itself or of anything below it
+	if strings.HasPrefix(parentPath, existing.Path) {
+		return nil, errors.InvalidParam{Param: []string{"parent_id"}}
+	}
+
+	category.Path = parentPath + category.ID + pathSeparator
+
+	return s.store.Update(ctx, category, existing.Path)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, id string) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	hasChildren, err := s.store.HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if hasChildren {
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "CATEGORY_HAS_CHILDREN",
+			Reason:     "category " + id + " still has child categories",
+		}
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+func (s *Service) GetProductCategories(ctx *krogo.Context, productID string) ([]models.Category, error) {
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+func (s *Service) SetProductCategories(ctx *krogo.Context, productID string, categoryIDs []string) ([]models.Category, error) {
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(categoryIDs))
+	ids := make([]string, 0, len(categoryIDs))
+
+	for _, id := range categoryIDs {
+		if seen[id] {
+			continue
+		}
+
+		if _, err := s.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	if err := s.store.SetProductCategories(ctx, productID, ids); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// GetAttributes returns the attribute schema of a category, including the definitions inherited from its ancestors.
+func (s *Service) GetAttributes(ctx *krogo.Context, id string) ([]models.AttributeDefinition, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetSchema(ctx, []string{id})
+}
+
+// SetAttributes replaces the attribute definitions declared on a category. Products already in the category are
+// checked against the new schema the next time their attributes are written.
+func (s *Service) SetAttributes(ctx *krogo.Context, id string,
+	definitions []models.AttributeDefinition) ([]models.AttributeDefinition, error) {
+	if err := validateDefinitions(definitions); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetAttributes(ctx, id, definitions); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetSchema(ctx, []string{id})
+}
+
+func (s *Service) parentPath(ctx *krogo.Context, parentID string) (string, error) {
+	if parentID == "" {
+		return "", nil
+	}
+
+	parent, err := s.store.GetByID(ctx, parentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.InvalidParam{Param: []string{"parent_id"}}
+		}
+
+		return "", err
+	}
+
+	return parent.Path, nil
+}
+
+var attributeName = regexp.MustCompile("^[a-z][a-z0-9_]{0,63}$")
+
+// validateDefinitions checks that attribute names are unique snake_case identifiers, that enums list their values
+// and that only numeric attributes carry a unit.
+func validateDefinitions(definitions []models.AttributeDefinition) error {
+	names := make(map[string]bool, len(definitions))
+
+	for _, d := range definitions {
+		if !attributeName.MatchString(d.Name) || names[d.Name] {
+			return errors.InvalidParam{Param: []string{"attributes"}}
+		}
+
+		names[d.Name] = true
+
+		switch d.Type {
+		case models.AttributeString, models.AttributeBoolean:
+			if len(d.Values) > 0 || d.Unit != "" {
+				return errors.InvalidParam{Param: []string{"attributes"}}
+			}
+		case models.AttributeNumber, models.AttributeInteger:
+			if len(d.Values) > 0 {
+				return errors.InvalidParam{Param: []string{"attributes"}}
+			}
+		case models.AttributeEnum:
+			if len(d.Values) == 0 || d.Unit != "" {
+				return errors.InvalidParam{Param: []string{"attributes"}}
+			}
+		default:
+			return errors.InvalidParam{Param: []string{"attributes"}}
+		}
+	}
+
+	return nil
+}
+
+// buildTree nests the categories under their parents; the input is expected to be ordered by path.
+func buildTree(all []models.Category) []models.Category {
+	children := make(map[string][]models.Category)
+
+	for _, c := range all {
+		children[c.ParentID] = append(children[c.ParentID], c)
+	}
+
+	var attach func(parentID string) []models.Category
+
+	attach = func(parentID string) []models.Category {
+		nodes := children[parentID]
+
+		for i := range nodes {
+			nodes[i].Children = attach(nodes[i].ID)
+		}
+
+		return nodes
+	}
+
+	return attach("")
+}
+
+func findMissingAttributes(category *models.Category) (res []string) {
+	if category.ID == "" {
+		res = append(res, "id")
+	}
+
+	if category.Name == "" {
+		res = append(res, "name")
+	}
+
+	return res
+}
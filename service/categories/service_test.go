@@ -0,0 +1,376 @@
+package categories
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/categories"
+	"practice-app/store/products"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	mockCategoryStore.EXPECT().GetAll(ctx).Return([]models.Category{
+		{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+		{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+		{ID: "whole", Name: "Whole Milk", ParentID: "milk", Path: "dairy/milk/whole/"},
+		{ID: "produce", Name: "Produce", Path: "produce/"},
+	}, nil)
+
+	res, err := mockService.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Category{
+		{ID: "dairy", Name: "Dairy", Path: "dairy/", Children: []models.Category{
+			{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/", Children: []models.Category{
+				{ID: "whole", Name: "Whole Milk", ParentID: "milk", Path: "dairy/milk/whole/"},
+			}},
+		}},
+		{ID: "produce", Name: "Produce", Path: "produce/"},
+	}, res)
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Category
+		ExpectedResult *models.Category
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: root",
+			Body:           &models.Category{ID: "dairy", Name: "Dairy"},
+			ExpectedResult: &models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().Create(ctx, &models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"}).
+					Return(&models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: child",
+			Body:           &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy"},
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "dairy").Return(&models.Category{ID: "dairy", Path: "dairy/"}, nil),
+				mockCategoryStore.EXPECT().Create(ctx, &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"}).
+					Return(&models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.Category{},
+			ExpectedErr: errors.MissingParam{Param: []string{"id", "name"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: invalid id",
+			Body:        &models.Category{ID: "dairy/milk", Name: "Milk"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"id"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: unknown parent",
+			Body:        &models.Category{ID: "milk", Name: "Milk", ParentID: "nope"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"parent_id"}},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Category
+		ExpectedResult *models.Category
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: move",
+			Body:           &models.Category{ID: "milk", Name: "Milk", ParentID: "drinks"},
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk", ParentID: "drinks", Path: "drinks/milk/"},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "milk").Return(&models.Category{ID: "milk", ParentID: "dairy", Path: "dairy/milk/"}, nil),
+				mockCategoryStore.EXPECT().GetByID(ctx, "drinks").Return(&models.Category{ID: "drinks", Path: "drinks/"}, nil),
+				mockCategoryStore.EXPECT().
+					Update(ctx, &models.Category{ID: "milk", Name: "Milk", ParentID: "drinks", Path: "drinks/milk/"}, "dairy/milk/").
+					Return(&models.Category{ID: "milk", Name: "Milk", ParentID: "drinks", Path: "drinks/milk/"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: move under own descendant",
+			Body:        &models.Category{ID: "dairy", Name: "Dairy", ParentID: "milk"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"parent_id"}},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "dairy").Return(&models.Category{ID: "dairy", Path: "dairy/"}, nil),
+				mockCategoryStore.EXPECT().GetByID(ctx, "milk").Return(&models.Category{ID: "milk", Path: "dairy/milk/"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Body:        &models.Category{ID: "nope", Name: "Nope"},
+			ExpectedErr: errors.EntityNotFound{ID: "nope", Entity: "categories"},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: missing name",
+			Body:        &models.Category{ID: "milk"},
+			ExpectedErr: errors.MissingParam{Param: []string{"name"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Update(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "milk").Return(&models.Category{ID: "milk"}, nil),
+				mockCategoryStore.EXPECT().HasChildren(ctx, "milk").Return(false, nil),
+				mockCategoryStore.EXPECT().Delete(ctx, "milk").Return(nil),
+			},
+		},
+		{
+			Desc: "Failure: has children",
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "CATEGORY_HAS_CHILDREN",
+				Reason:     "category milk still has child categories",
+			},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "milk").Return(&models.Category{ID: "milk"}, nil),
+				mockCategoryStore.EXPECT().HasChildren(ctx, "milk").Return(true, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, "milk")
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetProductCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockCategoryStore, mockProductStore)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc           string
+		CategoryIDs    []string
+		ExpectedResult []models.Category
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: duplicates collapsed",
+			CategoryIDs:    []string{"milk", "milk"},
+			ExpectedResult: []models.Category{{ID: "milk", Path: "dairy/milk/"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockCategoryStore.EXPECT().GetByID(ctx, "milk").Return(&models.Category{ID: "milk", Path: "dairy/milk/"}, nil),
+				mockCategoryStore.EXPECT().SetProductCategories(ctx, "1", []string{"milk"}).Return(nil),
+				mockCategoryStore.EXPECT().GetByProductID(ctx, "1").Return([]models.Category{{ID: "milk", Path: "dairy/milk/"}}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			CategoryIDs: []string{"milk"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: category not found",
+			CategoryIDs: []string{"nope"},
+			ExpectedErr: errors.EntityNotFound{ID: "nope", Entity: "categories"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockCategoryStore.EXPECT().GetByID(ctx, "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			CategoryIDs: []string{"milk"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetProductCategories(ctx, "1", test.CategoryIDs)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	wattage := models.AttributeDefinition{Name: "wattage", Type: "integer", Required: true, Unit: "W"}
+	finish := models.AttributeDefinition{Name: "finish", Type: "enum", Values: []string{"matte", "gloss"}}
+
+	testcases := []struct {
+		Desc           string
+		Body           []models.AttributeDefinition
+		ExpectedResult []models.AttributeDefinition
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Body: []models.AttributeDefinition{wattage, finish},
+			ExpectedResult: []models.AttributeDefinition{
+				{CategoryID: "kettles", Name: "finish", Type: "enum", Values: []string{"matte", "gloss"}},
+				{CategoryID: "appliances", Name: "voltage", Type: "number", Unit: "V"},
+				{CategoryID: "kettles", Name: "wattage", Type: "integer", Required: true, Unit: "W"},
+			},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "kettles").Return(&models.Category{ID: "kettles", Path: "appliances/kettles/"}, nil),
+				mockCategoryStore.EXPECT().SetAttributes(ctx, "kettles", []models.AttributeDefinition{wattage, finish}).Return(nil),
+				mockCategoryStore.EXPECT().GetSchema(ctx, []string{"kettles"}).Return([]models.AttributeDefinition{
+					{CategoryID: "kettles", Name: "finish", Type: "enum", Values: []string{"matte", "gloss"}},
+					{CategoryID: "appliances", Name: "voltage", Type: "number", Unit: "V"},
+					{CategoryID: "kettles", Name: "wattage", Type: "integer", Required: true, Unit: "W"},
+				}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: duplicate name",
+			Body:        []models.AttributeDefinition{wattage, wattage},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes"}},
+		},
+		{
+			Desc:        "Failure: invalid name",
+			Body:        []models.AttributeDefinition{{Name: "Wattage (W)", Type: "integer"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes"}},
+		},
+		{
+			Desc:        "Failure: unknown type",
+			Body:        []models.AttributeDefinition{{Name: "wattage", Type: "float"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes"}},
+		},
+		{
+			Desc:        "Failure: enum without values",
+			Body:        []models.AttributeDefinition{{Name: "finish", Type: "enum"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes"}},
+		},
+		{
+			Desc:        "Failure: unit on a string",
+			Body:        []models.AttributeDefinition{{Name: "fabric", Type: "string", Unit: "cm"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes"}},
+		},
+		{
+			Desc:        "Failure: category not found",
+			Body:        []models.AttributeDefinition{wattage},
+			ExpectedErr: errors.EntityNotFound{ID: "kettles", Entity: "categories"},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "kettles").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetAttributes(ctx, "kettles", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockCategoryStore, products.NewMockProductStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	schema := []models.AttributeDefinition{{CategoryID: "appliances", Name: "voltage", Type: "number", Unit: "V"}}
+
+	mockCategoryStore.EXPECT().GetByID(ctx, "kettles").Return(&models.Category{ID: "kettles"}, nil)
+	mockCategoryStore.EXPECT().GetSchema(ctx, []string{"kettles"}).Return(schema, nil)
+	mockCategoryStore.EXPECT().GetByID(ctx, "toasters").Return(nil, sql.ErrNoRows)
+
+	res, err := mockService.GetAttributes(ctx, "kettles")
+
+	assert.Equal(t, schema, res)
+	assert.NoError(t, err)
+
+	_, err = mockService.GetAttributes(ctx, "toasters")
+
+	assert.Equal(t, errors.EntityNotFound{ID: "toasters", Entity: "categories"}, err)
+}
@@ -0,0 +1,13 @@
+package currencies
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type CurrencyService interface {
+	GetAll(ctx *krogo.Context) ([]models.CurrencyRate, error)
+	GetByCode(ctx *krogo.Context, code string) (*models.CurrencyRate, error)
+	Set(ctx *krogo.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error)
+	Delete(ctx *krogo.Context, code string) error
+}
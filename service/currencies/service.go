@@ -0,0 +1,92 @@
+package currencies
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"math"
+	"practice-app/models"
+	"practice-app/store/currencies"
+	"regexp"
+	"strings"
+)
+
+// codePattern matches ISO 4217 currency codes.
+var codePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Service struct {
+	store currencies.CurrencyStore
+}
+
+func New(store currencies.CurrencyStore) *Service {
+	return &Service{store: store}
+}
+
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.CurrencyRate, error) {
+	return s.store.GetAll(ctx)
+}
+
+func (s *Service) GetByCode(ctx *krogo.Context, code string) (*models.CurrencyRate, error) {
+	code = strings.ToUpper(code)
+
+	r, err := s.store.GetByCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: code, Entity: "currency_rates"}
+		}
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Set adds or replaces the rate of a currency. Prices are rounded half up to the cent unless told otherwise.
+func (s *Service) Set(ctx *krogo.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error) {
+	rate.Currency = strings.ToUpper(strings.TrimSpace(rate.Currency))
+
+	if rate.Rounding == "" {
+		rate.Rounding = models.RoundHalfUp
+	}
+
+	if rate.Increment == 0 {
+		rate.Increment = 1
+	}
+
+	if err := validate(rate); err != nil {
+		return nil, err
+	}
+
+	return s.store.Set(ctx, rate)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, code string) error {
+	if _, err := s.GetByCode(ctx, code); err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, strings.ToUpper(code))
+}
+
+// validate checks the code of a currency, which cannot be the base currency, and that its rate fits the eight
+// decimals rates are stored with.
+func validate(rate *models.CurrencyRate) error {
+	var invalid string
+
+	switch {
+	case !codePattern.MatchString(rate.Currency) || rate.Currency == models.BaseCurrency:
+		invalid = "currency"
+	case rate.Rate <= 0 || rate.Rate >= 1e10 || math.Abs(rate.Rate*1e8-math.Round(rate.Rate*1e8)) > 1e-3:
+		invalid = "rate"
+	case rate.Rounding != models.RoundHalfUp && rate.Rounding != models.RoundUp && rate.Rounding != models.RoundDown:
+		invalid = "rounding"
+	case rate.Increment < 1 || rate.Increment > 10000:
+		invalid = "increment"
+	}
+
+	if invalid != "" {
+		return errors.InvalidParam{Param: []string{invalid}}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package currencies
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"practice-app/store/currencies"
+	"testing"
+	"time"
+)
+
+var updated = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestService_GetByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := currencies.NewMockCurrencyStore(ctrl)
+	mockService := New(mockStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	rate := &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.CurrencyRate
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: rate,
+			Calls:          []*gomock.Call{mockStore.EXPECT().GetByCode(ctx, "CAD").Return(rate, nil)},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "CAD", Entity: "currency_rates"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByCode(ctx, "CAD").Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByCode(ctx, "cad")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := currencies.NewMockCurrencyStore(ctrl)
+	mockService := New(mockStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.CurrencyRate
+		ExpectedResult *models.CurrencyRate
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: defaults",
+			Body:           &models.CurrencyRate{Currency: "cad", Rate: 1.35},
+			ExpectedResult: &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().Set(ctx, &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1}).
+					Return(&models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: base currency",
+			Body:        &models.CurrencyRate{Currency: "USD", Rate: 1},
+			ExpectedErr: errors.InvalidParam{Param: []string{"currency"}},
+		},
+		{
+			Desc:        "Failure: not a currency code",
+			Body:        &models.CurrencyRate{Currency: "pesos", Rate: 17},
+			ExpectedErr: errors.InvalidParam{Param: []string{"currency"}},
+		},
+		{
+			Desc:        "Failure: zero rate",
+			Body:        &models.CurrencyRate{Currency: "MXN"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rate"}},
+		},
+		{
+			Desc:        "Failure: too many decimals",
+			Body:        &models.CurrencyRate{Currency: "MXN", Rate: 17.051234567},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rate"}},
+		},
+		{
+			Desc:        "Failure: unknown rounding",
+			Body:        &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "bankers"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rounding"}},
+		},
+		{
+			Desc:        "Failure: negative increment",
+			Body:        &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Increment: -5},
+			ExpectedErr: errors.InvalidParam{Param: []string{"increment"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Set(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := currencies.NewMockCurrencyStore(ctrl)
+	mockService := New(mockStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByCode(ctx, "CAD").Return(&models.CurrencyRate{Currency: "CAD"}, nil),
+				mockStore.EXPECT().Delete(ctx, "CAD").Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "CAD", Entity: "currency_rates"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByCode(ctx, "CAD").Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, "cad")
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,14 @@
+package inventory
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type InventoryService interface {
+	Get(ctx *krogo.Context, id, pID string) (*models.Inventory, error)
+	Update(ctx *krogo.Context, pID string, inventory *models.Inventory) (*models.Inventory, error)
+	Reserve(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error)
+	Release(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error)
+	Commit(ctx *krogo.Context, id, pID, location string, quantity int) (*models.Inventory, error)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package inventory is a generated GoMock package.
+package inventory
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockInventoryService is a mock of InventoryService interface.
+type MockInventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceMockRecorder
+}
+
+// MockInventoryServiceMockRecorder is the mock recorder for MockInventoryService.
+type MockInventoryServiceMockRecorder struct {
+	mock *MockInventoryService
+}
+
+// NewMockInventoryService creates a new mock instance.
+func NewMockInventoryService(ctrl *gomock.Controller) *MockInventoryService {
+	mock := &MockInventoryService{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryService) EXPECT() *MockInventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockInventoryService) Commit(ctx *krogo.Context, id, pID, location string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, id, pID, location, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockInventoryServiceMockRecorder) Commit(ctx, id, pID, location, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockInventoryService)(nil).Commit), ctx, id, pID, location, quantity)
+}
+
+// Get mocks base method.
+func (m *MockInventoryService) Get(ctx *krogo.Context, id, pID string) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, pID)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInventoryServiceMockRecorder) Get(ctx, id, pID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInventoryService)(nil).Get), ctx, id, pID)
+}
+
+// Release mocks base method.
+func (m *MockInventoryService) Release(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id, pID, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockInventoryServiceMockRecorder) Release(ctx, id, pID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockInventoryService)(nil).Release), ctx, id, pID, quantity)
+}
+
+// Reserve mocks base method.
+func (m *MockInventoryService) Reserve(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, id, pID, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockInventoryServiceMockRecorder) Reserve(ctx, id, pID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockInventoryService)(nil).Reserve), ctx, id, pID, quantity)
+}
+
+// Update mocks base method.
+func (m *MockInventoryService) Update(ctx *krogo.Context, pID string, inventory *models.Inventory) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, pID, inventory)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockInventoryServiceMockRecorder) Update(ctx, pID, inventory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInventoryService)(nil).Update), ctx, pID, inventory)
+}
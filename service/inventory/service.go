@@ -0,0 +1,127 @@
+package inventory
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/inventory"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+)
+
+type Service struct {
+	store        inventory.InventoryStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store inventory.InventoryStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+func (s *Service) Get(ctx *krogo.Context, id, pID string) (*models.Inventory, error) {
+	if _, err := access.Product(ctx, s.productStore, pID); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Variant(ctx, s.variantStore, id, pID); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.store.GetByVariantID(ctx, id)
+	if err != nil {
+		// a variant that was never stocked simply has nothing on hand
+		if err == sql.ErrNoRows {
+			return &models.Inventory{VariantID: id}, nil
+		}
+
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+func (s *Service) Update(ctx *krogo.Context, pID string, inv *models.Inventory) (*models.Inventory, error) {
+	if inv.OnHand < 0 {
+		return nil, errors.InvalidParam{Param: []string{"on_hand"}}
+	}
+
+	if err := s.checkEditable(ctx, inv.VariantID, pID); err != nil {
+		return nil, err
+	}
+
+	res, err := s.store.Upsert(ctx, inv)
+	if err != nil {
+		return nil, mapStockError(err, inv.VariantID)
+	}
+
+	return res, nil
+}
+
+func (s *Service) Reserve(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, id, pID, quantity, s.store.Reserve)
+}
+
+func (s *Service) Release(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, id, pID, quantity, s.store.Release)
+}
+
+// Commit ships a reserved quantity of a variant, from the given location when the variant is stocked by location.
+func (s *Service) Commit(ctx *krogo.Context, id, pID, location string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, id, pID, quantity, func(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+		return s.store.Commit(ctx, variantID, location, quantity)
+	})
+}
+
+func (s *Service) adjust(ctx *krogo.Context, id, pID string, quantity int,
+	apply func(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error)) (*models.Inventory, error) {
+	if quantity <= 0 {
+		return nil, errors.InvalidParam{Param: []string{"quantity"}}
+	}
+
+	if err := s.checkEditable(ctx, id, pID); err != nil {
+		return nil, err
+	}
+
+	res, err := apply(ctx, id, quantity)
+	if err != nil {
+		return nil, mapStockError(err, id)
+	}
+
+	return res, nil
+}
+
+// checkEditable makes sure the variant exists and the user making the request may change the stock of its product.
+func (s *Service) checkEditable(ctx *krogo.Context, id, pID string) error {
+	if _, err := access.Editable(ctx, s.productStore, pID); err != nil {
+		return err
+	}
+
+	_, err := access.Variant(ctx, s.variantStore, id, pID)
+
+	return err
+}
+
+func mapStockError(err error, id string) error {
+	switch err {
+	case sql.ErrNoRows:
+		return errors.EntityNotFound{ID: id, Entity: "inventory"}
+	case inventory.ErrInsufficientStock:
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "INSUFFICIENT_STOCK",
+			Reason:     "insufficient stock for variant " + id,
+		}
+	case inventory.ErrStockedByLocation:
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "STOCKED_BY_LOCATION",
+			Reason:     "the stock of variant " + id + " is kept by location, so it is set and committed at a location",
+		}
+	default:
+		return err
+	}
+}
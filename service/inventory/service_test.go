@@ -0,0 +1,286 @@
+package inventory
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/inventory"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryStore := inventory.NewMockInventoryStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockInventoryStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Reserved: 1, Available: 4},
+			ExpectedErr:    nil,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().GetByVariantID(ctx, "1").
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Reserved: 1, Available: 4}, nil),
+			},
+		},
+		{
+			Desc:           "Success: never stocked",
+			ExpectedResult: &models.Inventory{VariantID: "1"},
+			ExpectedErr:    nil,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().GetByVariantID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:           "Failure: variant not found",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.EntityNotFound{ID: "1", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Get(ctx, "1", "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryStore := inventory.NewMockInventoryStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockInventoryStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Inventory
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 5},
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Available: 5},
+			ExpectedErr:    nil,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Upsert(ctx, &models.Inventory{VariantID: "1", OnHand: 5}).
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Available: 5}, nil),
+			},
+		},
+		{
+			Desc:           "Failure: negative on hand",
+			Body:           &models.Inventory{VariantID: "1", OnHand: -1},
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"on_hand"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: below reserved",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 1},
+			ExpectedResult: nil,
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "INSUFFICIENT_STOCK",
+				Reason:     "insufficient stock for variant 1",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Upsert(ctx, &models.Inventory{VariantID: "1", OnHand: 1}).
+					Return(nil, inventory.ErrInsufficientStock),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        &models.Inventory{VariantID: "1", OnHand: 5},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Update(ctx, "1", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Adjust(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInventoryStore := inventory.NewMockInventoryStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockInventoryStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+
+	commit := func(location string) func(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+		return func(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error) {
+			return mockService.Commit(ctx, id, pID, location, quantity)
+		}
+	}
+
+	testcases := []struct {
+		Desc           string
+		Apply          func(ctx *krogo.Context, id, pID string, quantity int) (*models.Inventory, error)
+		Quantity       int
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: reserve",
+			Apply:          mockService.Reserve,
+			Quantity:       2,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Reserved: 2, Available: 3},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Reserve(ctx, "1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Reserved: 2, Available: 3}, nil),
+			},
+		},
+		{
+			Desc:           "Success: release",
+			Apply:          mockService.Release,
+			Quantity:       2,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 5, Available: 5},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Release(ctx, "1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 5, Available: 5}, nil),
+			},
+		},
+		{
+			Desc:           "Success: commit",
+			Apply:          commit("CIN1"),
+			Quantity:       2,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 3, Available: 3},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Commit(ctx, "1", "CIN1", 2).
+					Return(&models.Inventory{VariantID: "1", OnHand: 3, Available: 3}, nil),
+			},
+		},
+		{
+			Desc:           "Failure: invalid quantity",
+			Apply:          mockService.Reserve,
+			Quantity:       0,
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"quantity"}},
+			Calls:          []*gomock.Call{},
+		},
+		{
+			Desc:           "Failure: insufficient stock",
+			Apply:          mockService.Reserve,
+			Quantity:       9,
+			ExpectedResult: nil,
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "INSUFFICIENT_STOCK",
+				Reason:     "insufficient stock for variant 1",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Reserve(ctx, "1", 9).Return(nil, inventory.ErrInsufficientStock),
+			},
+		},
+		{
+			Desc:           "Failure: not stocked",
+			Apply:          commit(""),
+			Quantity:       1,
+			ExpectedResult: nil,
+			ExpectedErr:    errors.EntityNotFound{ID: "1", Entity: "inventory"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Commit(ctx, "1", "", 1).Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:           "Failure: stocked by location",
+			Apply:          commit(""),
+			Quantity:       1,
+			ExpectedResult: nil,
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "STOCKED_BY_LOCATION",
+				Reason:     "the stock of variant 1 is kept by location, so it is set and committed at a location",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockInventoryStore.EXPECT().Commit(ctx, "1", "", 1).Return(nil, inventory.ErrStockedByLocation),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Apply:       mockService.Reserve,
+			Quantity:    1,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := test.Apply(ctx, "1", "1", test.Quantity)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,15 @@
+package locations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type LocationService interface {
+	GetByCode(ctx *krogo.Context, code string) (*models.Location, error)
+	GetAll(ctx *krogo.Context) ([]models.Location, error)
+	Create(ctx *krogo.Context, location *models.Location) (*models.Location, error)
+	SetStock(ctx *krogo.Context, pID string, stock *models.LocationStock) (*models.LocationStock, error)
+	GetAvailability(ctx *krogo.Context, id, pID, code string) ([]models.LocationStock, error)
+	GetNearest(ctx *krogo.Context, id, pID, code string) (*models.LocationStock, error)
+}
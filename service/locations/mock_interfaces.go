@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package locations is a generated GoMock package.
+package locations
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockLocationService is a mock of LocationService interface.
+type MockLocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationServiceMockRecorder
+}
+
+// MockLocationServiceMockRecorder is the mock recorder for MockLocationService.
+type MockLocationServiceMockRecorder struct {
+	mock *MockLocationService
+}
+
+// NewMockLocationService creates a new mock instance.
+func NewMockLocationService(ctrl *gomock.Controller) *MockLocationService {
+	mock := &MockLocationService{ctrl: ctrl}
+	mock.recorder = &MockLocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationService) EXPECT() *MockLocationServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLocationService) Create(ctx *krogo.Context, location *models.Location) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, location)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLocationServiceMockRecorder) Create(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLocationService)(nil).Create), ctx, location)
+}
+
+// GetAll mocks base method.
+func (m *MockLocationService) GetAll(ctx *krogo.Context) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLocationServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLocationService)(nil).GetAll), ctx)
+}
+
+// GetAvailability mocks base method.
+func (m *MockLocationService) GetAvailability(ctx *krogo.Context, id, pID, code string) ([]models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, id, pID, code)
+	ret0, _ := ret[0].([]models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockLocationServiceMockRecorder) GetAvailability(ctx, id, pID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockLocationService)(nil).GetAvailability), ctx, id, pID, code)
+}
+
+// GetByCode mocks base method.
+func (m *MockLocationService) GetByCode(ctx *krogo.Context, code string) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockLocationServiceMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockLocationService)(nil).GetByCode), ctx, code)
+}
+
+// GetNearest mocks base method.
+func (m *MockLocationService) GetNearest(ctx *krogo.Context, id, pID, code string) (*models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearest", ctx, id, pID, code)
+	ret0, _ := ret[0].(*models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearest indicates an expected call of GetNearest.
+func (mr *MockLocationServiceMockRecorder) GetNearest(ctx, id, pID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearest", reflect.TypeOf((*MockLocationService)(nil).GetNearest), ctx, id, pID, code)
+}
+
+// SetStock mocks base method.
+func (m *MockLocationService) SetStock(ctx *krogo.Context, pID string, stock *models.LocationStock) (*models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", ctx, pID, stock)
+	ret0, _ := ret[0].(*models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockLocationServiceMockRecorder) SetStock(ctx, pID, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockLocationService)(nil).SetStock), ctx, pID, stock)
+}
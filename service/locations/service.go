@@ -0,0 +1,183 @@
+package locations
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"math"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/inventory"
+	"practice-app/store/locations"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+)
+
+const earthRadiusKM = 6371.0
+
+type Service struct {
+	store        locations.LocationStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store locations.LocationStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+func (s *Service) GetByCode(ctx *krogo.Context, code string) (*models.Location, error) {
+	l, err := s.store.GetByCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: code, Entity: "locations"}
+		}
+
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.Location, error) {
+	return s.store.GetAll(ctx)
+}
+
+func (s *Service) Create(ctx *krogo.Context, location *models.Location) (*models.Location, error) {
+	missingAttributes := findMissingAttributes(location)
+
+	if len(missingAttributes) > 0 {
+		return nil, errors.MissingParam{Param: missingAttributes}
+	}
+
+	if location.Type != "store" && location.Type != "warehouse" {
+		return nil, errors.InvalidParam{Param: []string{"type"}}
+	}
+
+	if math.Abs(location.Latitude) > 90 || math.Abs(location.Longitude) > 180 {
+		return nil, errors.InvalidParam{Param: []string{"latitude", "longitude"}}
+	}
+
+	return s.store.Create(ctx, location)
+}
+
+func (s *Service) SetStock(ctx *krogo.Context, pID string, stock *models.LocationStock) (*models.LocationStock, error) {
+	if stock.OnHand < 0 {
+		return nil, errors.InvalidParam{Param: []string{"on_hand"}}
+	}
+
+	if _, err := access.Editable(ctx, s.productStore, pID); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Variant(ctx, s.variantStore, stock.VariantID, pID); err != nil {
+		return nil, err
+	}
+
+	l, err := s.GetByCode(ctx, stock.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	stock.Location = *l
+
+	res, err := s.store.SetStock(ctx, stock)
+	if err == inventory.ErrInsufficientStock {
+		return nil, &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "INSUFFICIENT_STOCK",
+			Reason:     "the stock of variant " + stock.VariantID + " would fall below its reserved quantity",
+		}
+	}
+
+	return res, err
+}
+
+func (s *Service) GetAvailability(ctx *krogo.Context, id, pID, code string) ([]models.LocationStock, error) {
+	if _, err := access.Product(ctx, s.productStore, pID); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Variant(ctx, s.variantStore, id, pID); err != nil {
+		return nil, err
+	}
+
+	if code != "" {
+		if _, err := s.GetByCode(ctx, code); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.store.GetStock(ctx, id, code)
+}
+
+// GetNearest finds the location closest to the one identified by code that has the variant in stock.
+func (s *Service) GetNearest(ctx *krogo.Context, id, pID, code string) (*models.LocationStock, error) {
+	if _, err := access.Product(ctx, s.productStore, pID); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Variant(ctx, s.variantStore, id, pID); err != nil {
+		return nil, err
+	}
+
+	origin, err := s.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := s.store.GetStock(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var nearest *models.LocationStock
+
+	for i := range stocks {
+		if stocks[i].OnHand <= 0 {
+			continue
+		}
+
+		d := distance(origin, &stocks[i].Location)
+
+		if nearest == nil || d < *nearest.DistanceKM {
+			nearest = &stocks[i]
+			nearest.DistanceKM = &d
+		}
+	}
+
+	if nearest == nil {
+		return nil, errors.EntityNotFound{ID: id, Entity: "in stock locations"}
+	}
+
+	return nearest, nil
+}
+
+// distance is the great-circle distance between two locations in kilometers, using the haversine formula.
+func distance(a, b *models.Location) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(h))
+}
+
+func findMissingAttributes(location *models.Location) (res []string) {
+	if location.Code == "" {
+		res = append(res, "code")
+	}
+
+	if location.Name == "" {
+		res = append(res, "name")
+	}
+
+	if location.Type == "" {
+		res = append(res, "type")
+	}
+
+	return res
+}
@@ -0,0 +1,310 @@
+package locations
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/inventory"
+	"practice-app/store/locations"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationStore := locations.NewMockLocationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockLocationStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Location
+		ExpectedResult *models.Location
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5},
+			ExpectedResult: &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5},
+			Calls: []*gomock.Call{
+				mockLocationStore.EXPECT().Create(ctx, gomock.Any()).
+					Return(&models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.Location{},
+			ExpectedErr: errors.MissingParam{Param: []string{"code", "name", "type"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: invalid type",
+			Body:        &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "kiosk"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"type"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: invalid coordinates",
+			Body:        &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 120},
+			ExpectedErr: errors.InvalidParam{Param: []string{"latitude", "longitude"}},
+			Calls:       []*gomock.Call{},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationStore := locations.NewMockLocationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockLocationStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Location
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"},
+			Calls: []*gomock.Call{
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "CIN1", Entity: "locations"},
+			Calls: []*gomock.Call{
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByCode(ctx, "CIN1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationStore := locations.NewMockLocationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockLocationStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+	location := models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.LocationStock
+		ExpectedResult *models.LocationStock
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 3},
+			ExpectedResult: &models.LocationStock{Location: location, VariantID: "1", OnHand: 3},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&location, nil),
+				mockLocationStore.EXPECT().SetStock(ctx, &models.LocationStock{Location: location, VariantID: "1", OnHand: 3}).
+					Return(&models.LocationStock{Location: location, VariantID: "1", OnHand: 3}, nil),
+			},
+		},
+		{
+			Desc: "Failure: below reserved stock",
+			Body: &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 1},
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "INSUFFICIENT_STOCK",
+				Reason:     "the stock of variant 1 would fall below its reserved quantity",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&location, nil),
+				mockLocationStore.EXPECT().SetStock(ctx, &models.LocationStock{Location: location, VariantID: "1", OnHand: 1}).
+					Return(nil, inventory.ErrInsufficientStock),
+			},
+		},
+		{
+			Desc:        "Failure: negative on hand",
+			Body:        &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: -3},
+			ExpectedErr: errors.InvalidParam{Param: []string{"on_hand"}},
+			Calls:       []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: unknown location",
+			Body:        &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 3},
+			ExpectedErr: errors.EntityNotFound{ID: "CIN1", Entity: "locations"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 3},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetStock(ctx, "1", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationStore := locations.NewMockLocationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockLocationStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	location := models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store"}
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult []models.LocationStock
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Code:           "CIN1",
+			ExpectedResult: []models.LocationStock{{Location: location, VariantID: "1", OnHand: 3}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&location, nil),
+				mockLocationStore.EXPECT().GetStock(ctx, "1", "CIN1").
+					Return([]models.LocationStock{{Location: location, VariantID: "1", OnHand: 3}}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetAvailability(ctx, "1", "1", test.Code)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetNearest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLocationStore := locations.NewMockLocationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockLocationStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	origin := models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.10, Longitude: -84.51}
+	dayton := models.Location{Code: "DAY1", Name: "Dayton", Type: "store", Latitude: 39.76, Longitude: -84.19}
+	columbus := models.Location{Code: "COL1", Name: "Columbus", Type: "warehouse", Latitude: 39.96, Longitude: -83.00}
+
+	testcases := []struct {
+		Desc         string
+		ExpectedCode string
+		ExpectedErr  error
+		Calls        []*gomock.Call
+	}{
+		{
+			Desc:         "Success: skips locations without stock",
+			ExpectedCode: "DAY1",
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&origin, nil),
+				mockLocationStore.EXPECT().GetStock(ctx, "1", "").Return([]models.LocationStock{
+					{Location: origin, VariantID: "1", OnHand: 0},
+					{Location: columbus, VariantID: "1", OnHand: 8},
+					{Location: dayton, VariantID: "1", OnHand: 2},
+				}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: out of stock everywhere",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "in stock locations"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(&models.Variant{ID: "1", ProductID: "1"}, nil),
+				mockLocationStore.EXPECT().GetByCode(ctx, "CIN1").Return(&origin, nil),
+				mockLocationStore.EXPECT().GetStock(ctx, "1", "").Return([]models.LocationStock{
+					{Location: origin, VariantID: "1", OnHand: 0},
+				}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetNearest(ctx, "1", "1", "CIN1")
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+
+		if test.ExpectedCode != "" {
+			assert.Equalf(t, test.ExpectedCode, res.Code, "TEST[%v] FAILED - %s", i, test.Desc)
+			assert.InDeltaf(t, 78, *res.DistanceKM, 2, "TEST[%v] FAILED - %s", i, test.Desc)
+		}
+	}
+}
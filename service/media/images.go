@@ -0,0 +1,276 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder with image.Decode
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// maxPixels guards against decompression bombs: small files that decode to huge images.
+	maxPixels   = 40_000_000
+	jpegQuality = 90
+
+	errImageTooLarge = errors.Error("image too large")
+)
+
+// thumbnailWidths are the widths thumbnails are generated in. Widths not smaller than the original are skipped.
+var thumbnailWidths = []int{160, 480}
+
+type encodedImage struct {
+	data   []byte
+	ext    string
+	width  int
+	height int
+}
+
+// processImage decodes an uploaded image, turns it upright according to its EXIF orientation and encodes it
+// again along with its thumbnails. Re-encoding drops all metadata, EXIF included. JPEGs stay JPEGs; every
+// other format is stored as PNG.
+func processImage(data []byte) (original encodedImage, thumbnails []encodedImage, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return encodedImage{}, nil, err
+	}
+
+	if cfg.Width*cfg.Height > maxPixels {
+		return encodedImage{}, nil, errImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return encodedImage{}, nil, err
+	}
+
+	img := toRGBA(src)
+
+	if format == "jpeg" {
+		img = orient(img, exifOrientation(data))
+	}
+
+	encode := encodePNG
+	if format == "jpeg" {
+		encode = encodeJPEG
+	}
+
+	if original, err = encode(img); err != nil {
+		return encodedImage{}, nil, err
+	}
+
+	for _, w := range thumbnailWidths {
+		if w >= original.width {
+			continue
+		}
+
+		h := original.height * w / original.width
+		if h < 1 {
+			h = 1
+		}
+
+		t, err := encode(resize(img, w, h))
+		if err != nil {
+			return encodedImage{}, nil, err
+		}
+
+		thumbnails = append(thumbnails, t)
+	}
+
+	return original, thumbnails, nil
+}
+
+func encodeJPEG(img *image.RGBA) (encodedImage, error) {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return encodedImage{}, err
+	}
+
+	return encodedImage{data: buf.Bytes(), ext: "jpg", width: img.Rect.Dx(), height: img.Rect.Dy()}, nil
+}
+
+func encodePNG(img *image.RGBA) (encodedImage, error) {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		return encodedImage{}, err
+	}
+
+	return encodedImage{data: buf.Bytes(), ext: "png", width: img.Rect.Dx(), height: img.Rect.Dy()}, nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, src, b.Min, draw.Src)
+
+	return dst
+}
+
+// resize scales an image down with a box filter: every destination pixel is the average of the source
+// pixels it covers.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var sum [4]int
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					p := src.PixOffset(sx, sy)
+					for c := 0; c < 4; c++ {
+						sum[c] += int(src.Pix[p+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			p := dst.PixOffset(x, y)
+
+			for c := 0; c < 4; c++ {
+				dst.Pix[p+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+// orient applies an EXIF orientation (1 to 8) so that the image displays upright without its metadata.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+
+	// orientations 5 to 8 swap width and height
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // needs a 90° clockwise turn
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // needs a 90° counter-clockwise turn
+				sx, sy = w-1-y, x
+			}
+
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
+
+// exifOrientation reads the orientation tag from the EXIF block of a JPEG, and returns 1 (upright) when
+// there is none.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+
+		marker := data[i+1]
+
+		// start of scan: no metadata segments follow
+		if marker == 0xDA {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+size]
+
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + size
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	const orientationTag = 0x0112
+
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+
+	for i := 0; i < entries; i++ {
+		e := ifd + 2 + i*12
+		if e+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[e:]) == orientationTag {
+			if o := int(order.Uint16(tiff[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+
+			return 1
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,145 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("error while encoding test image %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF block carrying the given orientation right after the JPEG start marker.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2))
+	out = append(out, segment...)
+
+	return append(out, data[2:]...)
+}
+
+func Test_processImage(t *testing.T) {
+	testcases := []struct {
+		Desc           string
+		Data           []byte
+		ExpectedExt    string
+		ExpectedWidth  int
+		ExpectedHeight int
+		ExpectedThumbs []int
+	}{
+		{
+			Desc:           "JPEG with thumbnails",
+			Data:           testJPEG(t, 640, 480),
+			ExpectedExt:    "jpg",
+			ExpectedWidth:  640,
+			ExpectedHeight: 480,
+			ExpectedThumbs: []int{160, 480},
+		},
+		{
+			Desc:           "JPEG turned upright",
+			Data:           withOrientation(testJPEG(t, 300, 200), 6),
+			ExpectedExt:    "jpg",
+			ExpectedWidth:  200,
+			ExpectedHeight: 300,
+			ExpectedThumbs: []int{160},
+		},
+		{
+			Desc:           "small image has no thumbnails",
+			Data:           testJPEG(t, 100, 50),
+			ExpectedExt:    "jpg",
+			ExpectedWidth:  100,
+			ExpectedHeight: 50,
+		},
+	}
+
+	for i, test := range testcases {
+		original, thumbnails, err := processImage(test.Data)
+
+		assert.NoErrorf(t, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedExt, original.ext, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedWidth, original.width, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedHeight, original.height, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, 1, exifOrientation(original.data), "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Falsef(t, bytes.Contains(original.data, []byte("Exif")), "TEST[%v] FAILED - %s", i, test.Desc)
+
+		var widths []int
+		for _, thumb := range thumbnails {
+			widths = append(widths, thumb.width)
+		}
+
+		assert.Equalf(t, test.ExpectedThumbs, widths, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_processImage_PNG(t *testing.T) {
+	var buf bytes.Buffer
+
+	_ = png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 200, 100)))
+
+	original, thumbnails, err := processImage(buf.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "png", original.ext)
+	assert.Len(t, thumbnails, 1)
+	assert.Equal(t, 160, thumbnails[0].width)
+	assert.Equal(t, 80, thumbnails[0].height)
+}
+
+func Test_exifOrientation(t *testing.T) {
+	photo := testJPEG(t, 4, 4)
+
+	assert.Equal(t, 1, exifOrientation(photo))
+	assert.Equal(t, 8, exifOrientation(withOrientation(photo, 8)))
+	assert.Equal(t, 1, exifOrientation(withOrientation(photo, 42)))
+	assert.Equal(t, 1, exifOrientation([]byte("not a jpeg")))
+}
+
+func Test_orient(t *testing.T) {
+	// 2x1 image: red on the left, blue on the right
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+	src.Set(1, 0, color.RGBA{B: 255, A: 255})
+
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+
+	mirrored := orient(src, 2)
+	assert.Equal(t, blue, mirrored.At(0, 0))
+
+	// a clockwise turn puts the left pixel on top
+	turned := orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), turned.Rect)
+	assert.Equal(t, red, turned.At(0, 0))
+	assert.Equal(t, blue, turned.At(0, 1))
+
+	// a counter-clockwise turn puts the right pixel on top
+	turned = orient(src, 8)
+	assert.Equal(t, blue, turned.At(0, 0))
+	assert.Equal(t, red, turned.At(0, 1))
+}
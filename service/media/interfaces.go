@@ -0,0 +1,15 @@
+package media
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type MediaService interface {
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Media, error)
+	Create(ctx *krogo.Context, media *models.Media) (*models.Media, error)
+	Upload(ctx *krogo.Context, media *models.Media, data []byte) (*models.Media, error)
+	GetFile(ctx *krogo.Context, key string) (data []byte, contentType string, err error)
+	Update(ctx *krogo.Context, media *models.Media) (*models.Media, error)
+	Delete(ctx *krogo.Context, productID string, id int) error
+}
@@ -0,0 +1,285 @@
+package media
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/blob"
+	"practice-app/store/media"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"strconv"
+	"strings"
+)
+
+const rolePrimary = "primary"
+
+// roles are the places an image can be shown in: the main picture, a color or pattern swatch, or a lifestyle shot.
+var roles = map[string]bool{rolePrimary: true, "swatch": true, "lifestyle": true}
+
+type Service struct {
+	store        media.MediaStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+	blobStore    blob.BlobStore
+}
+
+func New(store media.MediaStore, productStore products.ProductStore, variantStore variants.VariantStore,
+	blobStore blob.BlobStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore, blobStore: blobStore}
+}
+
+func (s *Service) GetByProductID(ctx *krogo.Context, productID string) ([]models.Media, error) {
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// Create adds an image at the end of the gallery of a product, or of one of its variants.
+func (s *Service) Create(ctx *krogo.Context, m *models.Media) (*models.Media, error) {
+	if err := validate(m); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTarget(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return s.store.Create(ctx, m)
+}
+
+// Upload stores an uploaded image and its thumbnails in the blob store and adds it to the gallery.
+// The stored files are re-encoded, which strips EXIF and any other metadata the upload carried.
+func (s *Service) Upload(ctx *krogo.Context, m *models.Media, data []byte) (*models.Media, error) {
+	if m.Role == "" {
+		return nil, errors.MissingParam{Param: []string{"role"}}
+	}
+
+	if !roles[m.Role] {
+		return nil, errors.InvalidParam{Param: []string{"role"}}
+	}
+
+	original, thumbnails, err := processImage(data)
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{"file"}}
+	}
+
+	if err = s.checkTarget(ctx, m); err != nil {
+		return nil, err
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+
+	m.BlobKey = name + "." + original.ext
+	m.Width, m.Height = original.width, original.height
+
+	if m.URL, err = s.blobStore.Put(ctx, m.BlobKey, original.data); err != nil {
+		return nil, err
+	}
+
+	for _, t := range thumbnails {
+		url, err := s.blobStore.Put(ctx, thumbnailKey(m.BlobKey, t.width), t.data)
+		if err != nil {
+			s.deleteBlobs(ctx, m)
+
+			return nil, err
+		}
+
+		m.Thumbnails = append(m.Thumbnails, models.Thumbnail{Width: t.width, Height: t.height, URL: url})
+	}
+
+	created, err := s.store.Create(ctx, m)
+	if err != nil {
+		s.deleteBlobs(ctx, m)
+
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetFile returns an uploaded file and its content type.
+func (s *Service) GetFile(ctx *krogo.Context, key string) (data []byte, contentType string, err error) {
+	data, err = s.blobStore.Get(ctx, key)
+	if err != nil {
+		if os.IsNotExist(err) || err == blob.ErrInvalidKey {
+			return nil, "", errors.EntityNotFound{ID: key, Entity: "files"}
+		}
+
+		return nil, "", err
+	}
+
+	return data, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+// Update replaces the URL, alt text, role, dimensions and position of an image. It stays attached to the same
+// product or variant.
+func (s *Service) Update(ctx *krogo.Context, m *models.Media) (*models.Media, error) {
+	if err := validate(m); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Editable(ctx, s.productStore, m.ProductID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.get(ctx, m.ProductID, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.VariantID = existing.VariantID
+
+	if err = s.checkPrimary(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return s.store.Update(ctx, m)
+}
+
+// Delete removes an image from the gallery, along with its files when it was uploaded.
+func (s *Service) Delete(ctx *krogo.Context, productID string, id int) error {
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return err
+	}
+
+	m, err := s.get(ctx, productID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.store.Delete(ctx, productID, id); err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, m)
+
+	return nil
+}
+
+func (s *Service) get(ctx *krogo.Context, productID string, id int) (*models.Media, error) {
+	m, err := s.store.GetByID(ctx, productID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "media"}
+		}
+
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// checkTarget makes sure the product, and the variant if one is given, exist, that the user making the request may
+// change the product, and that the image does not become a second primary image.
+func (s *Service) checkTarget(ctx *krogo.Context, m *models.Media) error {
+	if _, err := access.Editable(ctx, s.productStore, m.ProductID); err != nil {
+		return err
+	}
+
+	if m.VariantID != "" {
+		if _, err := s.variantStore.GetByID(ctx, m.VariantID, m.ProductID); err != nil {
+			if err == sql.ErrNoRows {
+				return errors.InvalidParam{Param: []string{"variant_id"}}
+			}
+
+			return err
+		}
+	}
+
+	return s.checkPrimary(ctx, m)
+}
+
+// deleteBlobs removes the files of an uploaded image. It is best effort: a file left behind is harmless,
+// while failing the request after the gallery has changed would not be.
+func (s *Service) deleteBlobs(ctx *krogo.Context, m *models.Media) {
+	if m.BlobKey == "" {
+		return
+	}
+
+	_ = s.blobStore.Delete(ctx, m.BlobKey)
+
+	for _, t := range m.Thumbnails {
+		_ = s.blobStore.Delete(ctx, thumbnailKey(m.BlobKey, t.Width))
+	}
+}
+
+// checkPrimary allows a single primary image per product gallery and per variant gallery.
+func (s *Service) checkPrimary(ctx *krogo.Context, m *models.Media) error {
+	if m.Role != rolePrimary {
+		return nil
+	}
+
+	gallery, err := s.store.GetByProductID(ctx, m.ProductID)
+	if err != nil {
+		return err
+	}
+
+	for i := range gallery {
+		if gallery[i].Role == rolePrimary && gallery[i].VariantID == m.VariantID && gallery[i].ID != m.ID {
+			return &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "PRIMARY_EXISTS",
+				Reason:     "image " + strconv.Itoa(gallery[i].ID) + " is already the primary image",
+			}
+		}
+	}
+
+	return nil
+}
+
+func validate(m *models.Media) error {
+	var missing []string
+
+	if m.URL == "" {
+		missing = append(missing, "url")
+	}
+
+	if m.Role == "" {
+		missing = append(missing, "role")
+	}
+
+	if len(missing) > 0 {
+		return errors.MissingParam{Param: missing}
+	}
+
+	if !roles[m.Role] {
+		return errors.InvalidParam{Param: []string{"role"}}
+	}
+
+	if m.Width < 0 || m.Height < 0 || m.Position < 0 {
+		return errors.InvalidParam{Param: []string{"width", "height", "position"}}
+	}
+
+	return nil
+}
+
+// thumbnailKey names the thumbnail of an uploaded image after the original, e.g. "ab12_w160.jpg" for "ab12.jpg".
+func thumbnailKey(key string, width int) string {
+	ext := path.Ext(key)
+
+	return strings.TrimSuffix(key, ext) + "_w" + strconv.Itoa(width) + ext
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,330 @@
+package media
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"practice-app/models"
+	"practice-app/store/blob"
+	"practice-app/store/media"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockMediaStore, mockProductStore, mockVariantStore, blob.NewMockBlobStore(ctrl))
+
+	ctx := userContext("u2")
+
+	gallery := []models.Media{
+		{ID: 1, ProductID: "1", URL: "front", Role: "primary"},
+		{ID: 2, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch"},
+	}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Media
+		ExpectedResult *models.Media
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: variant primary image",
+			Body:           &models.Media{ProductID: "1", VariantID: "1-red", URL: "red-front", Role: "primary"},
+			ExpectedResult: &models.Media{ID: 3, ProductID: "1", VariantID: "1-red", URL: "red-front", Role: "primary"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-red", "1").Return(&models.Variant{ID: "1-red"}, nil),
+				mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return(gallery, nil),
+				mockMediaStore.EXPECT().Create(ctx, gomock.Any()).
+					Return(&models.Media{ID: 3, ProductID: "1", VariantID: "1-red", URL: "red-front", Role: "primary"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: second product primary image",
+			Body: &models.Media{ProductID: "1", URL: "back", Role: "primary"},
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "PRIMARY_EXISTS",
+				Reason:     "image 1 is already the primary image",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return(gallery, nil),
+			},
+		},
+		{
+			Desc:        "Failure: unknown variant",
+			Body:        &models.Media{ProductID: "1", VariantID: "nope", URL: "url", Role: "swatch"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"variant_id"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "nope", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: invalid role",
+			Body:        &models.Media{ProductID: "1", URL: "url", Role: "banner"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"role"}},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.Media{ProductID: "1"},
+			ExpectedErr: errors.MissingParam{Param: []string{"url", "role"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Body:        &models.Media{ProductID: "2", URL: "url", Role: "lifestyle"},
+			ExpectedErr: errors.EntityNotFound{ID: "2", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "2").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        &models.Media{ProductID: "1", URL: "url", Role: "lifestyle"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockMediaStore, mockProductStore, variants.NewMockVariantStore(ctrl), blob.NewMockBlobStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Media
+		ExpectedResult *models.Media
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: keeps variant",
+			Body:           &models.Media{ID: 2, ProductID: "1", URL: "red", Role: "swatch", Position: 4},
+			ExpectedResult: &models.Media{ID: 2, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch", Position: 4},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByID(ctx, "1", 2).
+					Return(&models.Media{ID: 2, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch"}, nil),
+				mockMediaStore.EXPECT().Update(ctx, &models.Media{ID: 2, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch", Position: 4}).
+					Return(&models.Media{ID: 2, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch", Position: 4}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Body:        &models.Media{ID: 9, ProductID: "1", URL: "url", Role: "swatch"},
+			ExpectedErr: errors.EntityNotFound{ID: "9", Entity: "media"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByID(ctx, "1", 9).Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: negative position",
+			Body:        &models.Media{ID: 2, ProductID: "1", URL: "url", Role: "swatch", Position: -1},
+			ExpectedErr: errors.InvalidParam{Param: []string{"width", "height", "position"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Update(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockBlobStore := blob.NewMockBlobStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockMediaStore, mockProductStore, variants.NewMockVariantStore(ctrl), mockBlobStore)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc        string
+		ID          int
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success: hosted elsewhere",
+			ID:   2,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByID(ctx, "1", 2).Return(&models.Media{ID: 2, ProductID: "1"}, nil),
+				mockMediaStore.EXPECT().Delete(ctx, "1", 2).Return(nil),
+			},
+		},
+		{
+			Desc: "Success: uploaded",
+			ID:   3,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByID(ctx, "1", 3).Return(&models.Media{ID: 3, ProductID: "1", BlobKey: "ab12.jpg",
+					Thumbnails: []models.Thumbnail{{Width: 160}}}, nil),
+				mockMediaStore.EXPECT().Delete(ctx, "1", 3).Return(nil),
+				mockBlobStore.EXPECT().Delete(ctx, "ab12.jpg").Return(nil),
+				mockBlobStore.EXPECT().Delete(ctx, "ab12_w160.jpg").Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ID:          4,
+			ExpectedErr: errors.EntityNotFound{ID: "4", Entity: "media"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockMediaStore.EXPECT().GetByID(ctx, "1", 4).Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			ID:          2,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, "1", test.ID)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockBlobStore := blob.NewMockBlobStore(ctrl)
+	mockService := New(mockMediaStore, mockProductStore, variants.NewMockVariantStore(ctrl), mockBlobStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	photo := testJPEG(t, 640, 480)
+
+	testcases := []struct {
+		Desc        string
+		Body        *models.Media
+		Data        []byte
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Body: &models.Media{ProductID: "1", Role: "lifestyle"},
+			Data: photo,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *krogo.Context, key string, _ []byte) (string, error) { return "/media/files/" + key, nil }).
+					Times(3),
+				mockMediaStore.EXPECT().Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ *krogo.Context, m *models.Media) (*models.Media, error) { return m, nil }),
+			},
+		},
+		{
+			Desc:        "Failure: gallery insert removes files",
+			Body:        &models.Media{ProductID: "1", Role: "lifestyle"},
+			Data:        photo,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return("url", nil).Times(3),
+				mockMediaStore.EXPECT().Create(ctx, gomock.Any()).Return(nil, errors.DB{Err: errors.Error("DB Error")}),
+				mockBlobStore.EXPECT().Delete(ctx, gomock.Any()).Return(nil).Times(3),
+			},
+		},
+		{
+			Desc:        "Failure: not an image",
+			Body:        &models.Media{ProductID: "1", Role: "lifestyle"},
+			Data:        []byte("not an image"),
+			ExpectedErr: errors.InvalidParam{Param: []string{"file"}},
+		},
+		{
+			Desc:        "Failure: invalid role",
+			Body:        &models.Media{ProductID: "1", Role: "banner"},
+			Data:        photo,
+			ExpectedErr: errors.InvalidParam{Param: []string{"role"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Upload(ctx, test.Body, test.Data)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+
+		if test.ExpectedErr != nil {
+			continue
+		}
+
+		key := res.BlobKey
+		assert.Regexpf(t, "^[0-9a-f]{32}\\.jpg$", key, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, "/media/files/"+key, res.URL, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, 640, res.Width, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, 480, res.Height, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, []models.Thumbnail{
+			{Width: 160, Height: 120, URL: "/media/files/" + key[:32] + "_w160.jpg"},
+			{Width: 480, Height: 360, URL: "/media/files/" + key[:32] + "_w480.jpg"},
+		}, res.Thumbnails, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBlobStore := blob.NewMockBlobStore(ctrl)
+	mockService := New(media.NewMockMediaStore(ctrl), products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl), mockBlobStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	mockBlobStore.EXPECT().Get(ctx, "ab12.png").Return([]byte("png"), nil)
+	mockBlobStore.EXPECT().Get(ctx, "missing.jpg").Return(nil, os.ErrNotExist)
+
+	data, contentType, err := mockService.GetFile(ctx, "ab12.png")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+	assert.Equal(t, "image/png", contentType)
+
+	_, _, err = mockService.GetFile(ctx, "missing.jpg")
+
+	assert.Equal(t, errors.EntityNotFound{ID: "missing.jpg", Entity: "files"}, err)
+}
@@ -0,0 +1,12 @@
+package options
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type OptionService interface {
+	Get(ctx *krogo.Context, productID string) ([]models.OptionAxis, error)
+	Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) ([]models.OptionAxis, error)
+	GenerateVariants(ctx *krogo.Context, productID string) ([]models.Variant, error)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package options is a generated GoMock package.
+package options
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockOptionService is a mock of OptionService interface.
+type MockOptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOptionServiceMockRecorder
+}
+
+// MockOptionServiceMockRecorder is the mock recorder for MockOptionService.
+type MockOptionServiceMockRecorder struct {
+	mock *MockOptionService
+}
+
+// NewMockOptionService creates a new mock instance.
+func NewMockOptionService(ctrl *gomock.Controller) *MockOptionService {
+	mock := &MockOptionService{ctrl: ctrl}
+	mock.recorder = &MockOptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOptionService) EXPECT() *MockOptionServiceMockRecorder {
+	return m.recorder
+}
+
+// GenerateVariants mocks base method.
+func (m *MockOptionService) GenerateVariants(ctx *krogo.Context, productID string) ([]models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateVariants", ctx, productID)
+	ret0, _ := ret[0].([]models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateVariants indicates an expected call of GenerateVariants.
+func (mr *MockOptionServiceMockRecorder) GenerateVariants(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateVariants", reflect.TypeOf((*MockOptionService)(nil).GenerateVariants), ctx, productID)
+}
+
+// Get mocks base method.
+func (m *MockOptionService) Get(ctx *krogo.Context, productID string) ([]models.OptionAxis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID)
+	ret0, _ := ret[0].([]models.OptionAxis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOptionServiceMockRecorder) Get(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOptionService)(nil).Get), ctx, productID)
+}
+
+// Set mocks base method.
+func (m *MockOptionService) Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) ([]models.OptionAxis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, axes)
+	ret0, _ := ret[0].([]models.OptionAxis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockOptionServiceMockRecorder) Set(ctx, productID, axes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockOptionService)(nil).Set), ctx, productID, axes)
+}
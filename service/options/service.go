@@ -0,0 +1,252 @@
+package options
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/options"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxVariants is how many combinations the option axes of a product may have at most, since a variant is generated for
+// each of them at once.
+const MaxVariants = 500
+
+type Service struct {
+	store        options.OptionStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store options.OptionStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+func (s *Service) Get(ctx *krogo.Context, productID string) ([]models.OptionAxis, error) {
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// Set replaces the option axes of a product. Variants created earlier keep the options they were created with.
+func (s *Service) Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) ([]models.OptionAxis, error) {
+	if err := validateAxes(axes); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Set(ctx, productID, axes); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// GenerateVariants creates a variant for every combination of the product's option values that no variant has yet,
+// all in one transaction, and returns the variants it created.
+func (s *Service) GenerateVariants(ctx *krogo.Context, productID string) ([]models.Variant, error) {
+	product, err := access.Editable(ctx, s.productStore, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	axes, err := s.store.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(axes) == 0 {
+		return nil, &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "NO_OPTIONS",
+			Reason:     "product " + productID + " has no options to generate variants from",
+		}
+	}
+
+	// axes set before there was a limit may exceed it
+	if !withinLimit(axes) {
+		return nil, &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "TOO_MANY_VARIANTS",
+			Reason:     "the options of product " + productID + " have more than " + strconv.Itoa(MaxVariants) + " combinations",
+		}
+	}
+
+	keys, err := s.variantStore.GetOptionKeys(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		existing[k] = true
+	}
+
+	// Variants of a draft are drafts themselves until the product is approved; every other product sells them
+	// right away.
+	status := models.StatusActive
+	if product.Status == models.StatusDraft {
+		status = models.StatusDraft
+	}
+
+	var missing []*models.Variant
+
+	for _, combination := range combinations(axes) {
+		if !existing[variants.OptionKey(combination)] {
+			missing = append(missing, newVariant(productID, status, axes, combination))
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	if err := s.checkIDs(ctx, missing); err != nil {
+		return nil, err
+	}
+
+	return s.variantStore.CreateAll(ctx, missing)
+}
+
+// checkIDs makes sure the IDs named after the option values are not used by any variant yet. Values that differ
+// only in case or punctuation give the same ID, so the generated variants are checked against each other too.
+func (s *Service) checkIDs(ctx *krogo.Context, generated []*models.Variant) error {
+	ids := make(map[string]bool, len(generated))
+
+	for _, v := range generated {
+		if ids[v.ID] {
+			return &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "VARIANT_EXISTS",
+				Reason:     "variant id " + v.ID + " is generated by more than one combination of options",
+			}
+		}
+
+		ids[v.ID] = true
+
+		existing, err := s.variantStore.Lookup(ctx, v.ID)
+
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		default:
+			return &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "VARIANT_EXISTS",
+				Reason:     "variant id " + v.ID + " is already used by a variant of product " + existing.ProductID,
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateAxes(axes []models.OptionAxis) error {
+	names := make(map[string]bool, len(axes))
+
+	for _, a := range axes {
+		if a.Name == "" || len(a.Values) == 0 || names[a.Name] {
+			return errors.InvalidParam{Param: []string{"options"}}
+		}
+
+		names[a.Name] = true
+		values := make(map[string]bool, len(a.Values))
+
+		for _, v := range a.Values {
+			if v == "" || values[v] {
+				return errors.InvalidParam{Param: []string{"options"}}
+			}
+
+			values[v] = true
+		}
+	}
+
+	if !withinLimit(axes) {
+		return errors.InvalidParam{Param: []string{"options"}}
+	}
+
+	return nil
+}
+
+// withinLimit reports whether the axes have MaxVariants combinations or fewer, without computing how many more they have.
+func withinLimit(axes []models.OptionAxis) bool {
+	n := 1
+
+	for _, a := range axes {
+		if n *= len(a.Values); n > MaxVariants {
+			return false
+		}
+	}
+
+	return true
+}
+
+// combinations returns the cartesian product of the axes' values. The first axis varies slowest, so the
+// result follows the order in which the axes and their values were declared.
+func combinations(axes []models.OptionAxis) []map[string]string {
+	result := []map[string]string{{}}
+
+	for _, a := range axes {
+		next := make([]map[string]string, 0, len(result)*len(a.Values))
+
+		for _, partial := range result {
+			for _, v := range a.Values {
+				c := make(map[string]string, len(partial)+1)
+				for name, value := range partial {
+					c[name] = value
+				}
+
+				c[a.Name] = v
+				next = append(next, c)
+			}
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// newVariant names a generated variant after its option values, e.g. ID "1-s-red", name "S / Red"
+// and details "Size: S, Color: Red".
+func newVariant(productID, status string, axes []models.OptionAxis, combination map[string]string) *models.Variant {
+	ids := []string{productID}
+	names := make([]string, 0, len(axes))
+	details := make([]string, 0, len(axes))
+
+	for _, a := range axes {
+		v := combination[a.Name]
+
+		ids = append(ids, slug(v))
+		names = append(names, v)
+		details = append(details, a.Name+": "+v)
+	}
+
+	return &models.Variant{
+		ID:        strings.Join(ids, "-"),
+		ProductID: productID,
+		Name:      strings.Join(names, " / "),
+		Details:   strings.Join(details, ", "),
+		Options:   combination,
+		Status:    status,
+	}
+}
+
+func slug(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
@@ -0,0 +1,272 @@
+package options
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/options"
+	"practice-app/store/products"
+	"practice-app/store/variants"
+	"strconv"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+// values returns n distinct option values.
+func values(n int) []string {
+	res := make([]string, n)
+	for i := range res {
+		res[i] = strconv.Itoa(i)
+	}
+
+	return res
+}
+
+// largeAxes have one combination more than MaxVariants.
+var largeAxes = []models.OptionAxis{{Name: "Size", Values: values(MaxVariants/2 + 1)}, {Name: "Color", Values: values(2)}}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOptionStore := options.NewMockOptionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockOptionStore, mockProductStore, variants.NewMockVariantStore(ctrl))
+
+	ctx := userContext("u2")
+
+	axes := []models.OptionAxis{{Name: "Size", Values: []string{"S", "M"}}}
+
+	testcases := []struct {
+		Desc           string
+		Body           []models.OptionAxis
+		ExpectedResult []models.OptionAxis
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           axes,
+			ExpectedResult: axes,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().Set(ctx, "1", axes).Return(nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: duplicate axis",
+			Body:        []models.OptionAxis{{Name: "Size", Values: []string{"S"}}, {Name: "Size", Values: []string{"M"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+		},
+		{
+			Desc:        "Failure: duplicate value",
+			Body:        []models.OptionAxis{{Name: "Size", Values: []string{"S", "S"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+		},
+		{
+			Desc:        "Failure: no values",
+			Body:        []models.OptionAxis{{Name: "Size"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+		},
+		{
+			Desc:        "Failure: too many combinations",
+			Body:        largeAxes,
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Body:        axes,
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        axes,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Set(ctx, "1", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GenerateVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOptionStore := options.NewMockOptionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockOptionStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+
+	axes := []models.OptionAxis{
+		{Name: "Size", Values: []string{"S", "M"}},
+		{Name: "Color", Values: []string{"Red"}},
+	}
+
+	sRed := models.Variant{
+		ID:        "1-s-red",
+		ProductID: "1",
+		Name:      "S / Red",
+		Details:   "Size: S, Color: Red",
+		Options:   map[string]string{"Size": "S", "Color": "Red"},
+		Status:    "active",
+	}
+	mRed := models.Variant{
+		ID:        "1-m-red",
+		ProductID: "1",
+		Name:      "M / Red",
+		Details:   "Size: M, Color: Red",
+		Options:   map[string]string{"Size": "M", "Color": "Red"},
+		Status:    "active",
+	}
+
+	draftRed := mRed
+	draftRed.Status = "draft"
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Variant
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: only missing combinations",
+			ExpectedResult: []models.Variant{mRed},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").Return([]string{variants.OptionKey(sRed.Options)}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m-red").Return(nil, sql.ErrNoRows),
+				mockVariantStore.EXPECT().CreateAll(ctx, []*models.Variant{&mRed}).Return([]models.Variant{mRed}, nil),
+			},
+		},
+		{
+			Desc:           "Success: draft variants of a draft",
+			ExpectedResult: []models.Variant{draftRed},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u2"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").Return([]string{variants.OptionKey(sRed.Options)}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m-red").Return(nil, sql.ErrNoRows),
+				mockVariantStore.EXPECT().CreateAll(ctx, []*models.Variant{&draftRed}).Return([]models.Variant{draftRed}, nil),
+			},
+		},
+		{
+			Desc: "Success: every combination exists",
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").
+					Return([]string{variants.OptionKey(sRed.Options), variants.OptionKey(mRed.Options)}, nil),
+			},
+		},
+		{
+			Desc: "Failure: generated id already used",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "VARIANT_EXISTS",
+				Reason: "variant id 1-m-red is already used by a variant of product 2"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").Return([]string{variants.OptionKey(sRed.Options)}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m-red").Return(&models.Variant{ID: "1-m-red", ProductID: "2"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: values give the same id",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "VARIANT_EXISTS",
+				Reason: "variant id 1-m is generated by more than one combination of options"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return([]models.OptionAxis{{Name: "Size", Values: []string{"M", "m"}}}, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").Return(nil, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(ctx, "1").Return([]string{variants.OptionKey(sRed.Options)}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m-red").Return(nil, sql.ErrNoRows),
+				mockVariantStore.EXPECT().CreateAll(ctx, []*models.Variant{&mRed}).Return(nil, errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+		{
+			Desc: "Failure: too many combinations",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "TOO_MANY_VARIANTS",
+				Reason: "the options of product 1 have more than 500 combinations"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(largeAxes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no options",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NO_OPTIONS", Reason: "product 1 has no options to generate variants from"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(ctx, "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc: "Failure: product in review",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+				Reason: "the product is in review and cannot change until it is approved or rejected"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GenerateVariants(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_combinations(t *testing.T) {
+	res := combinations([]models.OptionAxis{
+		{Name: "Size", Values: []string{"S", "M"}},
+		{Name: "Color", Values: []string{"Red", "Blue"}},
+	})
+
+	assert.Equal(t, []map[string]string{
+		{"Size": "S", "Color": "Red"},
+		{"Size": "S", "Color": "Blue"},
+		{"Size": "M", "Color": "Red"},
+		{"Size": "M", "Color": "Blue"},
+	}, res)
+}
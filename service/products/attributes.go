@@ -0,0 +1,91 @@
+package products
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"math"
+	"practice-app/models"
+	"sort"
+)
+
+// checkAttributes validates attribute values against the schema of the product's categories. Every value must
+// be declared by the schema and match its type, and every required attribute must be present.
+func checkAttributes(schema []models.AttributeDefinition, attributes map[string]interface{}) error {
+	var missing, invalid []string
+
+	definitions := make(map[string]models.AttributeDefinition, len(schema))
+
+	for _, d := range schema {
+		definitions[d.Name] = d
+
+		if _, ok := attributes[d.Name]; d.Required && !ok {
+			missing = append(missing, "attributes."+d.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.MissingParam{Param: missing}
+	}
+
+	for name, value := range attributes {
+		if d, ok := definitions[name]; !ok || !validValue(d, value) {
+			invalid = append(invalid, "attributes."+name)
+		}
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+
+		return errors.InvalidParam{Param: invalid}
+	}
+
+	return nil
+}
+
+func validValue(d models.AttributeDefinition, value interface{}) bool {
+	switch d.Type {
+	case models.AttributeString:
+		_, ok := value.(string)
+
+		return ok
+	case models.AttributeNumber:
+		_, ok := number(value)
+
+		return ok
+	case models.AttributeInteger:
+		f, ok := number(value)
+
+		return ok && f == math.Trunc(f)
+	case models.AttributeBoolean:
+		_, ok := value.(bool)
+
+		return ok
+	case models.AttributeEnum:
+		s, ok := value.(string)
+
+		return ok && contains(d.Values, s)
+	}
+
+	return false
+}
+
+// number reads a numeric value; JSON request bodies decode every number as a float64.
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case int:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package products
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_checkAttributes(t *testing.T) {
+	schema := []models.AttributeDefinition{
+		{Name: "cordless", Type: models.AttributeBoolean},
+		{Name: "fabric", Type: models.AttributeString},
+		{Name: "finish", Type: models.AttributeEnum, Values: []string{"matte", "gloss"}},
+		{Name: "voltage", Type: models.AttributeNumber, Unit: "V"},
+		{Name: "wattage", Type: models.AttributeInteger, Required: true, Unit: "W"},
+	}
+
+	testcases := []struct {
+		Desc        string
+		Attributes  map[string]interface{}
+		ExpectedErr error
+	}{
+		{
+			Desc: "valid values",
+			Attributes: map[string]interface{}{"cordless": true, "fabric": "steel", "finish": "gloss",
+				"voltage": 229.5, "wattage": float64(1500)},
+		},
+		{
+			Desc:        "required attribute missing",
+			Attributes:  map[string]interface{}{"cordless": true},
+			ExpectedErr: errors.MissingParam{Param: []string{"attributes.wattage"}},
+		},
+		{
+			Desc: "values of the wrong type",
+			Attributes: map[string]interface{}{"cordless": "yes", "finish": "satin", "voltage": "230",
+				"wattage": 1500.5, "color": "red"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes.color", "attributes.cordless", "attributes.finish",
+				"attributes.voltage", "attributes.wattage"}},
+		},
+		{
+			Desc:        "null value",
+			Attributes:  map[string]interface{}{"wattage": nil},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes.wattage"}},
+		},
+	}
+
+	for i, test := range testcases {
+		err := checkAttributes(schema, test.Attributes)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
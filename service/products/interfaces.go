@@ -9,4 +9,11 @@ type ProductService interface {
 	GetByID(ctx *krogo.Context, id string) (*models.ProductWithVariants, error)
 	GetAll(ctx *krogo.Context) ([]models.ProductWithVariants, error)
 	Create(ctx *krogo.Context, product *models.Product) (*models.Product, error)
+	SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) (map[string]interface{}, error)
+	SetTaxClass(ctx *krogo.Context, id, taxClass string) (*models.ProductTaxClass, error)
+	SetStatus(ctx *krogo.Context, id string, change *models.StatusChange, actor string) ([]models.Transition, error)
+	SetVariantStatus(ctx *krogo.Context, productID, variantID string, change *models.StatusChange,
+		actor string) ([]models.Transition, error)
+	SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) (*models.Schedule, error)
+	GetTransitions(ctx *krogo.Context, id string) ([]models.Transition, error)
 }
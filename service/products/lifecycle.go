@@ -0,0 +1,211 @@
+package products
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+)
+
+// transitions lists the statuses each status can move to. Drafts go live through review, right away or at their
+// publication time; discontinued products can come back and archived ones are kept for history only.
+var transitions = map[string][]string{
+	models.StatusDraft:        {models.StatusInReview, models.StatusArchived},
+	models.StatusInReview:     {models.StatusDraft, models.StatusActive, models.StatusScheduled},
+	models.StatusScheduled:    {models.StatusActive, models.StatusArchived},
+	models.StatusActive:       {models.StatusDiscontinued, models.StatusArchived},
+	models.StatusDiscontinued: {models.StatusActive, models.StatusArchived},
+	models.StatusArchived:     {},
+}
+
+// variantTransitions lists the statuses each status of a variant can move to. Variants are not reviewed: drafts go
+// live on their own once their product is published.
+var variantTransitions = map[string][]string{
+	models.StatusDraft:        {models.StatusActive, models.StatusArchived},
+	models.StatusActive:       {models.StatusDiscontinued, models.StatusArchived},
+	models.StatusDiscontinued: {models.StatusActive, models.StatusArchived},
+	models.StatusArchived:     {},
+}
+
+// SetStatus moves a product to another status. Discontinuing or archiving a product does the same to those of
+// its variants that are not past that point already, drafts included; the changes are applied and recorded together.
+func (s *Service) SetStatus(ctx *krogo.Context, id string, change *models.StatusChange, actor string) ([]models.Transition, error) {
+	if err := checkStatus(change.Status); err != nil {
+		return nil, err
+	}
+
+	p, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "products"}
+		}
+
+		return nil, err
+	}
+
+	if err = access.CheckEditable(p, actor); err != nil {
+		return nil, err
+	}
+
+	// submissions, approvals and rejections go through the approval endpoints, which keep track of the review
+	if change.Status == models.StatusInReview || change.Status == models.StatusScheduled ||
+		p.Status == models.StatusDraft && change.Status == models.StatusActive {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "APPROVAL_REQUIRED",
+			Reason: "drafts are published by submitting them for review and approving them"}
+	}
+
+	if err = checkTransition(transitions, p.Status, change.Status); err != nil {
+		return nil, err
+	}
+
+	changes := []models.Transition{{ProductID: id, From: p.Status, To: change.Status, Actor: actor, Reason: change.Reason}}
+
+	if change.Status == models.StatusDiscontinued || change.Status == models.StatusArchived {
+		variants, err := s.variantStore.GetVariantData(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range variants {
+			if cascades(variants[i].Status, change.Status) {
+				changes = append(changes, models.Transition{ProductID: id, VariantID: variants[i].ID, From: variants[i].Status,
+					To: change.Status, Actor: actor, Reason: change.Reason})
+			}
+		}
+	}
+
+	return s.apply(ctx, changes)
+}
+
+// SetVariantStatus moves a variant to another status. A variant cannot become active before its product is
+// published, nor while it is discontinued or archived.
+func (s *Service) SetVariantStatus(ctx *krogo.Context, productID, variantID string, change *models.StatusChange,
+	actor string) ([]models.Transition, error) {
+	if err := checkStatus(change.Status); err != nil {
+		return nil, err
+	}
+
+	// only products are reviewed and scheduled
+	if change.Status == models.StatusInReview || change.Status == models.StatusScheduled {
+		return nil, errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	p, err := s.store.GetByID(ctx, productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: productID, Entity: "products"}
+		}
+
+		return nil, err
+	}
+
+	if err = access.CheckEditable(p, actor); err != nil {
+		return nil, err
+	}
+
+	v, err := s.variantStore.GetByID(ctx, variantID, productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: variantID, Entity: "variants"}
+		}
+
+		return nil, err
+	}
+
+	if err = checkTransition(variantTransitions, v.Status, change.Status); err != nil {
+		return nil, err
+	}
+
+	if change.Status == models.StatusActive && (!access.Published(p.Status) || p.Status == models.StatusDiscontinued ||
+		p.Status == models.StatusArchived) {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+			Reason: "variant cannot be active while its product is " + p.Status}
+	}
+
+	return s.apply(ctx, []models.Transition{{ProductID: productID, VariantID: variantID, From: v.Status, To: change.Status,
+		Actor: actor, Reason: change.Reason}})
+}
+
+// SetSchedule sets when a product is published and withdrawn. The publication time is used when the product is
+// approved; the withdrawal time discontinues it once it is active.
+func (s *Service) SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) (*models.Schedule, error) {
+	if err := checkSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Editable(ctx, s.store, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetSchedule(ctx, id, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// GetTransitions lists the status changes of a product and its variants, oldest first.
+func (s *Service) GetTransitions(ctx *krogo.Context, id string) ([]models.Transition, error) {
+	if _, err := access.Product(ctx, s.store, id); err != nil {
+		return nil, err
+	}
+
+	return s.lifecycleStore.GetByProductID(ctx, id)
+}
+
+func (s *Service) apply(ctx *krogo.Context, changes []models.Transition) ([]models.Transition, error) {
+	res, err := s.lifecycleStore.Apply(ctx, changes)
+	if err == sql.ErrNoRows {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "STATUS_CHANGED",
+			Reason: "the status was changed by another request, read it again before retrying"}
+	}
+
+	return res, err
+}
+
+func checkSchedule(schedule *models.Schedule) error {
+	if schedule.PublishAt != nil && schedule.UnpublishAt != nil && !schedule.UnpublishAt.After(*schedule.PublishAt) {
+		return errors.InvalidParam{Param: []string{"unpublish_at"}}
+	}
+
+	return nil
+}
+
+func checkStatus(status string) error {
+	if status == "" {
+		return errors.MissingParam{Param: []string{"status"}}
+	}
+
+	if _, ok := transitions[status]; !ok {
+		return errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	return nil
+}
+
+func checkTransition(table map[string][]string, from, to string) error {
+	if !allowed(table, from, to) {
+		return &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+			Reason: "cannot move from " + from + " to " + to}
+	}
+
+	return nil
+}
+
+// cascades reports whether a variant follows its product to a status. It does when it could move there on its own;
+// drafts cannot be discontinued on their own, but once their product is they cannot go live either.
+func cascades(from, to string) bool {
+	return allowed(variantTransitions, from, to) || from == models.StatusDraft && to == models.StatusDiscontinued
+}
+
+func allowed(table map[string][]string, from, to string) bool {
+	for _, status := range table[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,353 @@
+package products
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/brands"
+	"practice-app/store/bundles"
+	"practice-app/store/categories"
+	"practice-app/store/currencies"
+	"practice-app/store/lifecycle"
+	"practice-app/store/media"
+	"practice-app/store/products"
+	"practice-app/store/relationships"
+	"practice-app/store/translations"
+	"practice-app/store/variants"
+	"testing"
+	"time"
+)
+
+func TestService_SetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockLifecycleStore := lifecycle.NewMockLifecycleStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		mockLifecycleStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	at := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	archive := []models.Transition{{ProductID: "1", From: "draft", To: "archived", Actor: "u1"}}
+	reactivate := []models.Transition{{ProductID: "1", From: "discontinued", To: "active", Actor: "u1"}}
+	discontinue := []models.Transition{
+		{ProductID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "replaced"},
+		{ProductID: "1", VariantID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "replaced"},
+		{ProductID: "1", VariantID: "4", From: "draft", To: "discontinued", Actor: "u1", Reason: "replaced"},
+	}
+
+	testcases := []struct {
+		Desc           string
+		Change         *models.StatusChange
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: draft archived by its owner",
+			Change:         &models.StatusChange{Status: "archived"},
+			ExpectedResult: []models.Transition{{ID: 1, ProductID: "1", From: "draft", To: "archived", Actor: "u1", CreatedAt: at}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return(nil, nil),
+				mockLifecycleStore.EXPECT().Apply(ctx, archive).
+					Return([]models.Transition{{ID: 1, ProductID: "1", From: "draft", To: "archived", Actor: "u1", CreatedAt: at}}, nil),
+			},
+		},
+		{
+			Desc:           "Success: discontinue cascades to active and draft variants only",
+			Change:         &models.StatusChange{Status: "discontinued", Reason: "replaced"},
+			ExpectedResult: discontinue,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return([]models.VariantInfo{
+					{ID: "1", Status: "active"}, {ID: "2", Status: "archived"}, {ID: "3", Status: "discontinued"},
+					{ID: "4", Status: "draft"},
+				}, nil),
+				mockLifecycleStore.EXPECT().Apply(ctx, discontinue).Return(discontinue, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing status",
+			Change:      &models.StatusChange{},
+			ExpectedErr: errors.MissingParam{Param: []string{"status"}},
+		},
+		{
+			Desc:        "Failure: unknown status",
+			Change:      &models.StatusChange{Status: "retired"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Change:      &models.StatusChange{Status: "active"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:   "Failure: draft published without review",
+			Change: &models.StatusChange{Status: "active"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "APPROVAL_REQUIRED",
+				Reason: "drafts are published by submitting them for review and approving them"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+		{
+			Desc:   "Failure: scheduled without review",
+			Change: &models.StatusChange{Status: "scheduled"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "APPROVAL_REQUIRED",
+				Reason: "drafts are published by submitting them for review and approving them"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "discontinued"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Change:      &models.StatusChange{Status: "archived"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u2"}, nil),
+			},
+		},
+		{
+			Desc:   "Failure: in review",
+			Change: &models.StatusChange{Status: "draft"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+				Reason: "the product is in review and cannot change until it is approved or rejected"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+		{
+			Desc:   "Failure: archived products cannot come back",
+			Change: &models.StatusChange{Status: "active"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+				Reason: "cannot move from archived to active"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "archived"}, nil),
+			},
+		},
+		{
+			Desc:   "Failure: changed by another request",
+			Change: &models.StatusChange{Status: "active"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "STATUS_CHANGED",
+				Reason: "the status was changed by another request, read it again before retrying"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "discontinued"}, nil),
+				mockLifecycleStore.EXPECT().Apply(ctx, reactivate).Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetStatus(ctx, "1", test.Change, "u1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetVariantStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockLifecycleStore := lifecycle.NewMockLifecycleStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		mockLifecycleStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	reactivate := []models.Transition{{ProductID: "1", VariantID: "2", From: "discontinued", To: "active", Actor: "u1"}}
+	golive := []models.Transition{{ProductID: "1", VariantID: "2", From: "draft", To: "active", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		Change         *models.StatusChange
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Change:         &models.StatusChange{Status: "active"},
+			ExpectedResult: reactivate,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "2", "1").Return(&models.Variant{ID: "2", Status: "discontinued"}, nil),
+				mockLifecycleStore.EXPECT().Apply(ctx, reactivate).Return(reactivate, nil),
+			},
+		},
+		{
+			Desc:           "Success: draft of a published product goes live",
+			Change:         &models.StatusChange{Status: "active"},
+			ExpectedResult: golive,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "2", "1").Return(&models.Variant{ID: "2", Status: "draft"}, nil),
+				mockLifecycleStore.EXPECT().Apply(ctx, golive).Return(golive, nil),
+			},
+		},
+		{
+			Desc:   "Failure: draft of a draft product",
+			Change: &models.StatusChange{Status: "active"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+				Reason: "variant cannot be active while its product is draft"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "2", "1").Return(&models.Variant{ID: "2", Status: "draft"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variants are not reviewed",
+			Change:      &models.StatusChange{Status: "in_review"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+		},
+		{
+			Desc:        "Failure: variants are not scheduled",
+			Change:      &models.StatusChange{Status: "scheduled"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			Change:      &models.StatusChange{Status: "active"},
+			ExpectedErr: errors.EntityNotFound{ID: "2", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "2", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:   "Failure: product discontinued",
+			Change: &models.StatusChange{Status: "active"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "INVALID_TRANSITION",
+				Reason: "variant cannot be active while its product is discontinued"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "discontinued"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "2", "1").Return(&models.Variant{ID: "2", Status: "discontinued"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetVariantStatus(ctx, "1", "2", test.Change, "u1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), brands.NewMockBrandStore(ctrl),
+		media.NewMockMediaStore(ctrl), translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	publish := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	unpublish := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	schedule := &models.Schedule{PublishAt: &publish, UnpublishAt: &unpublish}
+
+	testcases := []struct {
+		Desc           string
+		Schedule       *models.Schedule
+		ExpectedResult *models.Schedule
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Schedule:       schedule,
+			ExpectedResult: schedule,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockProductStore.EXPECT().SetSchedule(ctx, "1", schedule).Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: withdrawn before it is published",
+			Schedule:    &models.Schedule{PublishAt: &unpublish, UnpublishAt: &publish},
+			ExpectedErr: errors.InvalidParam{Param: []string{"unpublish_at"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Schedule:    schedule,
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:     "Failure: in review",
+			Schedule: schedule,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+				Reason: "the product is in review and cannot change until it is approved or rejected"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetSchedule(ctx, "1", test.Schedule)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockLifecycleStore := lifecycle.NewMockLifecycleStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), brands.NewMockBrandStore(ctrl),
+		media.NewMockMediaStore(ctrl), translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		mockLifecycleStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	history := []models.Transition{{ID: 1, ProductID: "1", From: "draft", To: "active", Actor: "u1"}}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: history,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockLifecycleStore.EXPECT().GetByProductID(ctx, "1").Return(history, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetTransitions(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
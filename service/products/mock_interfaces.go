@@ -79,3 +79,93 @@ func (mr *MockProductServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProductService)(nil).GetByID), ctx, id)
 }
+
+// GetTransitions mocks base method.
+func (m *MockProductService) GetTransitions(ctx *krogo.Context, id string) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx, id)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockProductServiceMockRecorder) GetTransitions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockProductService)(nil).GetTransitions), ctx, id)
+}
+
+// SetAttributes mocks base method.
+func (m *MockProductService) SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttributes", ctx, id, attributes)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAttributes indicates an expected call of SetAttributes.
+func (mr *MockProductServiceMockRecorder) SetAttributes(ctx, id, attributes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttributes", reflect.TypeOf((*MockProductService)(nil).SetAttributes), ctx, id, attributes)
+}
+
+// SetSchedule mocks base method.
+func (m *MockProductService) SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) (*models.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, id, schedule)
+	ret0, _ := ret[0].(*models.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockProductServiceMockRecorder) SetSchedule(ctx, id, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockProductService)(nil).SetSchedule), ctx, id, schedule)
+}
+
+// SetStatus mocks base method.
+func (m *MockProductService) SetStatus(ctx *krogo.Context, id string, change *models.StatusChange, actor string) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, change, actor)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockProductServiceMockRecorder) SetStatus(ctx, id, change, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockProductService)(nil).SetStatus), ctx, id, change, actor)
+}
+
+// SetTaxClass mocks base method.
+func (m *MockProductService) SetTaxClass(ctx *krogo.Context, id, taxClass string) (*models.ProductTaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaxClass", ctx, id, taxClass)
+	ret0, _ := ret[0].(*models.ProductTaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTaxClass indicates an expected call of SetTaxClass.
+func (mr *MockProductServiceMockRecorder) SetTaxClass(ctx, id, taxClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaxClass", reflect.TypeOf((*MockProductService)(nil).SetTaxClass), ctx, id, taxClass)
+}
+
+// SetVariantStatus mocks base method.
+func (m *MockProductService) SetVariantStatus(ctx *krogo.Context, productID, variantID string, change *models.StatusChange, actor string) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariantStatus", ctx, productID, variantID, change, actor)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVariantStatus indicates an expected call of SetVariantStatus.
+func (mr *MockProductServiceMockRecorder) SetVariantStatus(ctx, productID, variantID, change, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariantStatus", reflect.TypeOf((*MockProductService)(nil).SetVariantStatus), ctx, productID, variantID, change, actor)
+}
@@ -4,22 +4,60 @@ import (
 	"database/sql"
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
 	"practice-app/models"
+	"practice-app/pricing"
+	"practice-app/service/access"
+	"practice-app/store/brands"
+	"practice-app/store/bundles"
+	"practice-app/store/categories"
+	"practice-app/store/currencies"
+	"practice-app/store/lifecycle"
+	"practice-app/store/media"
 	"practice-app/store/products"
+	"practice-app/store/relationships"
+	"practice-app/store/translations"
 	"practice-app/store/variants"
+	"strings"
+	"time"
 )
 
 type Service struct {
 	store        products.ProductStore
 	variantStore variants.VariantStore
+	brandStore   brands.BrandStore
+	mediaStore   media.MediaStore
+
+	translationStore translations.TranslationStore
+	categoryStore    categories.CategoryStore
+
+	relationshipStore relationships.RelationshipStore
+	bundleStore       bundles.BundleStore
+	currencyStore     currencies.CurrencyStore
+	lifecycleStore    lifecycle.LifecycleStore
 }
 
-func New(store products.ProductStore, variantStore variants.VariantStore) *Service {
-	return &Service{store: store, variantStore: variantStore}
+func New(store products.ProductStore, variantStore variants.VariantStore, brandStore brands.BrandStore,
+	mediaStore media.MediaStore, translationStore translations.TranslationStore, categoryStore categories.CategoryStore,
+	relationshipStore relationships.RelationshipStore, bundleStore bundles.BundleStore,
+	currencyStore currencies.CurrencyStore, lifecycleStore lifecycle.LifecycleStore) *Service {
+	return &Service{store: store, variantStore: variantStore, brandStore: brandStore, mediaStore: mediaStore,
+		translationStore: translationStore, categoryStore: categoryStore, relationshipStore: relationshipStore,
+		bundleStore: bundleStore, currencyStore: currencyStore, lifecycleStore: lifecycleStore}
 }
 
 func (s *Service) GetByID(ctx *krogo.Context, id string) (*models.ProductWithVariants, error) {
-	p, err := s.store.GetByID(ctx, id)
+	rate, err := s.rate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := asOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.read(ctx, id, at)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -29,15 +67,192 @@ func (s *Service) GetByID(ctx *krogo.Context, id string) (*models.ProductWithVar
 		return nil, err
 	}
 
-	res, _ := s.variantStore.GetVariantData(ctx, id)
+	if err = access.Preview(ctx, s.store, p); err != nil {
+		return nil, err
+	}
+
+	if err = s.readVariants(ctx, p, at); err != nil {
+		return nil, err
+	}
+
+	gallery, err := s.mediaStore.GetByProductID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attachMedia(p, gallery)
+
+	if err = s.attachBundle(ctx, p); err != nil {
+		return nil, err
+	}
+
+	if err = s.localize(ctx, p); err != nil {
+		return nil, err
+	}
+
+	// links to other products are only read when asked for with include=related
+	if included(ctx.Param("include"), "related") {
+		if p.Related, err = s.relationshipStore.GetByProductID(ctx, id, ""); err != nil {
+			return nil, err
+		}
+	}
 
-	p.Variant = res
+	if rate != nil {
+		pricing.ConvertProduct(p, rate)
+	}
 
 	return p, nil
 }
 
 func (s *Service) GetAll(ctx *krogo.Context) ([]models.ProductWithVariants, error) {
-	return s.store.GetAll(ctx, ctx.Params())
+	rate, err := s.rate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.store.GetAll(ctx, ctx.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.localizeAll(ctx, res); err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		if err = s.attachBundle(ctx, &res[i]); err != nil {
+			return nil, err
+		}
+
+		if rate != nil {
+			pricing.ConvertProduct(&res[i], rate)
+		}
+	}
+
+	return res, nil
+}
+
+// asOf reads the time a product is read as of from as_of=; it is nil when the product is read as it is now.
+func asOf(ctx *krogo.Context) (*time.Time, error) {
+	param := ctx.Param("as_of")
+	if param == "" {
+		return nil, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return nil, errors.InvalidParam{Param: []string{"as_of"}}
+	}
+
+	return &at, nil
+}
+
+// read reads a product as it is now, or as it was at a time according to its revisions.
+func (s *Service) read(ctx *krogo.Context, id string, at *time.Time) (*models.ProductWithVariants, error) {
+	if at == nil {
+		return s.store.GetByID(ctx, id)
+	}
+
+	return s.store.GetByIDAsOf(ctx, id, *at)
+}
+
+// readVariants reads the variants of a product as they are now, or as they were at a time.
+func (s *Service) readVariants(ctx *krogo.Context, p *models.ProductWithVariants, at *time.Time) error {
+	if at == nil {
+		p.Variant, _ = s.variantStore.GetVariantData(ctx, p.ID)
+
+		return nil
+	}
+
+	var err error
+
+	p.Variant, err = s.variantStore.GetVariantDataAsOf(ctx, p.ID, *at)
+
+	return err
+}
+
+// attachBundle reads the components of a bundle product. A bundle whose components are not set yet has none.
+func (s *Service) attachBundle(ctx *krogo.Context, p *models.ProductWithVariants) error {
+	if p.Type != models.ProductBundle {
+		return nil
+	}
+
+	b, err := s.bundleStore.Get(ctx, p.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	pricing.Derive(b)
+
+	p.Bundle = b
+
+	return nil
+}
+
+// localize translates a product and its variants into the languages of the request's Accept-Language header.
+// Text without a translation in any of them is left in the base language.
+func (s *Service) localize(ctx *krogo.Context, p *models.ProductWithVariants) error {
+	chain := translations.Chain(ctx.Header("Accept-Language"))
+	if len(chain) == 0 {
+		return nil
+	}
+
+	ts, err := s.translationStore.GetByProductID(ctx, p.ID, chain...)
+	if err != nil {
+		return err
+	}
+
+	translations.Localize(p, ts, chain)
+
+	return nil
+}
+
+// localizeAll localizes the products of a listing as localize does, reading the translations of all of them at once.
+func (s *Service) localizeAll(ctx *krogo.Context, list []models.ProductWithVariants) error {
+	chain := translations.Chain(ctx.Header("Accept-Language"))
+	if len(chain) == 0 || len(list) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(list))
+	for i := range list {
+		ids[i] = list[i].ID
+	}
+
+	ts, err := s.translationStore.GetByProductIDs(ctx, ids, chain...)
+	if err != nil {
+		return err
+	}
+
+	for i := range list {
+		translations.Localize(&list[i], ts[list[i].ID], chain)
+	}
+
+	return nil
+}
+
+// rate reads the exchange rate of the currency prices are asked for in with currency=. Prices stay in the base
+// currency when none is asked for.
+func (s *Service) rate(ctx *krogo.Context) (*models.CurrencyRate, error) {
+	code := strings.ToUpper(ctx.Param("currency"))
+	if code == "" || code == models.BaseCurrency {
+		return nil, nil
+	}
+
+	rate, err := s.currencyStore.GetByCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.InvalidParam{Param: []string{"currency"}}
+		}
+
+		return nil, err
+	}
+
+	return rate, nil
 }
 
 func (s *Service) Create(ctx *krogo.Context, product *models.Product) (*models.Product, error) {
@@ -47,9 +262,229 @@ func (s *Service) Create(ctx *krogo.Context, product *models.Product) (*models.P
 		return nil, errors.MissingParam{Param: missingAttributes}
 	}
 
+	switch product.Type {
+	case "":
+		product.Type = models.ProductStandard
+	case models.ProductStandard, models.ProductBundle:
+	default:
+		return nil, errors.InvalidParam{Param: []string{"type"}}
+	}
+
+	// products start out as drafts of their owner and only go live once a reviewer approves them
+	switch product.Status {
+	case "":
+		product.Status = models.StatusDraft
+	case models.StatusDraft:
+	default:
+		return nil, errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	if err := checkSchedule(&models.Schedule{PublishAt: product.PublishAt, UnpublishAt: product.UnpublishAt}); err != nil {
+		return nil, err
+	}
+
+	if product.TaxClass == "" {
+		product.TaxClass = models.TaxStandard
+	}
+
+	if !validTaxClass(product.TaxClass) {
+		return nil, errors.InvalidParam{Param: []string{"tax_class"}}
+	}
+
+	if err := s.resolveBrand(ctx, product); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCategories(ctx, product); err != nil {
+		return nil, err
+	}
+
 	return s.store.Create(ctx, product)
 }
 
+// SetAttributes replaces the attribute values of a product after checking them against the schema of the
+// categories the product is in.
+func (s *Service) SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) (map[string]interface{}, error) {
+	if _, err := access.Editable(ctx, s.store, id); err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.categoryStore.GetByProductID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(assigned))
+
+	for i := range assigned {
+		ids[i] = assigned[i].ID
+	}
+
+	schema, err := s.categoryStore.GetSchema(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkAttributes(schema, attributes); err != nil {
+		return nil, err
+	}
+
+	if err = s.store.SetAttributes(ctx, id, attributes); err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+// SetTaxClass changes the tax class a product is taxed under.
+func (s *Service) SetTaxClass(ctx *krogo.Context, id, taxClass string) (*models.ProductTaxClass, error) {
+	if taxClass == "" {
+		return nil, errors.MissingParam{Param: []string{"tax_class"}}
+	}
+
+	if !validTaxClass(taxClass) {
+		return nil, errors.InvalidParam{Param: []string{"tax_class"}}
+	}
+
+	if _, err := access.Editable(ctx, s.store, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetTaxClass(ctx, id, taxClass); err != nil {
+		return nil, err
+	}
+
+	return &models.ProductTaxClass{TaxClass: taxClass}, nil
+}
+
+// checkCategories makes sure the categories a new product is assigned to exist, and validates the product's
+// attributes against their schema.
+func (s *Service) checkCategories(ctx *krogo.Context, product *models.Product) error {
+	if len(product.CategoryIDs) == 0 {
+		return checkAttributes(nil, product.Attributes)
+	}
+
+	seen := make(map[string]bool, len(product.CategoryIDs))
+	ids := make([]string, 0, len(product.CategoryIDs))
+
+	for _, id := range product.CategoryIDs {
+		if seen[id] {
+			continue
+		}
+
+		if _, err := s.categoryStore.GetByID(ctx, id); err != nil {
+			if err == sql.ErrNoRows {
+				return errors.InvalidParam{Param: []string{"category_ids"}}
+			}
+
+			return err
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	product.CategoryIDs = ids
+
+	schema, err := s.categoryStore.GetSchema(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	return checkAttributes(schema, product.Attributes)
+}
+
+// resolveBrand links the product to a brand. A brand_id must point at an existing brand; a bare brand_name
+// is matched on its normalized form and a new brand is registered when nothing matches.
+func (s *Service) resolveBrand(ctx *krogo.Context, product *models.Product) error {
+	if product.BrandID != "" {
+		b, err := s.brandStore.GetByID(ctx, product.BrandID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.InvalidParam{Param: []string{"brand_id"}}
+			}
+
+			return err
+		}
+
+		product.BrandName = b.Name
+
+		return nil
+	}
+
+	if brands.Normalize(product.BrandName) == "" {
+		return errors.InvalidParam{Param: []string{"brand_name"}}
+	}
+
+	b, err := s.brandStore.GetByName(ctx, product.BrandName)
+	if err == sql.ErrNoRows {
+		b, err = s.registerBrand(ctx, product.BrandName)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	product.BrandID = b.ID
+	product.BrandName = b.Name
+
+	return nil
+}
+
+// registerBrand creates a brand for a name no brand uses yet. The ID is the slug of the name, which another brand
+// may already hold under a different name.
+func (s *Service) registerBrand(ctx *krogo.Context, name string) (*models.Brand, error) {
+	id := brands.Slug(name)
+
+	existing, err := s.brandStore.GetByID(ctx, id)
+	if err == nil {
+		return nil, &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "BRAND_EXISTS",
+			Reason:     "brand id " + id + " is already used by brand " + existing.Name,
+		}
+	}
+
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return s.brandStore.Create(ctx, &models.Brand{ID: id, Name: name})
+}
+
+// included reports whether a comma separated include parameter asks for a section of the response.
+func included(include, section string) bool {
+	for _, s := range strings.Split(include, ",") {
+		if strings.TrimSpace(s) == section {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validTaxClass reports whether a product may be assigned the tax class.
+func validTaxClass(taxClass string) bool {
+	return taxClass == models.TaxStandard || taxClass == models.TaxFood || taxClass == models.TaxExempt
+}
+
+// attachMedia hands out the images of a product's gallery to the product and to the variants they belong to.
+func attachMedia(p *models.ProductWithVariants, gallery []models.Media) {
+	for i := range gallery {
+		if gallery[i].VariantID == "" {
+			p.Media = append(p.Media, gallery[i])
+
+			continue
+		}
+
+		for j := range p.Variant {
+			if p.Variant[j].ID == gallery[i].VariantID {
+				p.Variant[j].Media = append(p.Variant[j].Media, gallery[i])
+			}
+		}
+	}
+}
+
 func findMissingAttributes(product *models.Product) (res []string) {
 	if product.ID == "" {
 		res = append(res, "id")
@@ -59,7 +494,7 @@ func findMissingAttributes(product *models.Product) (res []string) {
 		res = append(res, "name")
 	}
 
-	if product.BrandName == "" {
+	if product.BrandID == "" && product.BrandName == "" {
 		res = append(res, "brand_name")
 	}
 
@@ -67,9 +502,5 @@ func findMissingAttributes(product *models.Product) (res []string) {
 		res = append(res, "details")
 	}
 
-	if product.ImageUrl == "" {
-		res = append(res, "image_url")
-	}
-
 	return res
 }
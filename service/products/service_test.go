@@ -10,18 +10,41 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"practice-app/models"
+	"practice-app/store/brands"
+	"practice-app/store/bundles"
+	"practice-app/store/categories"
+	"practice-app/store/currencies"
+	"practice-app/store/lifecycle"
+	"practice-app/store/media"
 	"practice-app/store/products"
+	"practice-app/store/relationships"
+	"practice-app/store/translations"
 	"practice-app/store/variants"
 	"testing"
+	"time"
 )
 
+func getContext(target, acceptLanguage string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+
+	if acceptLanguage != "" {
+		r.Header.Set("Accept-Language", acceptLanguage)
+	}
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
 func TestHandler_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockProductStore := products.NewMockProductStore(ctrl)
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
-	mockService := New(mockProductStore, mockVariantStore)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
 
-	ctx := krogo.NewContext(nil, nil, krogo.New())
+	ctx := getContext("/products/1", "")
 
 	testcases := []struct {
 		Desc           string
@@ -43,7 +66,9 @@ func TestHandler_GetByID(t *testing.T) {
 					ID:      "1",
 					Name:    "variant_1",
 					Details: "details",
+					Media:   []models.Media{{ID: 2, ProductID: "1", VariantID: "1", URL: "swatch", Role: "swatch"}},
 				}},
+				Media: []models.Media{{ID: 1, ProductID: "1", URL: "url", Role: "primary"}},
 			},
 			ExpectedErr: nil,
 			Calls: []*gomock.Call{
@@ -59,6 +84,10 @@ func TestHandler_GetByID(t *testing.T) {
 					Name:    "variant_1",
 					Details: "details",
 				}}, nil),
+				mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return([]models.Media{
+					{ID: 1, ProductID: "1", URL: "url", Role: "primary"},
+					{ID: 2, ProductID: "1", VariantID: "1", URL: "swatch", Role: "swatch"},
+				}, nil),
 			},
 		},
 		{
@@ -93,10 +122,24 @@ func TestHandler_GetAll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockProductStore := products.NewMockProductStore(ctrl)
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
-	mockService := New(mockProductStore, mockVariantStore)
+	mockTranslationStore := translations.NewMockTranslationStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), media.NewMockMediaStore(ctrl),
+		mockTranslationStore, categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	product := models.ProductWithVariants{
+		ID:        "1",
+		Name:      "product_1",
+		BrandName: "brand_1",
+		Details:   "details",
+		ImageUrl:  "url",
+		Variant:   []models.VariantInfo{{ID: "1", Name: "variant_name", Details: "detail"}},
+	}
 
 	testcases := []struct {
 		Desc           string
+		AcceptLanguage string
 		ExpectedResult interface{}
 		ExpectedErr    error
 		Calls          []*gomock.Call
@@ -131,14 +174,44 @@ func TestHandler_GetAll(t *testing.T) {
 				}}, nil),
 			},
 		},
+		{
+			Desc:           "Success: localized, translations read at once",
+			AcceptLanguage: "es-MX,en;q=0.5",
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "producto_1",
+				BrandName: "brand_1",
+				Details:   "detalles",
+				ImageUrl:  "url",
+				Variant:   []models.VariantInfo{{ID: "1", Name: "variante", Details: "detail"}},
+			}, {ID: "2", Name: "product_2"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetAll(gomock.Any(), map[string]string{"pid": "1"}).
+					Return([]models.ProductWithVariants{product, {ID: "2", Name: "product_2"}}, nil),
+				mockTranslationStore.EXPECT().GetByProductIDs(gomock.Any(), []string{"1", "2"}, "es-MX", "es").
+					Return(map[string][]models.Translation{"1": {
+						{Locale: "es", Name: "producto", Details: "detalles"},
+						{Locale: "es-MX", Name: "producto_1"},
+						{VariantID: "1", Locale: "es", Name: "variante"},
+					}}, nil),
+			},
+		},
+		{
+			Desc:           "Failure: DB error",
+			AcceptLanguage: "es",
+			ExpectedResult: []models.ProductWithVariants(nil),
+			ExpectedErr:    errors.DB{Err: errors.Error("db error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetAll(gomock.Any(), map[string]string{"pid": "1"}).
+					Return([]models.ProductWithVariants{product}, nil),
+				mockTranslationStore.EXPECT().GetByProductIDs(gomock.Any(), []string{"1"}, "es").
+					Return(nil, errors.DB{Err: errors.Error("db error")}),
+			},
+		},
 	}
 
 	for i, test := range testcases {
-		target := "/products?pid=1"
-		r := httptest.NewRequest(http.MethodGet, target, nil)
-		req := request.NewHTTPRequest(r)
-		ctx := krogo.NewContext(nil, req, krogo.New())
-		res, err := mockService.GetAll(ctx)
+		res, err := mockService.GetAll(getContext("/products?pid=1", test.AcceptLanguage))
 
 		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
@@ -149,7 +222,11 @@ func TestHandler_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockProductStore := products.NewMockProductStore(ctrl)
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
-	mockService := New(mockProductStore, mockVariantStore)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, mockBrandStore, media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
 
 	testcases := []struct {
 		Desc           string
@@ -163,9 +240,13 @@ func TestHandler_Create(t *testing.T) {
 			ExpectedResult: &models.Product{
 				ID:        "1",
 				Name:      "product_1",
+				BrandID:   "brand-1",
 				BrandName: "brand_1",
 				Details:   "details",
 				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "draft",
 			},
 			ExpectedErr: nil,
 			Body: &models.Product{
@@ -176,25 +257,153 @@ func TestHandler_Create(t *testing.T) {
 				ImageUrl:  "url",
 			},
 			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByName(gomock.Any(), "brand_1").Return(&models.Brand{ID: "brand-1", Name: "brand_1"}, nil),
 				mockProductStore.EXPECT().Create(gomock.Any(), &models.Product{
 					ID:        "1",
 					Name:      "product_1",
+					BrandID:   "brand-1",
 					BrandName: "brand_1",
 					Details:   "details",
 					ImageUrl:  "url",
+					Type:      "standard",
+					TaxClass:  "standard",
+					Status:    "draft",
 				}).Return(&models.Product{
 					ID:        "1",
 					Name:      "product_1",
+					BrandID:   "brand-1",
 					BrandName: "brand_1",
 					Details:   "details",
 					ImageUrl:  "url",
+					Type:      "standard",
+					TaxClass:  "standard",
+					Status:    "draft",
+				}, nil),
+			},
+		},
+		{
+			Desc: "Success: new brand registered, no image",
+			ExpectedResult: &models.Product{
+				ID:        "2",
+				Name:      "product_2",
+				BrandID:   "kroger",
+				BrandName: "Kroger®",
+				Details:   "details",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "draft",
+			},
+			ExpectedErr: nil,
+			Body: &models.Product{
+				ID:        "2",
+				Name:      "product_2",
+				BrandName: "Kroger®",
+				Details:   "details",
+			},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByName(gomock.Any(), "Kroger®").Return(nil, sql.ErrNoRows),
+				mockBrandStore.EXPECT().GetByID(gomock.Any(), "kroger").Return(nil, sql.ErrNoRows),
+				mockBrandStore.EXPECT().Create(gomock.Any(), &models.Brand{ID: "kroger", Name: "Kroger®"}).
+					Return(&models.Brand{ID: "kroger", Name: "Kroger®"}, nil),
+				mockProductStore.EXPECT().Create(gomock.Any(), &models.Product{
+					ID:        "2",
+					Name:      "product_2",
+					BrandID:   "kroger",
+					BrandName: "Kroger®",
+					Details:   "details",
+					Type:      "standard",
+					TaxClass:  "standard",
+					Status:    "draft",
+				}).Return(&models.Product{
+					ID:        "2",
+					Name:      "product_2",
+					BrandID:   "kroger",
+					BrandName: "Kroger®",
+					Details:   "details",
+					Type:      "standard",
+					TaxClass:  "standard",
+					Status:    "draft",
 				}, nil),
 			},
 		},
+		{
+			Desc:           "Failure: brand id taken by another brand",
+			ExpectedResult: nil,
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "BRAND_EXISTS",
+				Reason:     "brand id kroger is already used by brand Kroger Co",
+			},
+			Body: &models.Product{
+				ID:        "2",
+				Name:      "product_2",
+				BrandName: "Kroger®",
+				Details:   "details",
+				ImageUrl:  "url",
+			},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByName(gomock.Any(), "Kroger®").Return(nil, sql.ErrNoRows),
+				mockBrandStore.EXPECT().GetByID(gomock.Any(), "kroger").Return(&models.Brand{ID: "kroger", Name: "Kroger Co"}, nil),
+			},
+		},
+		{
+			Desc:           "Failure: unknown brand id",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"brand_id"}},
+			Body: &models.Product{
+				ID:       "3",
+				Name:     "product_3",
+				BrandID:  "nope",
+				Details:  "details",
+				ImageUrl: "url",
+			},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(gomock.Any(), "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:           "Failure: unknown type",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"type"}},
+			Body: &models.Product{
+				ID:       "4",
+				Name:     "product_4",
+				BrandID:  "b1",
+				Details:  "details",
+				ImageUrl: "url",
+				Type:     "kit",
+			},
+		},
+		{
+			Desc:           "Failure: created live",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"status"}},
+			Body: &models.Product{
+				ID:       "6",
+				Name:     "product_6",
+				BrandID:  "b1",
+				Details:  "details",
+				ImageUrl: "url",
+				Status:   "active",
+			},
+		},
+		{
+			Desc:           "Failure: unknown tax class",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.InvalidParam{Param: []string{"tax_class"}},
+			Body: &models.Product{
+				ID:       "5",
+				Name:     "product_5",
+				BrandID:  "b1",
+				Details:  "details",
+				ImageUrl: "url",
+				TaxClass: "luxury",
+			},
+		},
 		{
 			Desc:           "Failure missing params",
 			ExpectedResult: nil,
-			ExpectedErr:    errors.MissingParam{Param: []string{"id", "name", "brand_name", "details", "image_url"}},
+			ExpectedErr:    errors.MissingParam{Param: []string{"id", "name", "brand_name", "details"}},
 			Body:           &models.Product{},
 			Calls:          []*gomock.Call{},
 		},
@@ -208,3 +417,504 @@ func TestHandler_Create(t *testing.T) {
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func TestService_CreateWithAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), mockBrandStore, media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl), mockCategoryStore,
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	schema := []models.AttributeDefinition{
+		{CategoryID: "kettles", Name: "finish", Type: "enum", Values: []string{"matte", "gloss"}},
+		{CategoryID: "appliances", Name: "wattage", Type: "integer", Required: true, Unit: "W"},
+	}
+
+	product := func(categoryIDs []string, attributes map[string]interface{}) *models.Product {
+		return &models.Product{ID: "1", Name: "kettle", BrandID: "b1", Details: "details", ImageUrl: "url",
+			CategoryIDs: categoryIDs, Attributes: attributes}
+	}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Product
+		ExpectedResult *models.Product
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           product([]string{"kettles", "kettles"}, map[string]interface{}{"wattage": float64(1500), "finish": "matte"}),
+			ExpectedResult: product([]string{"kettles"}, map[string]interface{}{"wattage": float64(1500), "finish": "matte"}),
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "kettles").Return(&models.Category{ID: "kettles"}, nil),
+				mockCategoryStore.EXPECT().GetSchema(ctx, []string{"kettles"}).Return(schema, nil),
+				mockProductStore.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(_ *krogo.Context, p *models.Product) (*models.Product, error) { return p, nil }),
+			},
+		},
+		{
+			Desc:        "Failure: unknown category",
+			Body:        product([]string{"toasters"}, nil),
+			ExpectedErr: errors.InvalidParam{Param: []string{"category_ids"}},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "toasters").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: required attribute missing",
+			Body:        product([]string{"kettles"}, map[string]interface{}{"finish": "matte"}),
+			ExpectedErr: errors.MissingParam{Param: []string{"attributes.wattage"}},
+			Calls: []*gomock.Call{
+				mockCategoryStore.EXPECT().GetByID(ctx, "kettles").Return(&models.Category{ID: "kettles"}, nil),
+				mockCategoryStore.EXPECT().GetSchema(ctx, []string{"kettles"}).Return(schema, nil),
+			},
+		},
+		{
+			Desc:        "Failure: attributes without categories",
+			Body:        product(nil, map[string]interface{}{"wattage": float64(1500)}),
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes.wattage"}},
+		},
+	}
+
+	for i, test := range testcases {
+		mockBrandStore.EXPECT().GetByID(ctx, "b1").Return(&models.Brand{ID: "b1", Name: "brand_1"}, nil)
+
+		if test.ExpectedResult != nil {
+			test.ExpectedResult.BrandName = "brand_1"
+			test.ExpectedResult.Type = "standard"
+			test.ExpectedResult.TaxClass = "standard"
+			test.ExpectedResult.Status = "draft"
+		}
+
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), brands.NewMockBrandStore(ctrl),
+		media.NewMockMediaStore(ctrl), translations.NewMockTranslationStore(ctrl), mockCategoryStore,
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	schema := []models.AttributeDefinition{{CategoryID: "shirts", Name: "fabric", Type: "string", Required: true}}
+
+	testcases := []struct {
+		Desc           string
+		Body           map[string]interface{}
+		ExpectedResult map[string]interface{}
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           map[string]interface{}{"fabric": "linen"},
+			ExpectedResult: map[string]interface{}{"fabric": "linen"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockCategoryStore.EXPECT().GetByProductID(ctx, "1").Return([]models.Category{{ID: "shirts"}}, nil),
+				mockCategoryStore.EXPECT().GetSchema(ctx, []string{"shirts"}).Return(schema, nil),
+				mockProductStore.EXPECT().SetAttributes(ctx, "1", map[string]interface{}{"fabric": "linen"}).Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: wrong type",
+			Body:        map[string]interface{}{"fabric": float64(3)},
+			ExpectedErr: errors.InvalidParam{Param: []string{"attributes.fabric"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockCategoryStore.EXPECT().GetByProductID(ctx, "1").Return([]models.Category{{ID: "shirts"}}, nil),
+				mockCategoryStore.EXPECT().GetSchema(ctx, []string{"shirts"}).Return(schema, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Body:        map[string]interface{}{"fabric": "linen"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetAttributes(ctx, "1", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetTaxClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), brands.NewMockBrandStore(ctrl),
+		media.NewMockMediaStore(ctrl), translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		TaxClass       string
+		ExpectedResult *models.ProductTaxClass
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			TaxClass:       "food",
+			ExpectedResult: &models.ProductTaxClass{TaxClass: "food"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockProductStore.EXPECT().SetTaxClass(ctx, "1", "food").Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing tax class",
+			ExpectedErr: errors.MissingParam{Param: []string{"tax_class"}},
+		},
+		{
+			Desc:        "Failure: unknown tax class",
+			TaxClass:    "luxury",
+			ExpectedErr: errors.InvalidParam{Param: []string{"tax_class"}},
+		},
+		{
+			Desc:     "Failure: draft of another user",
+			TaxClass: "food",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER",
+				Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+		{
+			Desc:     "Failure: in review",
+			TaxClass: "food",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+				Reason: "the product is in review and cannot change until it is approved or rejected"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			TaxClass:    "exempt",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetTaxClass(ctx, "1", test.TaxClass)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByIDPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	draft := &models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult *models.ProductWithVariants
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: valid token",
+			Target:         "/products/1?preview_token=abc",
+			ExpectedResult: draft,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(draft, nil),
+				mockProductStore.EXPECT().CheckPreview(gomock.Any(), "1", "abc").Return(true, nil),
+				mockVariantStore.EXPECT().GetVariantData(gomock.Any(), "1").Return(nil, nil),
+				mockMediaStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no token",
+			Target:      "/products/1",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: expired token",
+			Target:      "/products/1?preview_token=old",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+				mockProductStore.EXPECT().CheckPreview(gomock.Any(), "1", "old").Return(false, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByID(getContext(test.Target, ""), "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByIDAsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	at := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	variantsThen := []models.VariantInfo{{ID: "2", Name: "old name", Status: "active"}}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult *models.ProductWithVariants
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Target:         "/products/1?as_of=2023-05-01T10:00:00Z",
+			ExpectedResult: &models.ProductWithVariants{ID: "1", Name: "old name", Status: "active", Variant: variantsThen},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByIDAsOf(gomock.Any(), "1", at).
+					Return(&models.ProductWithVariants{ID: "1", Name: "old name", Status: "active"}, nil),
+				mockVariantStore.EXPECT().GetVariantDataAsOf(gomock.Any(), "1", at).Return(variantsThen, nil),
+				mockMediaStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: did not exist yet",
+			Target:      "/products/1?as_of=2023-05-01T10:00:00Z",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByIDAsOf(gomock.Any(), "1", at).Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: invalid time",
+			Target:      "/products/1?as_of=yesterday",
+			ExpectedErr: errors.InvalidParam{Param: []string{"as_of"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByID(getContext(test.Target, ""), "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByIDIncludeRelated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockRelationshipStore := relationships.NewMockRelationshipStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl), mockRelationshipStore,
+		bundles.NewMockBundleStore(ctrl), currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := getContext("/products/1?include=related", "")
+
+	links := []models.Relationship{
+		{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory"},
+		{ProductID: "1", RelatedID: "3", RelatedName: "kettle v2", Type: "replaced_by"},
+	}
+
+	mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Name: "kettle"}, nil)
+	mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return(nil, nil)
+	mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return(nil, nil)
+	mockRelationshipStore.EXPECT().GetByProductID(ctx, "1", "").Return(links, nil)
+
+	res, err := mockService.GetByID(ctx, "1")
+
+	assert.Equal(t, &models.ProductWithVariants{ID: "1", Name: "kettle", Related: links}, res)
+	assert.NoError(t, err)
+}
+
+func TestService_GetByIDCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockCurrencyStore := currencies.NewMockCurrencyStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), mockCurrencyStore,
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	updated := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	rate := &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated}
+
+	testcases := []struct {
+		Desc           string
+		Target         string
+		ExpectedResult *models.ProductWithVariants
+		ExpectedErr    error
+		Calls          func(ctx *krogo.Context)
+	}{
+		{
+			Desc:   "Success: converted",
+			Target: "/products/1?currency=cad",
+			ExpectedResult: &models.ProductWithVariants{ID: "1", Variant: []models.VariantInfo{{ID: "1", PriceCents: 2699}},
+				Conversion: &models.Conversion{Currency: "CAD", Rate: 1.35, RateUpdatedAt: updated}},
+			Calls: func(ctx *krogo.Context) {
+				mockCurrencyStore.EXPECT().GetByCode(ctx, "CAD").Return(rate, nil)
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil)
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return([]models.VariantInfo{{ID: "1", PriceCents: 1999}}, nil)
+				mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return(nil, nil)
+			},
+		},
+		{
+			Desc:           "Success: base currency",
+			Target:         "/products/1?currency=USD",
+			ExpectedResult: &models.ProductWithVariants{ID: "1", Variant: []models.VariantInfo{{ID: "1", PriceCents: 1999}}},
+			Calls: func(ctx *krogo.Context) {
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil)
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return([]models.VariantInfo{{ID: "1", PriceCents: 1999}}, nil)
+				mockMediaStore.EXPECT().GetByProductID(ctx, "1").Return(nil, nil)
+			},
+		},
+		{
+			Desc:        "Failure: currency without a rate",
+			Target:      "/products/1?currency=EUR",
+			ExpectedErr: errors.InvalidParam{Param: []string{"currency"}},
+			Calls: func(ctx *krogo.Context) {
+				mockCurrencyStore.EXPECT().GetByCode(ctx, "EUR").Return(nil, sql.ErrNoRows)
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		ctx := getContext(test.Target, "")
+		test.Calls(ctx)
+
+		res, err := mockService.GetByID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetAllCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockCurrencyStore := currencies.NewMockCurrencyStore(ctrl)
+	mockService := New(mockProductStore, variants.NewMockVariantStore(ctrl), brands.NewMockBrandStore(ctrl),
+		media.NewMockMediaStore(ctrl), translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), bundles.NewMockBundleStore(ctrl), mockCurrencyStore,
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := getContext("/products?pid=1&currency=MXN", "")
+
+	updated := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mockCurrencyStore.EXPECT().GetByCode(ctx, "MXN").
+		Return(&models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 100, UpdatedAt: updated}, nil)
+	mockProductStore.EXPECT().GetAll(ctx, gomock.Any()).
+		Return([]models.ProductWithVariants{{ID: "1", Variant: []models.VariantInfo{{ID: "1", PriceCents: 1999}}}}, nil)
+
+	res, err := mockService.GetAll(ctx)
+
+	assert.Equal(t, []models.ProductWithVariants{{ID: "1", Variant: []models.VariantInfo{{ID: "1", PriceCents: 34100}},
+		Conversion: &models.Conversion{Currency: "MXN", Rate: 17.0512, RateUpdatedAt: updated}}}, res)
+	assert.NoError(t, err)
+}
+
+func TestService_GetByIDBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockBundleStore := bundles.NewMockBundleStore(ctrl)
+	mockService := New(mockProductStore, mockVariantStore, brands.NewMockBrandStore(ctrl), mockMediaStore,
+		translations.NewMockTranslationStore(ctrl), categories.NewMockCategoryStore(ctrl),
+		relationships.NewMockRelationshipStore(ctrl), mockBundleStore,
+		currencies.NewMockCurrencyStore(ctrl),
+		lifecycle.NewMockLifecycleStore(ctrl))
+
+	ctx := getContext("/products/set", "")
+
+	bundle := &models.Bundle{
+		Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 1, ProductID: "k", PriceCents: 2999, Available: 4}},
+		Pricing:    models.BundlePricingFixed,
+		PriceCents: 2500,
+		Available:  4,
+	}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.ProductWithVariants
+		ExpectedErr    error
+		BundleErr      error
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.ProductWithVariants{ID: "set", Name: "tea set", Type: "bundle", Bundle: bundle},
+		},
+		{
+			Desc:           "Success: components not set yet",
+			ExpectedResult: &models.ProductWithVariants{ID: "set", Name: "tea set", Type: "bundle"},
+			BundleErr:      sql.ErrNoRows,
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			BundleErr:   errors.DB{Err: errors.Error("DB Error")},
+		},
+	}
+
+	for i, test := range testcases {
+		mockProductStore.EXPECT().GetByID(ctx, "set").Return(&models.ProductWithVariants{ID: "set", Name: "tea set", Type: "bundle"}, nil)
+		mockVariantStore.EXPECT().GetVariantData(ctx, "set").Return(nil, nil)
+		mockMediaStore.EXPECT().GetByProductID(ctx, "set").Return(nil, nil)
+
+		if test.BundleErr != nil {
+			mockBundleStore.EXPECT().Get(ctx, "set").Return(nil, test.BundleErr)
+		} else {
+			mockBundleStore.EXPECT().Get(ctx, "set").Return(bundle, nil)
+		}
+
+		res, err := mockService.GetByID(ctx, "set")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
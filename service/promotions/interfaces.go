@@ -0,0 +1,14 @@
+package promotions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type PromotionService interface {
+	GetAll(ctx *krogo.Context) ([]models.Promotion, error)
+	GetByID(ctx *krogo.Context, id int) (*models.Promotion, error)
+	Create(ctx *krogo.Context, promotion *models.Promotion) (*models.Promotion, error)
+	Delete(ctx *krogo.Context, id int) error
+	Evaluate(ctx *krogo.Context, request *models.EvaluationRequest) (*models.Evaluation, error)
+}
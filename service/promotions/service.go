@@ -0,0 +1,264 @@
+package promotions
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/pricing"
+	"practice-app/service/access"
+	"practice-app/store/brands"
+	"practice-app/store/categories"
+	"practice-app/store/products"
+	"practice-app/store/promotions"
+	"practice-app/store/variants"
+	"strconv"
+	"strings"
+)
+
+// maxQuantity bounds the quantity of an item that can be evaluated, since quantity promotions price units one by one.
+const maxQuantity = 1000
+
+type Service struct {
+	store        promotions.PromotionStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+
+	brandStore    brands.BrandStore
+	categoryStore categories.CategoryStore
+}
+
+func New(store promotions.PromotionStore, productStore products.ProductStore, variantStore variants.VariantStore,
+	brandStore brands.BrandStore, categoryStore categories.CategoryStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore, brandStore: brandStore,
+		categoryStore: categoryStore}
+}
+
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.Promotion, error) {
+	return s.store.GetAll(ctx)
+}
+
+func (s *Service) GetByID(ctx *krogo.Context, id int) (*models.Promotion, error) {
+	p, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "promotions"}
+		}
+
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *Service) Create(ctx *krogo.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	promotion.Name = strings.TrimSpace(promotion.Name)
+
+	var missing []string
+
+	if promotion.Name == "" {
+		missing = append(missing, "name")
+	}
+
+	if promotion.Type == "" {
+		missing = append(missing, "type")
+	}
+
+	if len(promotion.Targets) == 0 {
+		missing = append(missing, "targets")
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.MissingParam{Param: missing}
+	}
+
+	if err := validate(promotion); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTargets(ctx, promotion.Targets); err != nil {
+		return nil, err
+	}
+
+	return s.store.Create(ctx, promotion)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, id int) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// Evaluate prices a list of variants with the promotions running at the requested time, or now. Items of the
+// same variant are counted together.
+func (s *Service) Evaluate(ctx *krogo.Context, request *models.EvaluationRequest) (*models.Evaluation, error) {
+	if len(request.Items) == 0 {
+		return nil, errors.MissingParam{Param: []string{"items"}}
+	}
+
+	var (
+		lines   []pricing.Line
+		indexes = make(map[string]int)
+		known   = make(map[string]*pricing.Line)
+	)
+
+	for _, item := range request.Items {
+		if item.VariantID == "" || item.Quantity < 1 {
+			return nil, errors.InvalidParam{Param: []string{"items"}}
+		}
+
+		if i, ok := indexes[item.VariantID]; ok {
+			lines[i].Quantity += item.Quantity
+		} else {
+			l, err := s.line(ctx, item.VariantID, known)
+			if err != nil {
+				return nil, err
+			}
+
+			l.Quantity = item.Quantity
+			indexes[item.VariantID] = len(lines)
+			lines = append(lines, *l)
+		}
+
+		if lines[indexes[item.VariantID]].Quantity > maxQuantity {
+			return nil, errors.InvalidParam{Param: []string{"items"}}
+		}
+	}
+
+	active, err := s.store.GetActive(ctx, request.At)
+	if err != nil {
+		return nil, err
+	}
+
+	return pricing.Evaluate(active, lines), nil
+}
+
+// line looks up the price of a variant and the brand and categories of its product, which are read once per product.
+// A variant that is not for sale or has no price fails the evaluation rather than being priced.
+func (s *Service) line(ctx *krogo.Context, variantID string, products map[string]*pricing.Line) (*pricing.Line, error) {
+	v, err := s.variantStore.Lookup(ctx, variantID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.InvalidParam{Param: []string{"items"}}
+		}
+
+		return nil, err
+	}
+
+	product, ok := products[v.ProductID]
+	if !ok {
+		p, err := access.Product(ctx, s.productStore, v.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = access.ForSale(p.ID, p.Status); err != nil {
+			return nil, err
+		}
+
+		lineage, err := s.categoryStore.GetLineage(ctx, v.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		product = &pricing.Line{ProductID: p.ID, BrandID: p.BrandID}
+
+		for i := range lineage {
+			product.CategoryIDs = append(product.CategoryIDs, lineage[i].ID)
+		}
+
+		products[v.ProductID] = product
+	}
+
+	if err = access.ForSale(v.ID, v.Status); err != nil {
+		return nil, err
+	}
+
+	if v.PriceCents == 0 {
+		return nil, &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_PRICED",
+			Reason: "variant " + v.ID + " has no price"}
+	}
+
+	l := *product
+	l.VariantID = v.ID
+	l.UnitPriceCents = v.PriceCents
+
+	return &l, nil
+}
+
+// checkTargets makes sure every target of a promotion exists.
+func (s *Service) checkTargets(ctx *krogo.Context, targets []models.PromotionTarget) error {
+	for _, t := range targets {
+		var err error
+
+		switch t.Type {
+		case models.TargetProduct:
+			_, err = s.productStore.GetByID(ctx, t.ID)
+		case models.TargetVariant:
+			_, err = s.variantStore.Lookup(ctx, t.ID)
+		case models.TargetBrand:
+			_, err = s.brandStore.GetByID(ctx, t.ID)
+		case models.TargetCategory:
+			_, err = s.categoryStore.GetByID(ctx, t.ID)
+		default:
+			return errors.InvalidParam{Param: []string{"targets"}}
+		}
+
+		if err == sql.ErrNoRows {
+			return errors.InvalidParam{Param: []string{"targets"}}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validate checks that a promotion sets what its type needs and that its window ends after it starts.
+func validate(p *models.Promotion) error {
+	var invalid string
+
+	switch p.Type {
+	case models.PromotionPercentOff:
+		if p.PercentOff < 1 || p.PercentOff > 100 {
+			invalid = "percent_off"
+		}
+	case models.PromotionAmountOff:
+		if p.AmountOffCents <= 0 {
+			invalid = "amount_off_cents"
+		}
+	case models.PromotionBuyXGetY:
+		switch {
+		case p.BuyQuantity < 1:
+			invalid = "buy_quantity"
+		case p.GetQuantity < 1:
+			invalid = "get_quantity"
+		case p.PercentOff < 0 || p.PercentOff > 100:
+			invalid = "percent_off"
+		}
+	case models.PromotionMultiBuy:
+		switch {
+		case p.BuyQuantity < 2:
+			invalid = "buy_quantity"
+		case p.PriceCents <= 0:
+			invalid = "price_cents"
+		}
+	default:
+		invalid = "type"
+	}
+
+	if invalid == "" && p.EndsAt != nil && !p.StartsAt.IsZero() && !p.EndsAt.After(p.StartsAt) {
+		invalid = "ends_at"
+	}
+
+	if invalid != "" {
+		return errors.InvalidParam{Param: []string{invalid}}
+	}
+
+	return nil
+}
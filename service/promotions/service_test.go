@@ -0,0 +1,298 @@
+package promotions
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/brands"
+	"practice-app/store/categories"
+	"practice-app/store/products"
+	"practice-app/store/promotions"
+	"practice-app/store/variants"
+	"testing"
+	"time"
+)
+
+func TestService_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := promotions.NewMockPromotionStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl),
+		brands.NewMockBrandStore(ctrl), categories.NewMockCategoryStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	promotion := &models.Promotion{ID: 1, Name: "Summer sale", Type: "percent_off", PercentOff: 10}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Promotion
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: promotion,
+			Calls:          []*gomock.Call{mockStore.EXPECT().GetByID(ctx, 1).Return(promotion, nil)},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "promotions"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByID(ctx, 1).Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByID(ctx, 1)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := promotions.NewMockPromotionStore(ctrl)
+	mockBrandStore := brands.NewMockBrandStore(ctrl)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl),
+		mockBrandStore, mockCategoryStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	starts := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := starts.Add(-time.Hour)
+	brand := []models.PromotionTarget{{Type: "brand", ID: "acme"}}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Promotion
+		ExpectedResult *models.Promotion
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Promotion{Name: " Acme 10% ", Type: "percent_off", PercentOff: 10, Targets: brand},
+			ExpectedResult: &models.Promotion{ID: 1, Name: "Acme 10%", Type: "percent_off", PercentOff: 10, Targets: brand},
+			Calls: []*gomock.Call{
+				mockBrandStore.EXPECT().GetByID(ctx, "acme").Return(&models.Brand{ID: "acme"}, nil),
+				mockStore.EXPECT().Create(ctx, &models.Promotion{Name: "Acme 10%", Type: "percent_off", PercentOff: 10, Targets: brand}).
+					Return(&models.Promotion{ID: 1, Name: "Acme 10%", Type: "percent_off", PercentOff: 10, Targets: brand}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.Promotion{},
+			ExpectedErr: errors.MissingParam{Param: []string{"name", "type", "targets"}},
+		},
+		{
+			Desc:        "Failure: unknown type",
+			Body:        &models.Promotion{Name: "Free", Type: "free", Targets: brand},
+			ExpectedErr: errors.InvalidParam{Param: []string{"type"}},
+		},
+		{
+			Desc:        "Failure: percent out of range",
+			Body:        &models.Promotion{Name: "Too much", Type: "percent_off", PercentOff: 120, Targets: brand},
+			ExpectedErr: errors.InvalidParam{Param: []string{"percent_off"}},
+		},
+		{
+			Desc:        "Failure: amount off without an amount",
+			Body:        &models.Promotion{Name: "Nothing off", Type: "amount_off", Targets: brand},
+			ExpectedErr: errors.InvalidParam{Param: []string{"amount_off_cents"}},
+		},
+		{
+			Desc:        "Failure: buy X get nothing",
+			Body:        &models.Promotion{Name: "BOGO", Type: "buy_x_get_y", BuyQuantity: 1, Targets: brand},
+			ExpectedErr: errors.InvalidParam{Param: []string{"get_quantity"}},
+		},
+		{
+			Desc:        "Failure: multi buy of one",
+			Body:        &models.Promotion{Name: "1 for $5", Type: "multi_buy", BuyQuantity: 1, PriceCents: 500, Targets: brand},
+			ExpectedErr: errors.InvalidParam{Param: []string{"buy_quantity"}},
+		},
+		{
+			Desc: "Failure: ends before it starts",
+			Body: &models.Promotion{Name: "Acme 10%", Type: "percent_off", PercentOff: 10, Targets: brand,
+				StartsAt: starts, EndsAt: &before},
+			ExpectedErr: errors.InvalidParam{Param: []string{"ends_at"}},
+		},
+		{
+			Desc: "Failure: unknown target type",
+			Body: &models.Promotion{Name: "Acme 10%", Type: "percent_off", PercentOff: 10,
+				Targets: []models.PromotionTarget{{Type: "store", ID: "1"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"targets"}},
+		},
+		{
+			Desc: "Failure: target not found",
+			Body: &models.Promotion{Name: "Dairy 10%", Type: "percent_off", PercentOff: 10,
+				Targets: []models.PromotionTarget{{Type: "category", ID: "dairy"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"targets"}},
+			Calls:       []*gomock.Call{mockCategoryStore.EXPECT().GetByID(ctx, "dairy").Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := promotions.NewMockPromotionStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl),
+		brands.NewMockBrandStore(ctrl), categories.NewMockCategoryStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, 1).Return(&models.Promotion{ID: 1}, nil),
+				mockStore.EXPECT().Delete(ctx, 1).Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "promotions"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByID(ctx, 1).Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, 1)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Evaluate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := promotions.NewMockPromotionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockCategoryStore := categories.NewMockCategoryStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore,
+		brands.NewMockBrandStore(ctrl), mockCategoryStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	active := []models.Promotion{{ID: 1, Name: "Snacks 10%", Type: "percent_off", PercentOff: 10,
+		Targets: []models.PromotionTarget{{Type: "category", ID: "food"}}}}
+
+	testcases := []struct {
+		Desc           string
+		Request        *models.EvaluationRequest
+		ExpectedResult *models.Evaluation
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success: items of a variant counted together",
+			Request: &models.EvaluationRequest{Items: []models.EvaluationItem{
+				{VariantID: "1-s", Quantity: 1}, {VariantID: "1-m", Quantity: 1}, {VariantID: "1-s", Quantity: 1},
+			}},
+			ExpectedResult: &models.Evaluation{
+				Items: []models.EvaluatedItem{
+					{VariantID: "1-s", ProductID: "1", Quantity: 2, UnitPriceCents: 300, SubtotalCents: 600, DiscountCents: 60,
+						TotalCents: 540, Promotions: []int{1}},
+					{VariantID: "1-m", ProductID: "1", Quantity: 1, UnitPriceCents: 400, SubtotalCents: 400, DiscountCents: 40,
+						TotalCents: 360, Promotions: []int{1}},
+				},
+				Promotions:    []models.AppliedPromotion{{ID: 1, Name: "Snacks 10%", DiscountCents: 100}},
+				SubtotalCents: 1000, DiscountCents: 100, TotalCents: 900,
+			},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "active", PriceCents: 300}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active", BrandID: "acme"}, nil),
+				mockCategoryStore.EXPECT().GetLineage(ctx, "1").
+					Return([]models.Category{{ID: "food", Path: "food/"}, {ID: "snacks", ParentID: "food", Path: "food/snacks/"}}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "1-m").Return(&models.Variant{ID: "1-m", ProductID: "1", Status: "active", PriceCents: 400}, nil),
+				mockStore.EXPECT().GetActive(ctx, nil).Return(active, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no items",
+			Request:     &models.EvaluationRequest{},
+			ExpectedErr: errors.MissingParam{Param: []string{"items"}},
+		},
+		{
+			Desc:        "Failure: quantity not positive",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"items"}},
+		},
+		{
+			Desc:        "Failure: unknown variant",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "9", Quantity: 1}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"items"}},
+			Calls:       []*gomock.Call{mockVariantStore.EXPECT().Lookup(ctx, "9").Return(nil, sql.ErrNoRows)},
+		},
+		{
+			Desc:        "Failure: discontinued variant",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 1}}},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE", Reason: "1-s is discontinued and cannot be sold"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").
+					Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "discontinued", PriceCents: 300}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockCategoryStore.EXPECT().GetLineage(ctx, "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: archived product",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 1}}},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE", Reason: "1 is archived and cannot be sold"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "active", PriceCents: 300}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "archived"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variant without a price",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 1}}},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_PRICED", Reason: "variant 1-s has no price"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "active"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockCategoryStore.EXPECT().GetLineage(ctx, "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft product without a preview token",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 1}}},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "draft", PriceCents: 300}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: too many units",
+			Request:     &models.EvaluationRequest{Items: []models.EvaluationItem{{VariantID: "1-s", Quantity: 1001}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"items"}},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().Lookup(ctx, "1-s").Return(&models.Variant{ID: "1-s", ProductID: "1", Status: "active", PriceCents: 300}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockCategoryStore.EXPECT().GetLineage(ctx, "1").Return(nil, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Evaluate(ctx, test.Request)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
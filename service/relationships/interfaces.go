@@ -0,0 +1,12 @@
+package relationships
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type RelationshipService interface {
+	Get(ctx *krogo.Context, productID, relType string) ([]models.Relationship, error)
+	Set(ctx *krogo.Context, relationship *models.Relationship) (*models.Relationship, error)
+	Delete(ctx *krogo.Context, productID, relType, relatedID string) error
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package relationships is a generated GoMock package.
+package relationships
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockRelationshipService is a mock of RelationshipService interface.
+type MockRelationshipService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationshipServiceMockRecorder
+}
+
+// MockRelationshipServiceMockRecorder is the mock recorder for MockRelationshipService.
+type MockRelationshipServiceMockRecorder struct {
+	mock *MockRelationshipService
+}
+
+// NewMockRelationshipService creates a new mock instance.
+func NewMockRelationshipService(ctrl *gomock.Controller) *MockRelationshipService {
+	mock := &MockRelationshipService{ctrl: ctrl}
+	mock.recorder = &MockRelationshipServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelationshipService) EXPECT() *MockRelationshipServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRelationshipService) Delete(ctx *krogo.Context, productID, relType, relatedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, relType, relatedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelationshipServiceMockRecorder) Delete(ctx, productID, relType, relatedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelationshipService)(nil).Delete), ctx, productID, relType, relatedID)
+}
+
+// Get mocks base method.
+func (m *MockRelationshipService) Get(ctx *krogo.Context, productID, relType string) ([]models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID, relType)
+	ret0, _ := ret[0].([]models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRelationshipServiceMockRecorder) Get(ctx, productID, relType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRelationshipService)(nil).Get), ctx, productID, relType)
+}
+
+// Set mocks base method.
+func (m *MockRelationshipService) Set(ctx *krogo.Context, relationship *models.Relationship) (*models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, relationship)
+	ret0, _ := ret[0].(*models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRelationshipServiceMockRecorder) Set(ctx, relationship interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRelationshipService)(nil).Set), ctx, relationship)
+}
@@ -0,0 +1,128 @@
+package relationships
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/relationships"
+)
+
+var types = map[string]bool{
+	models.RelationshipRelated:    true,
+	models.RelationshipAccessory:  true,
+	models.RelationshipReplacedBy: true,
+	models.RelationshipUpSell:     true,
+}
+
+type Service struct {
+	store        relationships.RelationshipStore
+	productStore products.ProductStore
+}
+
+func New(store relationships.RelationshipStore, productStore products.ProductStore) *Service {
+	return &Service{store: store, productStore: productStore}
+}
+
+// Get lists the links of a product, optionally only those of one type.
+func (s *Service) Get(ctx *krogo.Context, productID, relType string) ([]models.Relationship, error) {
+	if relType != "" && !types[relType] {
+		return nil, errors.InvalidParam{Param: []string{"type"}}
+	}
+
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID, relType)
+}
+
+// Set links a product to another one. A product cannot be linked to itself, and replaced_by links may not
+// form a cycle, since following a product's replacements has to end somewhere.
+func (s *Service) Set(ctx *krogo.Context, relationship *models.Relationship) (*models.Relationship, error) {
+	if err := validate(relationship); err != nil {
+		return nil, err
+	}
+
+	if _, err := access.Editable(ctx, s.productStore, relationship.ProductID); err != nil {
+		return nil, err
+	}
+
+	related, err := s.productStore.GetByID(ctx, relationship.RelatedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.InvalidParam{Param: []string{"related_id"}}
+		}
+
+		return nil, err
+	}
+
+	res, err := s.store.Set(ctx, relationship)
+	if err == relationships.ErrCycle {
+		return nil, &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "REPLACEMENT_CYCLE",
+			Reason: "product " + relationship.RelatedID + " is already replaced by product " + relationship.ProductID +
+				", directly or through other replacements",
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.RelatedName = related.Name
+
+	return res, nil
+}
+
+func (s *Service) Delete(ctx *krogo.Context, productID, relType, relatedID string) error {
+	if !types[relType] {
+		return errors.InvalidParam{Param: []string{"type"}}
+	}
+
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return err
+	}
+
+	deleted, err := s.store.Delete(ctx, productID, relType, relatedID)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return errors.EntityNotFound{ID: relatedID, Entity: "relationships"}
+	}
+
+	return nil
+}
+
+func validate(r *models.Relationship) error {
+	if r.RelatedID == "" || r.Type == "" {
+		var missing []string
+
+		if r.RelatedID == "" {
+			missing = append(missing, "related_id")
+		}
+
+		if r.Type == "" {
+			missing = append(missing, "type")
+		}
+
+		return errors.MissingParam{Param: missing}
+	}
+
+	switch {
+	case !types[r.Type]:
+		return errors.InvalidParam{Param: []string{"type"}}
+	case r.RelatedID == r.ProductID:
+		return errors.InvalidParam{Param: []string{"related_id"}}
+	case r.Position < 0:
+		return errors.InvalidParam{Param: []string{"position"}}
+	}
+
+	return nil
+}
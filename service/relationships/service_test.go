@@ -0,0 +1,200 @@
+package relationships
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/relationships"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := relationships.NewMockRelationshipStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	links := []models.Relationship{{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory"}}
+
+	testcases := []struct {
+		Desc           string
+		Type           string
+		ExpectedResult []models.Relationship
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Type:           "accessory",
+			ExpectedResult: links,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1", "accessory").Return(links, nil),
+			},
+		},
+		{
+			Desc:        "Failure: unknown type",
+			Type:        "cross_sell",
+			ExpectedErr: errors.InvalidParam{Param: []string{"type"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Get(ctx, "1", test.Type)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := relationships.NewMockRelationshipStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Relationship
+		ExpectedResult *models.Relationship
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Relationship{ProductID: "1", RelatedID: "2", Type: "accessory", Position: 2},
+			ExpectedResult: &models.Relationship{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory", Position: 2},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "2").Return(&models.ProductWithVariants{ID: "2", Name: "lid"}, nil),
+				mockStore.EXPECT().Set(ctx, &models.Relationship{ProductID: "1", RelatedID: "2", Type: "accessory", Position: 2}).
+					Return(&models.Relationship{ProductID: "1", RelatedID: "2", Type: "accessory", Position: 2}, nil),
+			},
+		},
+		{
+			Desc:           "Success: replacement",
+			Body:           &models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"},
+			ExpectedResult: &models.Relationship{ProductID: "1", RelatedID: "3", RelatedName: "kettle v2", Type: "replaced_by"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "3").Return(&models.ProductWithVariants{ID: "3", Name: "kettle v2"}, nil),
+				mockStore.EXPECT().Set(ctx, &models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"}).
+					Return(&models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: replacement cycle",
+			Body: &models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"},
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "REPLACEMENT_CYCLE",
+				Reason:     "product 3 is already replaced by product 1, directly or through other replacements",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "3").Return(&models.ProductWithVariants{ID: "3"}, nil),
+				mockStore.EXPECT().Set(ctx, &models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"}).
+					Return(nil, relationships.ErrCycle),
+			},
+		},
+		{
+			Desc:        "Failure: self link",
+			Body:        &models.Relationship{ProductID: "1", RelatedID: "1", Type: "related"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"related_id"}},
+		},
+		{
+			Desc:        "Failure: unknown type",
+			Body:        &models.Relationship{ProductID: "1", RelatedID: "2", Type: "cross_sell"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"type"}},
+		},
+		{
+			Desc:        "Failure: negative position",
+			Body:        &models.Relationship{ProductID: "1", RelatedID: "2", Type: "up_sell", Position: -1},
+			ExpectedErr: errors.InvalidParam{Param: []string{"position"}},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.Relationship{ProductID: "1"},
+			ExpectedErr: errors.MissingParam{Param: []string{"related_id", "type"}},
+		},
+		{
+			Desc:        "Failure: related product not found",
+			Body:        &models.Relationship{ProductID: "1", RelatedID: "9", Type: "related"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"related_id"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "9").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        &models.Relationship{ProductID: "1", RelatedID: "2", Type: "related"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Set(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := relationships.NewMockRelationshipStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := userContext("u2")
+
+	gomock.InOrder(
+		mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+		mockStore.EXPECT().Delete(ctx, "1", "accessory", "2").Return(true, nil),
+		mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+		mockStore.EXPECT().Delete(ctx, "1", "accessory", "3").Return(false, nil),
+		mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+	)
+
+	assert.NoError(t, mockService.Delete(ctx, "1", "accessory", "2"))
+	assert.Equal(t, errors.EntityNotFound{ID: "3", Entity: "relationships"}, mockService.Delete(ctx, "1", "accessory", "3"))
+	assert.Equal(t, &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"}, mockService.Delete(ctx, "1", "accessory", "2"))
+	assert.Equal(t, errors.InvalidParam{Param: []string{"type"}}, mockService.Delete(ctx, "1", "cross_sell", "2"))
+}
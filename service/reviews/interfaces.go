@@ -0,0 +1,13 @@
+package reviews
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type ReviewService interface {
+	GetByProductID(ctx *krogo.Context, productID, status string) ([]models.Review, error)
+	Create(ctx *krogo.Context, review *models.Review) (*models.Review, error)
+	Moderate(ctx *krogo.Context, productID string, id int, status string) (*models.Review, error)
+	Delete(ctx *krogo.Context, productID string, id int) error
+}
@@ -0,0 +1,157 @@
+package reviews
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/reviews"
+	"practice-app/store/variants"
+	"strconv"
+	"strings"
+)
+
+var statuses = map[string]bool{
+	models.ReviewPending:  true,
+	models.ReviewApproved: true,
+	models.ReviewRejected: true,
+}
+
+type Service struct {
+	store        reviews.ReviewStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+	moderators   map[string]bool
+}
+
+// New takes the IDs of the users allowed to moderate reviews; anyone else can only write reviews and delete their own.
+func New(store reviews.ReviewStore, productStore products.ProductStore, variantStore variants.VariantStore,
+	moderators []string) *Service {
+	s := &Service{store: store, productStore: productStore, variantStore: variantStore,
+		moderators: make(map[string]bool, len(moderators))}
+
+	for _, m := range moderators {
+		if m = strings.TrimSpace(m); m != "" {
+			s.moderators[m] = true
+		}
+	}
+
+	return s
+}
+
+// GetByProductID lists the reviews of a product in a moderation state, the approved ones by default.
+func (s *Service) GetByProductID(ctx *krogo.Context, productID, status string) ([]models.Review, error) {
+	if status == "" {
+		status = models.ReviewApproved
+	}
+
+	if !statuses[status] {
+		return nil, errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID, status)
+}
+
+// Create submits a review by the user making the request. It is held for moderation and does not count towards the
+// product's rating until it is approved.
+func (s *Service) Create(ctx *krogo.Context, review *models.Review) (*models.Review, error) {
+	review.Title = strings.TrimSpace(review.Title)
+	review.Body = strings.TrimSpace(review.Body)
+
+	var missing []string
+
+	if review.Title == "" {
+		missing = append(missing, "title")
+	}
+
+	if review.Body == "" {
+		missing = append(missing, "body")
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.MissingParam{Param: missing}
+	}
+
+	if review.Rating < 1 || review.Rating > 5 {
+		return nil, errors.InvalidParam{Param: []string{"rating"}}
+	}
+
+	if _, err := access.Product(ctx, s.productStore, review.ProductID); err != nil {
+		return nil, err
+	}
+
+	if review.VariantID != "" {
+		if _, err := s.variantStore.GetByID(ctx, review.VariantID, review.ProductID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.InvalidParam{Param: []string{"variant_id"}}
+			}
+
+			return nil, err
+		}
+	}
+
+	review.Status = models.ReviewPending
+	review.Author = ctx.Header(models.UserHeader)
+
+	return s.store.Create(ctx, review)
+}
+
+// Moderate approves or rejects a review, or puts it back in the queue. Only moderators can.
+func (s *Service) Moderate(ctx *krogo.Context, productID string, id int, status string) (*models.Review, error) {
+	if !statuses[status] {
+		return nil, errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	if !s.moderators[ctx.Header(models.UserHeader)] {
+		return nil, forbidden("NOT_MODERATOR", "only moderators can approve or reject reviews")
+	}
+
+	if err := s.store.SetStatus(ctx, productID, id, status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "reviews"}
+		}
+
+		return nil, err
+	}
+
+	return s.store.GetByID(ctx, productID, id)
+}
+
+// Delete removes a review. Its author and moderators can.
+func (s *Service) Delete(ctx *krogo.Context, productID string, id int) error {
+	if user := ctx.Header(models.UserHeader); !s.moderators[user] {
+		r, err := s.store.GetByID(ctx, productID, id)
+		if err == sql.ErrNoRows {
+			return errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "reviews"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if r.Author != user {
+			return forbidden("NOT_AUTHOR", "only the author of a review and moderators can delete it")
+		}
+	}
+
+	if err := s.store.Delete(ctx, productID, id); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "reviews"}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func forbidden(code, reason string) error {
+	return &errors.Response{StatusCode: http.StatusForbidden, Code: code, Reason: reason}
+}
@@ -0,0 +1,291 @@
+package reviews
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/reviews"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := reviews.NewMockReviewStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, variants.NewMockVariantStore(ctrl), []string{"m1"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	list := []models.Review{{ID: 1, ProductID: "1", Rating: 5, Title: "Great", Body: "Loved it", Status: "approved"}}
+
+	testcases := []struct {
+		Desc           string
+		Status         string
+		ExpectedResult []models.Review
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: approved by default",
+			ExpectedResult: list,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1", "approved").Return(list, nil),
+			},
+		},
+		{
+			Desc:   "Success: moderation queue",
+			Status: "pending",
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1", "pending").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: unknown status",
+			Status:      "spam",
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByProductID(ctx, "1", test.Status)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := reviews.NewMockReviewStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore, []string{"m1"})
+
+	ctx := userContext("u1")
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Review
+		ExpectedResult *models.Review
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Body: &models.Review{ProductID: "1", VariantID: "1-s", Rating: 4, Title: " Good ", Body: "Nice", Status: "approved"},
+			ExpectedResult: &models.Review{ID: 3, ProductID: "1", VariantID: "1-s", Rating: 4, Title: "Good", Body: "Nice", Status: "pending",
+				Author: "u1"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-s", "1").Return(&models.Variant{ID: "1-s"}, nil),
+				mockStore.EXPECT().Create(ctx, &models.Review{ProductID: "1", VariantID: "1-s", Rating: 4, Title: "Good", Body: "Nice",
+					Status: "pending", Author: "u1"}).
+					Return(&models.Review{ID: 3, ProductID: "1", VariantID: "1-s", Rating: 4, Title: "Good", Body: "Nice", Status: "pending",
+						Author: "u1"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing title and body",
+			Body:        &models.Review{ProductID: "1", Rating: 4, Title: "  "},
+			ExpectedErr: errors.MissingParam{Param: []string{"title", "body"}},
+		},
+		{
+			Desc:        "Failure: rating out of range",
+			Body:        &models.Review{ProductID: "1", Rating: 6, Title: "Good", Body: "Nice"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rating"}},
+		},
+		{
+			Desc:        "Failure: unknown variant",
+			Body:        &models.Review{ProductID: "1", VariantID: "1-x", Rating: 4, Title: "Good", Body: "Nice"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"variant_id"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-x", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Body:        &models.Review{ProductID: "1", Rating: 4, Title: "Good", Body: "Nice"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			Body:        &models.Review{ProductID: "1", Rating: 4, Title: "Good", Body: "Nice"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := reviews.NewMockReviewStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl), []string{"m1"})
+
+	moderator := userContext("m1")
+
+	approved := &models.Review{ID: 3, ProductID: "1", Rating: 4, Title: "Good", Body: "Nice", Status: "approved", Author: "u1"}
+
+	testcases := []struct {
+		Desc           string
+		Ctx            *krogo.Context
+		Status         string
+		ExpectedResult *models.Review
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			Ctx:            moderator,
+			Status:         "approved",
+			ExpectedResult: approved,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().SetStatus(moderator, "1", 3, "approved").Return(nil),
+				mockStore.EXPECT().GetByID(moderator, "1", 3).Return(approved, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Ctx:         moderator,
+			Status:      "rejected",
+			ExpectedErr: errors.EntityNotFound{ID: "3", Entity: "reviews"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().SetStatus(moderator, "1", 3, "rejected").Return(sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: unknown status",
+			Ctx:         moderator,
+			Status:      "hidden",
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+		},
+		{
+			Desc:   "Failure: author approving their own review",
+			Ctx:    userContext("u1"),
+			Status: "approved",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_MODERATOR",
+				Reason: "only moderators can approve or reject reviews"},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Moderate(test.Ctx, "1", 3, test.Status)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := reviews.NewMockReviewStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl), []string{"m1"})
+
+	author, other, moderator := userContext("u1"), userContext("u2"), userContext("m1")
+
+	review := &models.Review{ID: 3, ProductID: "1", Rating: 4, Status: "approved", Author: "u1"}
+
+	testcases := []struct {
+		Desc        string
+		Ctx         *krogo.Context
+		ID          int
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success: author",
+			Ctx:  author,
+			ID:   3,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(author, "1", 3).Return(review, nil),
+				mockStore.EXPECT().Delete(author, "1", 3).Return(nil),
+			},
+		},
+		{
+			Desc: "Success: moderator",
+			Ctx:  moderator,
+			ID:   3,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().Delete(moderator, "1", 3).Return(nil),
+			},
+		},
+		{
+			Desc: "Failure: review of another user",
+			Ctx:  other,
+			ID:   3,
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_AUTHOR",
+				Reason: "only the author of a review and moderators can delete it"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(other, "1", 3).Return(review, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Ctx:         author,
+			ID:          4,
+			ExpectedErr: errors.EntityNotFound{ID: "4", Entity: "reviews"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(author, "1", 4).Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: deleted meanwhile",
+			Ctx:         moderator,
+			ID:          4,
+			ExpectedErr: errors.EntityNotFound{ID: "4", Entity: "reviews"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().Delete(moderator, "1", 4).Return(sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(test.Ctx, "1", test.ID)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
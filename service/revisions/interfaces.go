@@ -0,0 +1,12 @@
+package revisions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type RevisionService interface {
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error)
+	Diff(ctx *krogo.Context, productID, from, to string) (*models.RevisionDiff, error)
+	Revert(ctx *krogo.Context, productID, id, actor string) (*models.Revision, error)
+}
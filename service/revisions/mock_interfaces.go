@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package revisions is a generated GoMock package.
+package revisions
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockRevisionService is a mock of RevisionService interface.
+type MockRevisionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionServiceMockRecorder
+}
+
+// MockRevisionServiceMockRecorder is the mock recorder for MockRevisionService.
+type MockRevisionServiceMockRecorder struct {
+	mock *MockRevisionService
+}
+
+// NewMockRevisionService creates a new mock instance.
+func NewMockRevisionService(ctrl *gomock.Controller) *MockRevisionService {
+	mock := &MockRevisionService{ctrl: ctrl}
+	mock.recorder = &MockRevisionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionService) EXPECT() *MockRevisionServiceMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockRevisionService) Diff(ctx *krogo.Context, productID, from, to string) (*models.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, productID, from, to)
+	ret0, _ := ret[0].(*models.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockRevisionServiceMockRecorder) Diff(ctx, productID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockRevisionService)(nil).Diff), ctx, productID, from, to)
+}
+
+// GetByProductID mocks base method.
+func (m *MockRevisionService) GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockRevisionServiceMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockRevisionService)(nil).GetByProductID), ctx, productID)
+}
+
+// Revert mocks base method.
+func (m *MockRevisionService) Revert(ctx *krogo.Context, productID, id, actor string) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, productID, id, actor)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockRevisionServiceMockRecorder) Revert(ctx, productID, id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockRevisionService)(nil).Revert), ctx, productID, id, actor)
+}
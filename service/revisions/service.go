@@ -0,0 +1,114 @@
+package revisions
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/revisions"
+	"reflect"
+	"sort"
+)
+
+type Service struct {
+	store        revisions.RevisionStore
+	productStore products.ProductStore
+}
+
+func New(store revisions.RevisionStore, productStore products.ProductStore) *Service {
+	return &Service{store: store, productStore: productStore}
+}
+
+// GetByProductID lists the revisions of a product and its variants, oldest first.
+func (s *Service) GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error) {
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByProductID(ctx, productID)
+}
+
+// Diff lists the fields that changed from one revision to another of the same product or variant.
+func (s *Service) Diff(ctx *krogo.Context, productID, from, to string) (*models.RevisionDiff, error) {
+	if _, err := access.Product(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	a, err := s.revision(ctx, productID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.revision(ctx, productID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.VariantID != b.VariantID {
+		return nil, errors.InvalidParam{Param: []string{"to"}}
+	}
+
+	return &models.RevisionDiff{From: a.ID, To: b.ID, Changes: diff(a.Snapshot, b.Snapshot)}, nil
+}
+
+// Revert restores a product or one of its variants as it was in a revision. Reverting is itself a change, recorded as
+// a new revision, and follows the same rules as any other: drafts only by their owner and nothing while in review.
+func (s *Service) Revert(ctx *krogo.Context, productID, id, actor string) (*models.Revision, error) {
+	r, err := s.revision(ctx, productID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = access.Editable(ctx, s.productStore, productID); err != nil {
+		return nil, err
+	}
+
+	res, err := s.store.Revert(ctx, r, actor)
+	if err == sql.ErrNoRows {
+		return nil, errors.EntityNotFound{ID: r.VariantID, Entity: "variants"}
+	}
+
+	return res, err
+}
+
+func (s *Service) revision(ctx *krogo.Context, productID, id string) (*models.Revision, error) {
+	r, err := s.store.GetByID(ctx, productID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: id, Entity: "revisions"}
+		}
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// diff compares two snapshots field by field, in the order of the field names.
+func diff(from, to map[string]interface{}) []models.FieldChange {
+	fields := make([]string, 0, len(from)+len(to))
+
+	for field := range from {
+		fields = append(fields, field)
+	}
+
+	for field := range to {
+		if _, ok := from[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	changes := make([]models.FieldChange, 0)
+
+	for _, field := range fields {
+		if !reflect.DeepEqual(from[field], to[field]) {
+			changes = append(changes, models.FieldChange{Field: field, From: from[field], To: to[field]})
+		}
+	}
+
+	return changes
+}
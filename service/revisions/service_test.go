@@ -0,0 +1,233 @@
+package revisions
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/revisions"
+	"testing"
+	"time"
+)
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_GetByProductID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := revisions.NewMockRevisionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	history := []models.Revision{{ID: 1, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}, CreatedAt: at}}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Revision
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: history,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return(history, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByProductID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := revisions.NewMockRevisionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	before := &models.Revision{ID: 1, ProductID: "1", Snapshot: map[string]interface{}{
+		"name": "Milk", "details": "whole", "attributes": map[string]interface{}{"fat": "3.5%"}}}
+	after := &models.Revision{ID: 4, ProductID: "1", Snapshot: map[string]interface{}{
+		"name": "Milk", "details": "skimmed", "attributes": map[string]interface{}{"fat": "0.1%"}, "publish_at": "2023-05-01T00:00:00Z"}}
+	variant := &models.Revision{ID: 5, ProductID: "1", VariantID: "2", Snapshot: map[string]interface{}{"variant_name": "1 l"}}
+
+	testcases := []struct {
+		Desc           string
+		To             string
+		ExpectedResult *models.RevisionDiff
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			To:   "4",
+			ExpectedResult: &models.RevisionDiff{From: 1, To: 4, Changes: []models.FieldChange{
+				{Field: "attributes", From: map[string]interface{}{"fat": "3.5%"}, To: map[string]interface{}{"fat": "0.1%"}},
+				{Field: "details", From: "whole", To: "skimmed"},
+				{Field: "publish_at", To: "2023-05-01T00:00:00Z"},
+			}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(before, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "4").Return(after, nil),
+			},
+		},
+		{
+			Desc:        "Failure: revisions of different variants",
+			To:          "5",
+			ExpectedErr: errors.InvalidParam{Param: []string{"to"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(before, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "5").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: revision not found",
+			To:          "9",
+			ExpectedErr: errors.EntityNotFound{ID: "9", Entity: "revisions"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(before, nil),
+				mockStore.EXPECT().GetByID(ctx, "1", "9").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			To:          "4",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Diff(ctx, "1", "1", test.To)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := revisions.NewMockRevisionStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore)
+
+	ctx := userContext("u1")
+
+	old := &models.Revision{ID: 1, ProductID: "1", Snapshot: map[string]interface{}{"name": "Milk"}}
+	variant := &models.Revision{ID: 2, ProductID: "1", VariantID: "2", Snapshot: map[string]interface{}{"variant_name": "1 l"}}
+	reverted := &models.Revision{ID: 7, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}, CreatedAt: at}
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult *models.Revision
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			ExpectedResult: reverted,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(old, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockStore.EXPECT().Revert(ctx, old, "u1").Return(reverted, nil),
+			},
+		},
+		{
+			Desc:           "Success: draft of the user",
+			ID:             "1",
+			ExpectedResult: reverted,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(old, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+				mockStore.EXPECT().Revert(ctx, old, "u1").Return(reverted, nil),
+			},
+		},
+		{
+			Desc: "Failure: in review",
+			ID:   "1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "IN_REVIEW",
+				Reason: "the product is in review and cannot change until it is approved or rejected"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(old, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "in_review"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			ID:          "1",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "1").Return(old, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u2"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variant no longer exists",
+			ID:          "2",
+			ExpectedErr: errors.EntityNotFound{ID: "2", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "2").Return(variant, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "active"}, nil),
+				mockStore.EXPECT().Revert(ctx, variant, "u1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: revision not found",
+			ID:          "9",
+			ExpectedErr: errors.EntityNotFound{ID: "9", Entity: "revisions"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, "1", "9").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Revert(ctx, "1", test.ID, "u1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,12 @@
+package tags
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type TagService interface {
+	GetAll(ctx *krogo.Context) ([]models.Tag, error)
+	Add(ctx *krogo.Context, productID, variantID string, tags []string) (*models.Tags, error)
+	Remove(ctx *krogo.Context, productID, variantID, tag string) error
+}
@@ -0,0 +1,105 @@
+package tags
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/tags"
+	"practice-app/store/variants"
+	"practice-app/vocabulary"
+)
+
+type Service struct {
+	store        tags.TagStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store tags.TagStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+func (s *Service) GetAll(ctx *krogo.Context) ([]models.Tag, error) {
+	return s.store.GetAll(ctx)
+}
+
+// Add tags a product, or one of its variants when variantID is set, and returns all of its tags. Tags are
+// normalized first, so "Gluten Free" and "gluten-free" end up as the same tag.
+func (s *Service) Add(ctx *krogo.Context, productID, variantID string, list []string) (*models.Tags, error) {
+	if len(list) == 0 {
+		return nil, errors.MissingParam{Param: []string{"tags"}}
+	}
+
+	seen := make(map[string]bool, len(list))
+	normalized := make([]string, 0, len(list))
+
+	for _, tag := range list {
+		tag = vocabulary.Normalize(tag)
+
+		if tag == "" || len(tag) > vocabulary.MaxLength {
+			return nil, errors.InvalidParam{Param: []string{"tags"}}
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if err := s.checkTarget(ctx, productID, variantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Add(ctx, productID, variantID, normalized); err != nil {
+		return nil, err
+	}
+
+	res, err := s.store.Get(ctx, productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Tags{Tags: res}, nil
+}
+
+// Remove takes a tag off a product or variant.
+func (s *Service) Remove(ctx *krogo.Context, productID, variantID, tag string) error {
+	normalized := vocabulary.Normalize(tag)
+
+	if normalized == "" {
+		return errors.InvalidParam{Param: []string{"tag"}}
+	}
+
+	if err := s.checkTarget(ctx, productID, variantID); err != nil {
+		return err
+	}
+
+	removed, err := s.store.Remove(ctx, productID, variantID, normalized)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return errors.EntityNotFound{ID: normalized, Entity: "tags"}
+	}
+
+	return nil
+}
+
+// checkTarget makes sure the product, and the variant if one is given, exist and that the user making the request
+// may change the product.
+func (s *Service) checkTarget(ctx *krogo.Context, productID, variantID string) error {
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return err
+	}
+
+	if variantID == "" {
+		return nil
+	}
+
+	_, err := access.Variant(ctx, s.variantStore, variantID, productID)
+
+	return err
+}
@@ -0,0 +1,179 @@
+package tags
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/tags"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tags.NewMockTagStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	vocabulary := []models.Tag{{Name: "organic", Products: 2, Variants: 1}}
+
+	mockStore.EXPECT().GetAll(ctx).Return(vocabulary, nil)
+
+	res, err := mockService.GetAll(ctx)
+
+	assert.Equal(t, vocabulary, res)
+	assert.NoError(t, err)
+}
+
+func TestService_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tags.NewMockTagStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc           string
+		VariantID      string
+		Tags           []string
+		ExpectedResult *models.Tags
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: product",
+			Tags:           []string{"Organic", "gluten free", "organic"},
+			ExpectedResult: &models.Tags{Tags: []string{"gluten-free", "new", "organic"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().Add(ctx, "1", "", []string{"organic", "gluten-free"}).Return(nil),
+				mockStore.EXPECT().Get(ctx, "1", "").Return([]string{"gluten-free", "new", "organic"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: variant",
+			VariantID:      "1-s",
+			Tags:           []string{"new"},
+			ExpectedResult: &models.Tags{Tags: []string{"new"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-s", "1").Return(&models.Variant{ID: "1-s"}, nil),
+				mockStore.EXPECT().Add(ctx, "1", "1-s", []string{"new"}).Return(nil),
+				mockStore.EXPECT().Get(ctx, "1", "1-s").Return([]string{"new"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: no tags",
+			ExpectedErr: errors.MissingParam{Param: []string{"tags"}},
+		},
+		{
+			Desc:        "Failure: tag without letters or digits",
+			Tags:        []string{"organic", "!!"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"tags"}},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			VariantID:   "1-x",
+			Tags:        []string{"new"},
+			ExpectedErr: errors.EntityNotFound{ID: "1-x", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-x", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Tags:        []string{"new"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Tags:        []string{"new"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Add(ctx, "1", test.VariantID, test.Tags)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tags.NewMockTagStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, variants.NewMockVariantStore(ctrl))
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc        string
+		Tag         string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Tag:  "Gluten Free",
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().Remove(ctx, "1", "", "gluten-free").Return(true, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not tagged",
+			Tag:         "vegan",
+			ExpectedErr: errors.EntityNotFound{ID: "vegan", Entity: "tags"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().Remove(ctx, "1", "", "vegan").Return(false, nil),
+			},
+		},
+		{
+			Desc:        "Failure: invalid tag",
+			Tag:         "--",
+			ExpectedErr: errors.InvalidParam{Param: []string{"tag"}},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Tag:         "vegan",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Remove(ctx, "1", "", test.Tag)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
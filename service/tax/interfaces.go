@@ -0,0 +1,14 @@
+package tax
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type TaxService interface {
+	GetAll(ctx *krogo.Context, region string) ([]models.TaxRate, error)
+	Create(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	Update(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	Delete(ctx *krogo.Context, id int) error
+	Quote(ctx *krogo.Context, request *models.TaxQuoteRequest) (*models.TaxQuote, error)
+}
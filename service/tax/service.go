@@ -0,0 +1,269 @@
+package tax
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"math"
+	"net/http"
+	"practice-app/models"
+	"practice-app/pricing"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/tax"
+	"practice-app/store/variants"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// regionPattern matches ISO 3166 country codes, optionally followed by a subdivision, such as US or US-OH.
+var regionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+type Service struct {
+	store        tax.TaxStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+}
+
+func New(store tax.TaxStore, productStore products.ProductStore, variantStore variants.VariantStore) *Service {
+	return &Service{store: store, productStore: productStore, variantStore: variantStore}
+}
+
+func (s *Service) GetAll(ctx *krogo.Context, region string) ([]models.TaxRate, error) {
+	return s.store.GetAll(ctx, strings.ToUpper(strings.TrimSpace(region)))
+}
+
+func (s *Service) Create(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	if err := s.check(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	return s.store.Create(ctx, rate)
+}
+
+func (s *Service) Update(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	if _, err := s.getByID(ctx, rate.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.check(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	return s.store.Update(ctx, rate)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, id int) error {
+	if _, err := s.getByID(ctx, id); err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// Quote computes the tax of a sale in a region at the requested time, or now. Each line is taxed at the rate of
+// its product's tax class and rounded on its own; the tax of the sale is the sum of its lines. A line of a taxed
+// class without a rate in effect in the region fails the quote rather than going untaxed.
+func (s *Service) Quote(ctx *krogo.Context, request *models.TaxQuoteRequest) (*models.TaxQuote, error) {
+	region := strings.ToUpper(strings.TrimSpace(request.Region))
+
+	if region == "" {
+		return nil, errors.MissingParam{Param: []string{"region"}}
+	}
+
+	if !regionPattern.MatchString(region) {
+		return nil, errors.InvalidParam{Param: []string{"region"}}
+	}
+
+	if len(request.Items) == 0 {
+		return nil, errors.MissingParam{Param: []string{"items"}}
+	}
+
+	effective, err := s.store.GetEffective(ctx, region, request.At)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(effective) == 0 {
+		return nil, errors.EntityNotFound{ID: region, Entity: "tax_rates"}
+	}
+
+	rates := make(map[string]float64, len(effective))
+
+	for i := range effective {
+		rates[effective[i].TaxClass] = effective[i].Rate
+	}
+
+	var (
+		quote   = &models.TaxQuote{Region: region, Lines: make([]models.TaxLine, 0, len(request.Items))}
+		classes = make(map[string]string)
+	)
+
+	for _, item := range request.Items {
+		if item.VariantID == "" || item.Quantity < 1 || (item.UnitPriceCents != nil && *item.UnitPriceCents < 0) {
+			return nil, errors.InvalidParam{Param: []string{"items"}}
+		}
+
+		l, err := s.line(ctx, item, classes)
+		if err != nil {
+			return nil, err
+		}
+
+		if l.TaxClass != models.TaxExempt {
+			rate, ok := rates[l.TaxClass]
+			if !ok {
+				return nil, errors.EntityNotFound{ID: region + "/" + l.TaxClass, Entity: "tax_rates"}
+			}
+
+			l.Rate = rate
+		}
+
+		l.TaxCents = pricing.Tax(l.AmountCents, l.Rate)
+
+		quote.Lines = append(quote.Lines, *l)
+		quote.SubtotalCents += l.AmountCents
+		quote.TaxCents += l.TaxCents
+	}
+
+	quote.TotalCents = quote.SubtotalCents + quote.TaxCents
+
+	return quote, nil
+}
+
+// line prices an item and looks up the tax class of its product, which is read once per product. Items that are not
+// for sale cannot be quoted.
+func (s *Service) line(ctx *krogo.Context, item models.TaxQuoteItem, classes map[string]string) (*models.TaxLine, error) {
+	v, err := s.variantStore.Lookup(ctx, item.VariantID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.InvalidParam{Param: []string{"items"}}
+		}
+
+		return nil, err
+	}
+
+	class, ok := classes[v.ProductID]
+	if !ok {
+		p, err := access.Product(ctx, s.productStore, v.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = access.ForSale(p.ID, p.Status); err != nil {
+			return nil, err
+		}
+
+		class = p.TaxClass
+		if class == "" {
+			class = models.TaxStandard
+		}
+
+		classes[v.ProductID] = class
+	}
+
+	if err = access.ForSale(v.ID, v.Status); err != nil {
+		return nil, err
+	}
+
+	l := &models.TaxLine{VariantID: v.ID, ProductID: v.ProductID, Quantity: item.Quantity, UnitPriceCents: v.PriceCents,
+		TaxClass: class}
+
+	if item.UnitPriceCents != nil {
+		l.UnitPriceCents = *item.UnitPriceCents
+	}
+
+	l.AmountCents = l.UnitPriceCents * int64(l.Quantity)
+
+	return l, nil
+}
+
+func (s *Service) getByID(ctx *krogo.Context, id int) (*models.TaxRate, error) {
+	r, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: strconv.Itoa(id), Entity: "tax_rates"}
+		}
+
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// check validates a rate and makes sure its period does not overlap another rate of the same class in its region,
+// so that exactly one rate applies at any time.
+func (s *Service) check(ctx *krogo.Context, rate *models.TaxRate) error {
+	rate.Region = strings.ToUpper(strings.TrimSpace(rate.Region))
+
+	var missing []string
+
+	if rate.Region == "" {
+		missing = append(missing, "region")
+	}
+
+	if rate.TaxClass == "" {
+		missing = append(missing, "tax_class")
+	}
+
+	if rate.EffectiveFrom.IsZero() {
+		missing = append(missing, "effective_from")
+	}
+
+	if len(missing) > 0 {
+		return errors.MissingParam{Param: missing}
+	}
+
+	if err := validate(rate); err != nil {
+		return err
+	}
+
+	existing, err := s.store.GetAll(ctx, rate.Region)
+	if err != nil {
+		return err
+	}
+
+	for i := range existing {
+		r := &existing[i]
+
+		if r.ID != rate.ID && r.TaxClass == rate.TaxClass && overlap(r, rate) {
+			return &errors.Response{StatusCode: http.StatusConflict, Code: "TAX_RATE_OVERLAP",
+				Reason: "rate " + strconv.Itoa(r.ID) + " of " + r.TaxClass + " in " + r.Region + " is in effect in the same period"}
+		}
+	}
+
+	return nil
+}
+
+// validate checks the region, the class and the rate, which is a percentage with at most four decimals, and that
+// the period of the rate ends after it starts. Exempt products are never taxed, so that class takes no rates.
+func validate(rate *models.TaxRate) error {
+	var invalid string
+
+	switch {
+	case !regionPattern.MatchString(rate.Region):
+		invalid = "region"
+	case rate.TaxClass != models.TaxStandard && rate.TaxClass != models.TaxFood:
+		invalid = "tax_class"
+	case rate.Rate < 0 || rate.Rate > 100 || math.Abs(rate.Rate*10000-math.Round(rate.Rate*10000)) > 1e-6:
+		invalid = "rate"
+	case rate.EffectiveTo != nil && !rate.EffectiveTo.After(rate.EffectiveFrom):
+		invalid = "effective_to"
+	}
+
+	if invalid != "" {
+		return errors.InvalidParam{Param: []string{invalid}}
+	}
+
+	return nil
+}
+
+// overlap reports whether the periods of two rates share an instant; a period without an end never ends.
+func overlap(a, b *models.TaxRate) bool {
+	return before(a.EffectiveFrom, b.EffectiveTo) && before(b.EffectiveFrom, a.EffectiveTo)
+}
+
+func before(t time.Time, end *time.Time) bool {
+	return end == nil || t.Before(*end)
+}
@@ -0,0 +1,329 @@
+package tax
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/tax"
+	"practice-app/store/variants"
+	"testing"
+	"time"
+)
+
+var (
+	jan = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	may = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	jun = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tax.NewMockTaxStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	rates := []models.TaxRate{{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: jan}}
+
+	mockStore.EXPECT().GetAll(ctx, "US-OH").Return(rates, nil)
+
+	res, err := mockService.GetAll(ctx, " us-oh")
+
+	assert.Equal(t, rates, res)
+	assert.NoError(t, err)
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tax.NewMockTaxStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	existing := []models.TaxRate{
+		{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.5, EffectiveFrom: jan, EffectiveTo: &may},
+		{ID: 2, Region: "US-OH", TaxClass: "food", Rate: 0, EffectiveFrom: jan},
+	}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.TaxRate
+		ExpectedResult *models.TaxRate
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: rate starting when the previous one ends",
+			Body:           &models.TaxRate{Region: "us-oh", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may},
+			ExpectedResult: &models.TaxRate{ID: 3, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetAll(ctx, "US-OH").Return(existing, nil),
+				mockStore.EXPECT().Create(ctx, &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}).
+					Return(&models.TaxRate{ID: 3, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: may}, nil),
+			},
+		},
+		{
+			Desc: "Failure: overlapping rate",
+			Body: &models.TaxRate{Region: "US-OH", TaxClass: "food", Rate: 1, EffectiveFrom: may, EffectiveTo: &jun},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "TAX_RATE_OVERLAP",
+				Reason: "rate 2 of food in US-OH is in effect in the same period"},
+			Calls: []*gomock.Call{mockStore.EXPECT().GetAll(ctx, "US-OH").Return(existing, nil)},
+		},
+		{
+			Desc:        "Failure: missing params",
+			Body:        &models.TaxRate{Rate: 5},
+			ExpectedErr: errors.MissingParam{Param: []string{"region", "tax_class", "effective_from"}},
+		},
+		{
+			Desc:        "Failure: invalid region",
+			Body:        &models.TaxRate{Region: "Ohio", TaxClass: "standard", Rate: 5, EffectiveFrom: may},
+			ExpectedErr: errors.InvalidParam{Param: []string{"region"}},
+		},
+		{
+			Desc:        "Failure: exempt class",
+			Body:        &models.TaxRate{Region: "US-OH", TaxClass: "exempt", Rate: 0, EffectiveFrom: may},
+			ExpectedErr: errors.InvalidParam{Param: []string{"tax_class"}},
+		},
+		{
+			Desc:        "Failure: too many decimals",
+			Body:        &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 5.12345, EffectiveFrom: may},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rate"}},
+		},
+		{
+			Desc:        "Failure: rate above 100",
+			Body:        &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 101, EffectiveFrom: may},
+			ExpectedErr: errors.InvalidParam{Param: []string{"rate"}},
+		},
+		{
+			Desc:        "Failure: ends before it starts",
+			Body:        &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 5, EffectiveFrom: may, EffectiveTo: &jan},
+			ExpectedErr: errors.InvalidParam{Param: []string{"effective_to"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tax.NewMockTaxStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	existing := []models.TaxRate{{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.5, EffectiveFrom: jan, EffectiveTo: &may}}
+	rate := &models.TaxRate{ID: 1, Region: "US-OH", TaxClass: "standard", Rate: 5.5, EffectiveFrom: jan, EffectiveTo: &jun}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.TaxRate
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: extending a rate does not overlap itself",
+			ExpectedResult: rate,
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, 1).Return(&existing[0], nil),
+				mockStore.EXPECT().GetAll(ctx, "US-OH").Return(existing, nil),
+				mockStore.EXPECT().Update(ctx, rate).Return(rate, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "tax_rates"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByID(ctx, 1).Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Update(ctx, rate)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tax.NewMockTaxStore(ctrl)
+	mockService := New(mockStore, products.NewMockProductStore(ctrl), variants.NewMockVariantStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetByID(ctx, 1).Return(&models.TaxRate{ID: 1}, nil),
+				mockStore.EXPECT().Delete(ctx, 1).Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "tax_rates"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetByID(ctx, 1).Return(nil, sql.ErrNoRows)},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, 1)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := tax.NewMockTaxStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore)
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	rates := []models.TaxRate{
+		{ID: 1, Region: "US-OH", TaxClass: "food", Rate: 2.25, EffectiveFrom: jan},
+		{ID: 2, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: jan},
+	}
+	discounted := int64(250)
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.TaxQuoteRequest
+		ExpectedResult *models.TaxQuote
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success",
+			Body: &models.TaxQuoteRequest{Region: "us-oh", At: &may, Items: []models.TaxQuoteItem{
+				{VariantID: "kettle-red", Quantity: 1},
+				{VariantID: "kettle-blue", Quantity: 3, UnitPriceCents: &discounted},
+				{VariantID: "bread", Quantity: 2},
+				{VariantID: "aspirin", Quantity: 1},
+			}},
+			ExpectedResult: &models.TaxQuote{
+				Region: "US-OH",
+				Lines: []models.TaxLine{
+					{VariantID: "kettle-red", ProductID: "kettle", Quantity: 1, UnitPriceCents: 1999, AmountCents: 1999,
+						TaxClass: "standard", Rate: 5.75, TaxCents: 115},
+					{VariantID: "kettle-blue", ProductID: "kettle", Quantity: 3, UnitPriceCents: 250, AmountCents: 750,
+						TaxClass: "standard", Rate: 5.75, TaxCents: 43},
+					{VariantID: "bread", ProductID: "bread", Quantity: 2, UnitPriceCents: 349, AmountCents: 698,
+						TaxClass: "food", Rate: 2.25, TaxCents: 16},
+					{VariantID: "aspirin", ProductID: "aspirin", Quantity: 1, UnitPriceCents: 899, AmountCents: 899,
+						TaxClass: "exempt", TaxCents: 0},
+				},
+				SubtotalCents: 4346,
+				TaxCents:      174,
+				TotalCents:    4520,
+			},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetEffective(ctx, "US-OH", &may).Return(rates, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "kettle-red").
+					Return(&models.Variant{ID: "kettle-red", ProductID: "kettle", Status: "active", PriceCents: 1999}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "kettle").Return(&models.ProductWithVariants{ID: "kettle", Status: "active"}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "kettle-blue").
+					Return(&models.Variant{ID: "kettle-blue", ProductID: "kettle", Status: "active", PriceCents: 1999}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "bread").
+					Return(&models.Variant{ID: "bread", ProductID: "bread", Status: "active", PriceCents: 349}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "bread").Return(&models.ProductWithVariants{ID: "bread", Status: "active", TaxClass: "food"}, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "aspirin").
+					Return(&models.Variant{ID: "aspirin", ProductID: "aspirin", Status: "active", PriceCents: 899}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "aspirin").
+					Return(&models.ProductWithVariants{ID: "aspirin", Status: "active", TaxClass: "exempt"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing region",
+			Body:        &models.TaxQuoteRequest{Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: errors.MissingParam{Param: []string{"region"}},
+		},
+		{
+			Desc:        "Failure: invalid region",
+			Body:        &models.TaxQuoteRequest{Region: "Ohio", Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"region"}},
+		},
+		{
+			Desc:        "Failure: missing items",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH"},
+			ExpectedErr: errors.MissingParam{Param: []string{"items"}},
+		},
+		{
+			Desc:        "Failure: region without rates",
+			Body:        &models.TaxQuoteRequest{Region: "US-TX", Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: errors.EntityNotFound{ID: "US-TX", Entity: "tax_rates"},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetEffective(ctx, "US-TX", nil).Return(nil, nil)},
+		},
+		{
+			Desc:        "Failure: class without a rate in the region",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH", Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: errors.EntityNotFound{ID: "US-OH/food", Entity: "tax_rates"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetEffective(ctx, "US-OH", nil).Return(rates[1:], nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "bread").
+					Return(&models.Variant{ID: "bread", ProductID: "bread", Status: "active", PriceCents: 349}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "bread").Return(&models.ProductWithVariants{ID: "bread", Status: "active", TaxClass: "food"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: discontinued product",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH", Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusConflict, Code: "NOT_FOR_SALE", Reason: "bread is discontinued and cannot be sold"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetEffective(ctx, "US-OH", nil).Return(rates, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "bread").
+					Return(&models.Variant{ID: "bread", ProductID: "bread", Status: "active", PriceCents: 349}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "bread").Return(&models.ProductWithVariants{ID: "bread", Status: "discontinued"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft product without a preview token",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH", Items: []models.TaxQuoteItem{{VariantID: "bread", Quantity: 1}}},
+			ExpectedErr: errors.EntityNotFound{ID: "bread", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetEffective(ctx, "US-OH", nil).Return(rates, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "bread").
+					Return(&models.Variant{ID: "bread", ProductID: "bread", Status: "draft", PriceCents: 349}, nil),
+				mockProductStore.EXPECT().GetByID(ctx, "bread").Return(&models.ProductWithVariants{ID: "bread", Status: "draft"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: invalid quantity",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH", Items: []models.TaxQuoteItem{{VariantID: "bread"}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"items"}},
+			Calls:       []*gomock.Call{mockStore.EXPECT().GetEffective(ctx, "US-OH", nil).Return(rates, nil)},
+		},
+		{
+			Desc:        "Failure: unknown variant",
+			Body:        &models.TaxQuoteRequest{Region: "US-OH", Items: []models.TaxQuoteItem{{VariantID: "nope", Quantity: 1}}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"items"}},
+			Calls: []*gomock.Call{
+				mockStore.EXPECT().GetEffective(ctx, "US-OH", nil).Return(rates, nil),
+				mockVariantStore.EXPECT().Lookup(ctx, "nope").Return(nil, sql.ErrNoRows),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Quote(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,12 @@
+package translations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type TranslationService interface {
+	Get(ctx *krogo.Context, productID string) (*models.ProductTranslations, error)
+	Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error)
+	Delete(ctx *krogo.Context, productID, variantID, locale string) error
+}
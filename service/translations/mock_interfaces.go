@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package translations is a generated GoMock package.
+package translations
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockTranslationService is a mock of TranslationService interface.
+type MockTranslationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationServiceMockRecorder
+}
+
+// MockTranslationServiceMockRecorder is the mock recorder for MockTranslationService.
+type MockTranslationServiceMockRecorder struct {
+	mock *MockTranslationService
+}
+
+// NewMockTranslationService creates a new mock instance.
+func NewMockTranslationService(ctrl *gomock.Controller) *MockTranslationService {
+	mock := &MockTranslationService{ctrl: ctrl}
+	mock.recorder = &MockTranslationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationService) EXPECT() *MockTranslationServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTranslationService) Delete(ctx *krogo.Context, productID, variantID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, variantID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTranslationServiceMockRecorder) Delete(ctx, productID, variantID, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTranslationService)(nil).Delete), ctx, productID, variantID, locale)
+}
+
+// Get mocks base method.
+func (m *MockTranslationService) Get(ctx *krogo.Context, productID string) (*models.ProductTranslations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID)
+	ret0, _ := ret[0].(*models.ProductTranslations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTranslationServiceMockRecorder) Get(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTranslationService)(nil).Get), ctx, productID)
+}
+
+// Set mocks base method.
+func (m *MockTranslationService) Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, translation)
+	ret0, _ := ret[0].(*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTranslationServiceMockRecorder) Set(ctx, productID, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTranslationService)(nil).Set), ctx, productID, translation)
+}
@@ -0,0 +1,152 @@
+package translations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/service/access"
+	"practice-app/store/products"
+	"practice-app/store/translations"
+	"practice-app/store/variants"
+)
+
+type Service struct {
+	store        translations.TranslationStore
+	productStore products.ProductStore
+	variantStore variants.VariantStore
+	required     []string
+}
+
+// New builds the service. requiredLocales are the locales every product is expected to be translated into;
+// invalid tags and the base locale are ignored.
+func New(store translations.TranslationStore, productStore products.ProductStore, variantStore variants.VariantStore,
+	requiredLocales []string) *Service {
+	var required []string
+
+	for _, tag := range requiredLocales {
+		if locale, ok := translations.Normalize(tag); ok && locale != translations.BaseLocale {
+			required = append(required, locale)
+		}
+	}
+
+	return &Service{store: store, productStore: productStore, variantStore: variantStore, required: required}
+}
+
+// Get returns the translations of a product and its variants, and the required locales in which some of
+// their text has no translation, even after falling back to a more general locale.
+func (s *Service) Get(ctx *krogo.Context, productID string) (*models.ProductTranslations, error) {
+	p, err := access.Product(ctx, s.productStore, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Variant, err = s.variantStore.GetVariantData(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.store.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ProductTranslations{ProductID: productID, Translations: ts, MissingLocales: []string{}}
+
+	if ts == nil {
+		res.Translations = []models.Translation{}
+	}
+
+	for _, locale := range s.required {
+		if !translated(p, ts, translations.Fallbacks(locale)) {
+			res.MissingLocales = append(res.MissingLocales, locale)
+		}
+	}
+
+	return res, nil
+}
+
+// Set stores the translation of a product, or of one of its variants when VariantID is set.
+func (s *Service) Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error) {
+	locale, err := checkLocale(translation.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if translation.Name == "" && translation.Details == "" {
+		return nil, errors.MissingParam{Param: []string{"name", "details"}}
+	}
+
+	if err = s.checkTarget(ctx, productID, translation.VariantID); err != nil {
+		return nil, err
+	}
+
+	translation.Locale = locale
+
+	return s.store.Set(ctx, productID, translation)
+}
+
+func (s *Service) Delete(ctx *krogo.Context, productID, variantID, locale string) error {
+	locale, err := checkLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	if _, err = access.Editable(ctx, s.productStore, productID); err != nil {
+		return err
+	}
+
+	ts, err := s.store.GetByProductID(ctx, productID, locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range ts {
+		if ts[i].VariantID == variantID {
+			return s.store.Delete(ctx, productID, variantID, locale)
+		}
+	}
+
+	return errors.EntityNotFound{ID: locale, Entity: "translations"}
+}
+
+// checkTarget makes sure the product, and the variant if one is given, exist and that the user making the request
+// may change the product.
+func (s *Service) checkTarget(ctx *krogo.Context, productID, variantID string) error {
+	if _, err := access.Editable(ctx, s.productStore, productID); err != nil {
+		return err
+	}
+
+	if variantID == "" {
+		return nil
+	}
+
+	_, err := access.Variant(ctx, s.variantStore, variantID, productID)
+
+	return err
+}
+
+// checkLocale normalizes a locale a translation is written in. The base locale is rejected, as its text
+// is the one stored on the product and variants.
+func checkLocale(tag string) (string, error) {
+	locale, ok := translations.Normalize(tag)
+	if !ok || locale == translations.BaseLocale {
+		return "", errors.InvalidParam{Param: []string{"locale"}}
+	}
+
+	return locale, nil
+}
+
+// translated reports whether the name and details of a product and of all its variants resolve to a translation.
+func translated(p *models.ProductWithVariants, ts []models.Translation, chain []string) bool {
+	if t := translations.Resolve(ts, "", chain); t.Name == "" || t.Details == "" {
+		return false
+	}
+
+	for i := range p.Variant {
+		if t := translations.Resolve(ts, p.Variant[i].ID, chain); t.Name == "" || t.Details == "" {
+			return false
+		}
+	}
+
+	return true
+}
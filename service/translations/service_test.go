@@ -0,0 +1,262 @@
+package translations
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"practice-app/store/products"
+	"practice-app/store/translations"
+	"practice-app/store/variants"
+	"testing"
+)
+
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
+func TestService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := translations.NewMockTranslationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore, []string{"es", "es_mx", "fr", "en", "bad tag"})
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	product := &models.ProductWithVariants{ID: "1", Name: "Milk", Details: "Whole milk"}
+	variantData := []models.VariantInfo{{ID: "1-s", Name: "Small", Details: "Half gallon"}}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.ProductTranslations
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success: regional locale falls back to its language",
+			ExpectedResult: &models.ProductTranslations{
+				ProductID: "1",
+				Translations: []models.Translation{
+					{Locale: "es", Name: "Leche", Details: "Leche entera"},
+					{Locale: "es-MX", Name: "Leche de vaca"},
+					{VariantID: "1-s", Locale: "es", Name: "Chica", Details: "Medio galón"},
+					{VariantID: "1-s", Locale: "fr", Name: "Petit"},
+				},
+				MissingLocales: []string{"fr"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return(variantData, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return([]models.Translation{
+					{Locale: "es", Name: "Leche", Details: "Leche entera"},
+					{Locale: "es-MX", Name: "Leche de vaca"},
+					{VariantID: "1-s", Locale: "es", Name: "Chica", Details: "Medio galón"},
+					{VariantID: "1-s", Locale: "fr", Name: "Petit"},
+				}, nil),
+			},
+		},
+		{
+			Desc: "Success: no translations",
+			ExpectedResult: &models.ProductTranslations{
+				ProductID:      "1",
+				Translations:   []models.Translation{},
+				MissingLocales: []string{"es", "es-MX", "fr"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return(variantData, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockVariantStore.EXPECT().GetVariantData(ctx, "1").Return(variantData, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1").Return(nil, errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Get(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := translations.NewMockTranslationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockService := New(mockStore, mockProductStore, mockVariantStore, nil)
+
+	ctx := userContext("u2")
+
+	product := &models.ProductWithVariants{ID: "1"}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Translation
+		ExpectedResult *models.Translation
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: product translation",
+			Body:           &models.Translation{Locale: "es_mx", Name: "Leche"},
+			ExpectedResult: &models.Translation{Locale: "es-MX", Name: "Leche"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockStore.EXPECT().Set(ctx, "1", &models.Translation{Locale: "es-MX", Name: "Leche"}).
+					Return(&models.Translation{Locale: "es-MX", Name: "Leche"}, nil),
+			},
+		},
+		{
+			Desc:           "Success: variant translation",
+			Body:           &models.Translation{VariantID: "1-s", Locale: "es", Details: "Medio galón"},
+			ExpectedResult: &models.Translation{VariantID: "1-s", Locale: "es", Details: "Medio galón"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-s", "1").Return(&models.Variant{ID: "1-s", ProductID: "1"}, nil),
+				mockStore.EXPECT().Set(ctx, "1", &models.Translation{VariantID: "1-s", Locale: "es", Details: "Medio galón"}).
+					Return(&models.Translation{VariantID: "1-s", Locale: "es", Details: "Medio galón"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: base locale",
+			Body:        &models.Translation{Locale: "en", Name: "Milk"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"locale"}},
+		},
+		{
+			Desc:        "Failure: invalid locale",
+			Body:        &models.Translation{Locale: "spanish", Name: "Leche"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"locale"}},
+		},
+		{
+			Desc:        "Failure: no text",
+			Body:        &models.Translation{Locale: "es"},
+			ExpectedErr: errors.MissingParam{Param: []string{"name", "details"}},
+		},
+		{
+			Desc:        "Failure: product not found",
+			Body:        &models.Translation{Locale: "es", Name: "Leche"},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			Body:        &models.Translation{VariantID: "1-x", Locale: "es", Name: "Chica"},
+			ExpectedErr: errors.EntityNotFound{ID: "1-x", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(product, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1-x", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Body:        &models.Translation{Locale: "es", Name: "Leche"},
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.Set(ctx, "1", test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := translations.NewMockTranslationStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockStore, mockProductStore, variants.NewMockVariantStore(ctrl), nil)
+
+	ctx := userContext("u2")
+
+	testcases := []struct {
+		Desc        string
+		VariantID   string
+		Locale      string
+		ExpectedErr error
+		Calls       []*gomock.Call
+	}{
+		{
+			Desc:      "Success",
+			VariantID: "1-s",
+			Locale:    "ES",
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1", "es").Return([]models.Translation{
+					{Locale: "es", Name: "Leche"},
+					{VariantID: "1-s", Locale: "es", Name: "Chica"},
+				}, nil),
+				mockStore.EXPECT().Delete(ctx, "1", "1-s", "es").Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Locale:      "es",
+			ExpectedErr: errors.EntityNotFound{ID: "es", Entity: "translations"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockStore.EXPECT().GetByProductID(ctx, "1", "es").Return([]models.Translation{
+					{VariantID: "1-s", Locale: "es", Name: "Chica"},
+				}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: invalid locale",
+			Locale:      "en",
+			ExpectedErr: errors.InvalidParam{Param: []string{"locale"}},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			Locale:      "es",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(ctx, "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		err := mockService.Delete(ctx, "1", test.VariantID, test.Locale)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -7,5 +7,9 @@ import (
 
 type VariantService interface {
 	GetByID(ctx *krogo.Context, id, pID string) (*models.Variant, error)
+	GetByGTIN(ctx *krogo.Context, code string) (*models.Variant, error)
 	Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error)
+	SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) (*models.Variant, error)
+	SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) (*models.Variant, error)
+	SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) (*models.Allergens, error)
 }
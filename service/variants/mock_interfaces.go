@@ -36,18 +36,33 @@ func (m *MockVariantService) EXPECT() *MockVariantServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockVariantService) Create(ctx *krogo.Context, product *models.Variant) (*models.Variant, error) {
+func (m *MockVariantService) Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, product)
+	ret := m.ctrl.Call(m, "Create", ctx, variant)
 	ret0, _ := ret[0].(*models.Variant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockVariantServiceMockRecorder) Create(ctx, product interface{}) *gomock.Call {
+func (mr *MockVariantServiceMockRecorder) Create(ctx, variant interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVariantService)(nil).Create), ctx, product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVariantService)(nil).Create), ctx, variant)
+}
+
+// GetByGTIN mocks base method.
+func (m *MockVariantService) GetByGTIN(ctx *krogo.Context, code string) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGTIN", ctx, code)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGTIN indicates an expected call of GetByGTIN.
+func (mr *MockVariantServiceMockRecorder) GetByGTIN(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGTIN", reflect.TypeOf((*MockVariantService)(nil).GetByGTIN), ctx, code)
 }
 
 // GetByID mocks base method.
@@ -64,3 +79,48 @@ func (mr *MockVariantServiceMockRecorder) GetByID(ctx, id, pID interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVariantService)(nil).GetByID), ctx, id, pID)
 }
+
+// SetAllergens mocks base method.
+func (m *MockVariantService) SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) (*models.Allergens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllergens", ctx, id, pID, allergens)
+	ret0, _ := ret[0].(*models.Allergens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAllergens indicates an expected call of SetAllergens.
+func (mr *MockVariantServiceMockRecorder) SetAllergens(ctx, id, pID, allergens interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllergens", reflect.TypeOf((*MockVariantService)(nil).SetAllergens), ctx, id, pID, allergens)
+}
+
+// SetMeasurements mocks base method.
+func (m *MockVariantService) SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMeasurements", ctx, id, pID, measurements)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMeasurements indicates an expected call of SetMeasurements.
+func (mr *MockVariantServiceMockRecorder) SetMeasurements(ctx, id, pID, measurements interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMeasurements", reflect.TypeOf((*MockVariantService)(nil).SetMeasurements), ctx, id, pID, measurements)
+}
+
+// SetNutrition mocks base method.
+func (m *MockVariantService) SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNutrition", ctx, id, pID, panel)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNutrition indicates an expected call of SetNutrition.
+func (mr *MockVariantServiceMockRecorder) SetNutrition(ctx, id, pID, panel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNutrition", reflect.TypeOf((*MockVariantService)(nil).SetNutrition), ctx, id, pID, panel)
+}
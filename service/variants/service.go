@@ -1,22 +1,93 @@
 package variants
 
 import (
+	"database/sql"
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
+	"net/http"
 	"practice-app/models"
+	"practice-app/nutrition"
+	"practice-app/service/access"
+	"practice-app/store/media"
+	"practice-app/store/options"
+	"practice-app/store/products"
+	"practice-app/store/translations"
 	"practice-app/store/variants"
+	"practice-app/units"
+	"regexp"
+	"sort"
+	"strings"
 )
 
+// gtinLength is the length every accepted barcode is normalized to. UPC-A (12 digits) and EAN-13 (13 digits)
+// become GTIN-14 by left-padding with zeros, which leaves their check digit valid.
+const gtinLength = 14
+
 type Service struct {
-	store variants.VariantStore
+	store        variants.VariantStore
+	productStore products.ProductStore
+	optionStore  options.OptionStore
+	mediaStore   media.MediaStore
+
+	translationStore translations.TranslationStore
 }
 
-func New(store variants.VariantStore) *Service {
-	return &Service{store: store}
+func New(store variants.VariantStore, productStore products.ProductStore, optionStore options.OptionStore,
+	mediaStore media.MediaStore, translationStore translations.TranslationStore) *Service {
+	return &Service{store: store, productStore: productStore, optionStore: optionStore, mediaStore: mediaStore,
+		translationStore: translationStore}
 }
 
 func (s *Service) GetByID(ctx *krogo.Context, id, pID string) (*models.Variant, error) {
-	return s.store.GetByID(ctx, id, pID)
+	if _, err := access.Product(ctx, s.productStore, pID); err != nil {
+		return nil, err
+	}
+
+	v, err := s.store.GetByID(ctx, id, pID)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Media, err = s.mediaStore.GetByVariantID(ctx, pID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the variant is translated into the languages of the Accept-Language header, as its product would be
+	if chain := translations.Chain(ctx.Header("Accept-Language")); len(chain) > 0 {
+		ts, err := s.translationStore.GetByProductID(ctx, pID, chain...)
+		if err != nil {
+			return nil, err
+		}
+
+		translations.LocalizeVariant(v, ts, chain)
+	}
+
+	return v, nil
+}
+
+// GetByGTIN looks a variant up by any of the barcode formats its GTIN can be written in. Variants of products that
+// are not published yet are not found without a preview token for their product.
+func (s *Service) GetByGTIN(ctx *krogo.Context, code string) (*models.Variant, error) {
+	gtin, ok := normalizeGTIN(code)
+	if !ok {
+		return nil, errors.InvalidParam{Param: []string{"code"}}
+	}
+
+	v, err := s.store.GetByGTIN(ctx, gtin)
+	if err == nil {
+		_, err = access.Product(ctx, s.productStore, v.ProductID)
+	}
+
+	if err != nil {
+		if _, ok := err.(errors.EntityNotFound); ok || err == sql.ErrNoRows {
+			return nil, errors.EntityNotFound{ID: code, Entity: "variants"}
+		}
+
+		return nil, err
+	}
+
+	return v, nil
 }
 
 func (s *Service) Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error) {
@@ -26,9 +97,322 @@ func (s *Service) Create(ctx *krogo.Context, variant *models.Variant) (*models.V
 		return nil, errors.MissingParam{Param: missingAttributes}
 	}
 
+	if variant.PriceCents < 0 {
+		return nil, errors.InvalidParam{Param: []string{"price_cents"}}
+	}
+
+	switch variant.Status {
+	case "":
+		variant.Status = models.StatusActive
+	case models.StatusDraft, models.StatusActive:
+	default:
+		return nil, errors.InvalidParam{Param: []string{"status"}}
+	}
+
+	if err := checkMeasurements(variant.Measurements); err != nil {
+		return nil, err
+	}
+
+	if err := checkNutrition(variant.Nutrition); err != nil {
+		return nil, err
+	}
+
+	allergens, err := checkAllergens(variant.Allergens)
+	if err != nil {
+		return nil, err
+	}
+
+	variant.Allergens = allergens
+
+	if _, err := access.Editable(ctx, s.productStore, variant.ProductID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkIdentifiers(ctx, variant); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkOptions(ctx, variant); err != nil {
+		return nil, err
+	}
+
 	return s.store.Create(ctx, variant)
 }
 
+// SetMeasurements replaces the measurements of a variant and returns the variant with its new unit price.
+func (s *Service) SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) (*models.Variant, error) {
+	if err := s.checkEditable(ctx, id, pID); err != nil {
+		return nil, err
+	}
+
+	if err := checkMeasurements(measurements); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetMeasurements(ctx, id, pID, measurements); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByID(ctx, id, pID)
+}
+
+// SetNutrition replaces the nutrition facts panel of a variant and returns the variant with its daily values.
+func (s *Service) SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) (*models.Variant, error) {
+	if err := s.checkEditable(ctx, id, pID); err != nil {
+		return nil, err
+	}
+
+	if panel == nil {
+		return nil, errors.MissingParam{Param: []string{"nutrition"}}
+	}
+
+	if err := checkNutrition(panel); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SetNutrition(ctx, id, pID, panel); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetByID(ctx, id, pID)
+}
+
+// SetAllergens replaces the major allergens a variant is declared to contain. An empty list declares none.
+func (s *Service) SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) (*models.Allergens, error) {
+	if err := s.checkEditable(ctx, id, pID); err != nil {
+		return nil, err
+	}
+
+	allergens, err := checkAllergens(allergens)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.store.SetAllergens(ctx, id, pID, allergens); err != nil {
+		return nil, err
+	}
+
+	return &models.Allergens{Allergens: allergens}, nil
+}
+
+// checkEditable makes sure the variant exists and the user making the request may change its product.
+func (s *Service) checkEditable(ctx *krogo.Context, id, pID string) error {
+	if _, err := access.Editable(ctx, s.productStore, pID); err != nil {
+		return err
+	}
+
+	_, err := access.Variant(ctx, s.store, id, pID)
+
+	return err
+}
+
+// checkNutrition validates a nutrition facts panel. The serving size must be a weight or volume, and every
+// nutrient must be known and appear once; amounts are converted to the unit of the nutrient and the nutrients
+// are put in label order.
+func checkNutrition(panel *models.Nutrition) error {
+	if panel == nil {
+		return nil
+	}
+
+	symbol, ok := units.Normalize(panel.ServingSize.Unit)
+	if kind, _ := units.Kind(symbol); !ok || panel.ServingSize.Value <= 0 || (kind != units.Mass && kind != units.Volume) {
+		return errors.InvalidParam{Param: []string{"nutrition.serving_size"}}
+	}
+
+	panel.ServingSize.Unit = symbol
+
+	if panel.Calories < 0 || panel.ServingsPerContainer < 0 {
+		return errors.InvalidParam{Param: []string{"nutrition.calories"}}
+	}
+
+	positions := make(map[string]int, len(panel.Nutrients))
+
+	for i := range panel.Nutrients {
+		n := &panel.Nutrients[i]
+
+		nutrient, position, ok := nutrition.Lookup(n.Name)
+		if !ok || n.Amount < 0 {
+			return errors.InvalidParam{Param: []string{"nutrition.nutrients"}}
+		}
+
+		if _, ok = positions[nutrient.Name]; ok {
+			return errors.InvalidParam{Param: []string{"nutrition.nutrients"}}
+		}
+
+		if n.Unit != "" && n.Unit != nutrient.Unit {
+			amount, err := units.Convert(n.Amount, n.Unit, nutrient.Unit)
+			if err != nil {
+				return errors.InvalidParam{Param: []string{"nutrition.nutrients"}}
+			}
+
+			n.Amount = amount
+		}
+
+		n.Name, n.Unit, n.DailyValue = nutrient.Name, nutrient.Unit, nil
+		positions[nutrient.Name] = position
+	}
+
+	sort.SliceStable(panel.Nutrients, func(i, j int) bool {
+		return positions[panel.Nutrients[i].Name] < positions[panel.Nutrients[j].Name]
+	})
+
+	return nil
+}
+
+// checkAllergens maps declared allergens to the names of the major allergens, without duplicates.
+func checkAllergens(allergens []string) ([]string, error) {
+	var res []string
+
+	for _, name := range allergens {
+		allergen, ok := nutrition.NormalizeAllergen(name)
+		if !ok {
+			return nil, errors.InvalidParam{Param: []string{"allergens"}}
+		}
+
+		if !contains(res, allergen) {
+			res = append(res, allergen)
+		}
+	}
+
+	return res, nil
+}
+
+// checkMeasurements makes sure every measurement has a positive value and a unit of the kind of quantity it
+// measures, and rewrites the units to their canonical symbols.
+func checkMeasurements(m *models.Measurements) error {
+	if m == nil {
+		return nil
+	}
+
+	fields := []struct {
+		name string
+		kind string
+		m    *models.Measurement
+	}{
+		{"net_weight", units.Mass, m.NetWeight},
+		{"volume", units.Volume, m.Volume},
+		{"length", units.Length, m.Length},
+		{"width", units.Length, m.Width},
+		{"height", units.Length, m.Height},
+	}
+
+	for _, f := range fields {
+		if f.m == nil {
+			continue
+		}
+
+		symbol, ok := units.Normalize(f.m.Unit)
+		if !ok || f.m.Value <= 0 {
+			return errors.InvalidParam{Param: []string{"measurements." + f.name}}
+		}
+
+		if kind, _ := units.Kind(symbol); kind != f.kind {
+			return errors.InvalidParam{Param: []string{"measurements." + f.name}}
+		}
+
+		f.m.Unit = symbol
+	}
+
+	return nil
+}
+
+// checkIdentifiers normalizes the SKU and GTIN of a new variant and rejects ones already used by another variant.
+func (s *Service) checkIdentifiers(ctx *krogo.Context, variant *models.Variant) error {
+	if variant.SKU != "" {
+		variant.SKU = strings.ToUpper(strings.TrimSpace(variant.SKU))
+
+		re := regexp.MustCompile("^[A-Z0-9][A-Z0-9._-]{0,63}$")
+		if !re.MatchString(variant.SKU) {
+			return errors.InvalidParam{Param: []string{"sku"}}
+		}
+
+		if err := s.checkTaken(ctx, s.store.GetBySKU, "sku", variant.SKU); err != nil {
+			return err
+		}
+	}
+
+	if variant.GTIN != "" {
+		gtin, ok := normalizeGTIN(variant.GTIN)
+		if !ok {
+			return errors.InvalidParam{Param: []string{"gtin"}}
+		}
+
+		variant.GTIN = gtin
+
+		if err := s.checkTaken(ctx, s.store.GetByGTIN, "gtin", variant.GTIN); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkTaken returns a conflict when get finds a variant already using value for the given identifier field.
+func (s *Service) checkTaken(ctx *krogo.Context, get func(*krogo.Context, string) (*models.Variant, error), field, value string) error {
+	existing, err := get(ctx, value)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return err
+	default:
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       strings.ToUpper(field) + "_EXISTS",
+			Reason:     field + " " + value + " is already used by variant " + existing.ID,
+		}
+	}
+}
+
+// checkOptions makes sure a variant of a product with option axes picks exactly one declared value per axis,
+// and that no other variant of the product already has the same combination.
+func (s *Service) checkOptions(ctx *krogo.Context, variant *models.Variant) error {
+	axes, err := s.optionStore.GetByProductID(ctx, variant.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if len(axes) == 0 && len(variant.Options) == 0 {
+		return nil
+	}
+
+	if len(variant.Options) != len(axes) {
+		return errors.InvalidParam{Param: []string{"options"}}
+	}
+
+	for _, a := range axes {
+		if !contains(a.Values, variant.Options[a.Name]) {
+			return errors.InvalidParam{Param: []string{"options"}}
+		}
+	}
+
+	keys, err := s.store.GetOptionKeys(ctx, variant.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if contains(keys, variants.OptionKey(variant.Options)) {
+		return &errors.Response{
+			StatusCode: http.StatusConflict,
+			Code:       "VARIANT_COMBINATION_EXISTS",
+			Reason:     "product " + variant.ProductID + " already has a variant with these options",
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 func findMissingAttributes(variant *models.Variant) (res []string) {
 	if variant.ID == "" {
 		res = append(res, "id")
@@ -48,3 +432,41 @@ func findMissingAttributes(variant *models.Variant) (res []string) {
 
 	return res
 }
+
+// normalizeGTIN accepts a UPC-A, EAN-13 or GTIN-14 barcode, ignoring spaces and dashes, and returns it as a
+// 14-digit GTIN if its check digit is valid.
+func normalizeGTIN(code string) (string, bool) {
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+
+	if len(code) < 12 || len(code) > gtinLength {
+		return "", false
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+
+	code = strings.Repeat("0", gtinLength-len(code)) + code
+
+	return code, checkDigit(code[:gtinLength-1]) == code[gtinLength-1]
+}
+
+// checkDigit computes the GS1 mod-10 check digit: digits are weighted 3 and 1 alternately,
+// starting with 3 at the digit next to the check digit.
+func checkDigit(digits string) byte {
+	sum := 0
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+
+		sum += d
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
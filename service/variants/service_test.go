@@ -1,25 +1,44 @@
 package variants
 
 import (
+	"database/sql"
 	"github.com/golang/mock/gomock"
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"practice-app/models"
+	"practice-app/store/media"
+	"practice-app/store/options"
+	"practice-app/store/products"
+	"practice-app/store/translations"
 	"practice-app/store/variants"
 	"testing"
 )
 
+// userContext is a request made by a signed in user.
+func userContext(user string) *krogo.Context {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(models.UserHeader, user)
+
+	return krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+}
+
 func TestHandler_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
-	mockService := New(mockVariantStore)
-
-	ctx := krogo.NewContext(nil, nil, krogo.New())
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockMediaStore := media.NewMockMediaStore(ctrl)
+	mockTranslationStore := translations.NewMockTranslationStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, options.NewMockOptionStore(ctrl), mockMediaStore, mockTranslationStore)
 
 	testcases := []struct {
 		Desc           string
-		ExpectedResult interface{}
+		AcceptLanguage string
+		Query          string
+		ExpectedResult *models.Variant
 		ExpectedErr    error
 		ID             string
 		Pid            string
@@ -34,20 +53,68 @@ func TestHandler_GetByID(t *testing.T) {
 				ProductID: "1",
 				Name:      "variant_1",
 				Details:   "details",
+				Media:     []models.Media{{ID: 2, ProductID: "1", VariantID: "1", URL: "swatch", Role: "swatch"}},
 			},
 			ExpectedErr: nil,
 			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
 				mockVariantStore.EXPECT().GetByID(gomock.Any(), "1", "1").Return(&models.Variant{
 					ID:        "1",
 					ProductID: "1",
 					Name:      "variant_1",
 					Details:   "details",
 				}, nil),
+				mockMediaStore.EXPECT().GetByVariantID(gomock.Any(), "1", "1").
+					Return([]models.Media{{ID: 2, ProductID: "1", VariantID: "1", URL: "swatch", Role: "swatch"}}, nil),
+			},
+		},
+		{
+			Desc:           "Success: localized",
+			AcceptLanguage: "es-MX",
+			ID:             "1",
+			Pid:            "1",
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", Name: "variante_1", Details: "details"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(gomock.Any(), "1", "1").
+					Return(&models.Variant{ID: "1", ProductID: "1", Name: "variant_1", Details: "details"}, nil),
+				mockMediaStore.EXPECT().GetByVariantID(gomock.Any(), "1", "1").Return(nil, nil),
+				mockTranslationStore.EXPECT().GetByProductID(gomock.Any(), "1", "es-MX", "es").Return([]models.Translation{
+					{Locale: "es", Name: "producto_1"},
+					{VariantID: "1", Locale: "es", Name: "variante_1"},
+				}, nil),
+			},
+		},
+		{
+			Desc:           "Success: preview of a draft",
+			Query:          "?preview_token=abc",
+			ID:             "1",
+			Pid:            "1",
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", Name: "variant_1", Details: "details"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+				mockProductStore.EXPECT().CheckPreview(gomock.Any(), "1", "abc").Return(true, nil),
+				mockVariantStore.EXPECT().GetByID(gomock.Any(), "1", "1").
+					Return(&models.Variant{ID: "1", ProductID: "1", Name: "variant_1", Details: "details"}, nil),
+				mockMediaStore.EXPECT().GetByVariantID(gomock.Any(), "1", "1").Return(nil, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft without a preview token",
+			ID:          "1",
+			Pid:         "1",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "products"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
 			},
 		},
 	}
 
 	for i, test := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/products/1/variant/1"+test.Query, nil)
+		r.Header.Set("Accept-Language", test.AcceptLanguage)
+
+		ctx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
 		res, err := mockService.GetByID(ctx, test.ID, test.Pid)
 
 		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
@@ -58,9 +125,17 @@ func TestHandler_GetByID(t *testing.T) {
 func TestHandler_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
-	mockService := New(mockVariantStore)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockOptionStore := options.NewMockOptionStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, mockOptionStore, media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl))
 
-	ctx := krogo.NewContext(nil, nil, krogo.New())
+	ctx := userContext("u2")
+
+	axes := []models.OptionAxis{
+		{Name: "Size", Values: []string{"S", "M"}},
+		{Name: "Color", Values: []string{"Red"}},
+	}
 
 	testcases := []struct {
 		Desc           string
@@ -87,11 +162,14 @@ func TestHandler_Create(t *testing.T) {
 				Details:   "details",
 			},
 			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(nil, nil),
 				mockVariantStore.EXPECT().Create(gomock.Any(), &models.Variant{
 					ID:        "1",
 					ProductID: "1",
 					Name:      "variant_1",
 					Details:   "details",
+					Status:    "active",
 				}).Return(&models.Variant{
 					ID:        "1",
 					ProductID: "1",
@@ -100,6 +178,231 @@ func TestHandler_Create(t *testing.T) {
 				}, nil),
 			},
 		},
+		{
+			Desc: "Success: with options",
+			ExpectedResult: &models.Variant{
+				ID:        "2",
+				ProductID: "1",
+				Name:      "S / Red",
+				Details:   "details",
+				Options:   map[string]string{"Size": "S", "Color": "Red"},
+			},
+			Pid: "1",
+			Body: &models.Variant{
+				ID:        "2",
+				ProductID: "1",
+				Name:      "S / Red",
+				Details:   "details",
+				Options:   map[string]string{"Size": "S", "Color": "Red"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(gomock.Any(), "1").Return([]string{`{"Color":"Red","Size":"M"}`}, nil),
+				mockVariantStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Variant{
+					ID:        "2",
+					ProductID: "1",
+					Name:      "S / Red",
+					Details:   "details",
+					Options:   map[string]string{"Size": "S", "Color": "Red"},
+				}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: undeclared option value",
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "3",
+				ProductID: "1",
+				Name:      "L / Red",
+				Details:   "details",
+				Options:   map[string]string{"Size": "L", "Color": "Red"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(axes, nil),
+			},
+		},
+		{
+			Desc:        "Failure: missing option axis",
+			ExpectedErr: errors.InvalidParam{Param: []string{"options"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "3",
+				ProductID: "1",
+				Name:      "S",
+				Details:   "details",
+				Options:   map[string]string{"Size": "S"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(axes, nil),
+			},
+		},
+		{
+			Desc: "Success: identifiers normalized",
+			ExpectedResult: &models.Variant{
+				ID:        "5",
+				ProductID: "1",
+				Name:      "variant_5",
+				Details:   "details",
+				SKU:       "ABC-123",
+				GTIN:      "00012345678905",
+			},
+			Pid: "1",
+			Body: &models.Variant{
+				ID:        "5",
+				ProductID: "1",
+				Name:      "variant_5",
+				Details:   "details",
+				SKU:       " abc-123 ",
+				GTIN:      "0 12345-67890 5",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetBySKU(gomock.Any(), "ABC-123").Return(nil, sql.ErrNoRows),
+				mockVariantStore.EXPECT().GetByGTIN(gomock.Any(), "00012345678905").Return(nil, sql.ErrNoRows),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(nil, nil),
+				mockVariantStore.EXPECT().Create(gomock.Any(), &models.Variant{
+					ID:        "5",
+					ProductID: "1",
+					Name:      "variant_5",
+					Details:   "details",
+					SKU:       "ABC-123",
+					GTIN:      "00012345678905",
+					Status:    "active",
+				}).Return(&models.Variant{
+					ID:        "5",
+					ProductID: "1",
+					Name:      "variant_5",
+					Details:   "details",
+					SKU:       "ABC-123",
+					GTIN:      "00012345678905",
+				}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: bad check digit",
+			ExpectedErr: errors.InvalidParam{Param: []string{"gtin"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "6",
+				ProductID: "1",
+				Name:      "variant_6",
+				Details:   "details",
+				GTIN:      "012345678904",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: negative price",
+			ExpectedErr: errors.InvalidParam{Param: []string{"price_cents"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:         "6",
+				ProductID:  "1",
+				Name:       "variant_6",
+				Details:    "details",
+				PriceCents: -100,
+			},
+		},
+		{
+			Desc:        "Failure: created archived",
+			ExpectedErr: errors.InvalidParam{Param: []string{"status"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "6",
+				ProductID: "1",
+				Name:      "variant_6",
+				Details:   "details",
+				Status:    "archived",
+			},
+		},
+		{
+			Desc:        "Failure: invalid sku",
+			ExpectedErr: errors.InvalidParam{Param: []string{"sku"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "6",
+				ProductID: "1",
+				Name:      "variant_6",
+				Details:   "details",
+				SKU:       "no spaces allowed",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: gtin taken",
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "GTIN_EXISTS",
+				Reason:     "gtin 00012345678905 is already used by variant 5",
+			},
+			Pid: "1",
+			Body: &models.Variant{
+				ID:        "6",
+				ProductID: "1",
+				Name:      "variant_6",
+				Details:   "details",
+				GTIN:      "0012345678905",
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByGTIN(gomock.Any(), "00012345678905").Return(&models.Variant{ID: "5"}, nil),
+			},
+		},
+		{
+			Desc: "Failure: combination exists",
+			ExpectedErr: &errors.Response{
+				StatusCode: http.StatusConflict,
+				Code:       "VARIANT_COMBINATION_EXISTS",
+				Reason:     "product 1 already has a variant with these options",
+			},
+			Pid: "1",
+			Body: &models.Variant{
+				ID:        "4",
+				ProductID: "1",
+				Name:      "M / Red",
+				Details:   "details",
+				Options:   map[string]string{"Size": "M", "Color": "Red"},
+			},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockOptionStore.EXPECT().GetByProductID(gomock.Any(), "1").Return(axes, nil),
+				mockVariantStore.EXPECT().GetOptionKeys(gomock.Any(), "1").Return([]string{`{"Color":"Red","Size":"M"}`}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: net weight in a unit of volume",
+			ExpectedErr: errors.InvalidParam{Param: []string{"measurements.net_weight"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:           "5",
+				ProductID:    "1",
+				Name:         "variant_5",
+				Details:      "details",
+				Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 12, Unit: "fl oz"}},
+			},
+			Calls: []*gomock.Call{},
+		},
+		{
+			Desc:        "Failure: not a major allergen",
+			ExpectedErr: errors.InvalidParam{Param: []string{"allergens"}},
+			Pid:         "1",
+			Body: &models.Variant{
+				ID:        "6",
+				ProductID: "1",
+				Name:      "variant_6",
+				Details:   "details",
+				Allergens: []string{"milk", "gluten"},
+			},
+			Calls: []*gomock.Call{},
+		},
 		{
 			Desc:           "Failure: Missing params",
 			ExpectedResult: nil,
@@ -108,6 +411,15 @@ func TestHandler_Create(t *testing.T) {
 			Body:           &models.Variant{},
 			Calls:          []*gomock.Call{},
 		},
+		{
+			Desc:        "Failure: draft of another user",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Pid:         "1",
+			Body:        &models.Variant{ID: "6", ProductID: "1", Name: "variant_6", Details: "details"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
 	}
 
 	for i, test := range testcases {
@@ -117,3 +429,369 @@ func TestHandler_Create(t *testing.T) {
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func TestService_SetMeasurements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, options.NewMockOptionStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl))
+
+	ctx := userContext("u2")
+
+	variant := &models.Variant{ID: "1", ProductID: "1", PriceCents: 349}
+	measured := &models.Variant{
+		ID: "1", ProductID: "1", PriceCents: 349,
+		Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 1, Unit: "lb"}},
+		UnitPrice:    &models.UnitPrice{Cents: 21.81, Per: "oz"},
+	}
+
+	testcases := []struct {
+		Desc           string
+		Measurements   *models.Measurements
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: unit normalized",
+			Measurements:   &models.Measurements{NetWeight: &models.Measurement{Value: 1, Unit: "Pounds"}},
+			ExpectedResult: measured,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+				mockVariantStore.EXPECT().SetMeasurements(ctx, "1", "1",
+					&models.Measurements{NetWeight: &models.Measurement{Value: 1, Unit: "lb"}}).Return(nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(measured, nil),
+			},
+		},
+		{
+			Desc:         "Failure: variant not found",
+			Measurements: &models.Measurements{},
+			ExpectedErr:  errors.EntityNotFound{ID: "1", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:         "Failure: unknown unit",
+			Measurements: &models.Measurements{Height: &models.Measurement{Value: 10, Unit: "furlong"}},
+			ExpectedErr:  errors.InvalidParam{Param: []string{"measurements.height"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:         "Failure: value not positive",
+			Measurements: &models.Measurements{Volume: &models.Measurement{Value: 0, Unit: "ml"}},
+			ExpectedErr:  errors.InvalidParam{Param: []string{"measurements.volume"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:         "Failure: DB error",
+			Measurements: &models.Measurements{Width: &models.Measurement{Value: 5, Unit: "cm"}},
+			ExpectedErr:  errors.DB{Err: errors.Error("DB Error")},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+				mockVariantStore.EXPECT().SetMeasurements(ctx, "1", "1", gomock.Any()).
+					Return(errors.DB{Err: errors.Error("DB Error")}),
+			},
+		},
+		{
+			Desc:         "Failure: draft of another user",
+			ExpectedErr:  &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Measurements: &models.Measurements{},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetMeasurements(ctx, "1", "1", test.Measurements)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetNutrition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, options.NewMockOptionStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl))
+
+	ctx := userContext("u2")
+
+	variant := &models.Variant{ID: "1", ProductID: "1"}
+
+	testcases := []struct {
+		Desc           string
+		Panel          *models.Nutrition
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc: "Success: nutrients converted and put in label order",
+			Panel: &models.Nutrition{
+				ServingSize: models.Measurement{Value: 1, Unit: "Ounce"},
+				Calories:    160,
+				Nutrients: []models.Nutrient{
+					{Name: "Sodium", Amount: 0.46, Unit: "g"},
+					{Name: "total fat", Amount: 8},
+				},
+			},
+			ExpectedResult: variant,
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+				mockVariantStore.EXPECT().SetNutrition(ctx, "1", "1", &models.Nutrition{
+					ServingSize: models.Measurement{Value: 1, Unit: "oz"},
+					Calories:    160,
+					Nutrients: []models.Nutrient{
+						{Name: "total_fat", Amount: 8, Unit: "g"},
+						{Name: "sodium", Amount: 460, Unit: "mg"},
+					},
+				}).Return(nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			Panel:       &models.Nutrition{},
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: missing panel",
+			ExpectedErr: errors.MissingParam{Param: []string{"nutrition"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: serving size in a unit of length",
+			Panel:       &models.Nutrition{ServingSize: models.Measurement{Value: 2, Unit: "in"}},
+			ExpectedErr: errors.InvalidParam{Param: []string{"nutrition.serving_size"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: negative calories",
+			Panel:       &models.Nutrition{ServingSize: models.Measurement{Value: 30, Unit: "g"}, Calories: -1},
+			ExpectedErr: errors.InvalidParam{Param: []string{"nutrition.calories"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc: "Failure: nutrient listed twice",
+			Panel: &models.Nutrition{
+				ServingSize: models.Measurement{Value: 30, Unit: "g"},
+				Nutrients:   []models.Nutrient{{Name: "protein", Amount: 3}, {Name: "Protein", Amount: 4}},
+			},
+			ExpectedErr: errors.InvalidParam{Param: []string{"nutrition.nutrients"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc: "Failure: unknown nutrient",
+			Panel: &models.Nutrition{
+				ServingSize: models.Measurement{Value: 30, Unit: "g"},
+				Nutrients:   []models.Nutrient{{Name: "caffeine", Amount: 95, Unit: "mg"}},
+			},
+			ExpectedErr: errors.InvalidParam{Param: []string{"nutrition.nutrients"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetNutrition(ctx, "1", "1", test.Panel)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_SetAllergens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, options.NewMockOptionStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl))
+
+	ctx := userContext("u2")
+
+	variant := &models.Variant{ID: "1", ProductID: "1"}
+
+	testcases := []struct {
+		Desc           string
+		Allergens      []string
+		ExpectedResult *models.Allergens
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: normalized without duplicates",
+			Allergens:      []string{"Peanuts", "milk", "peanut"},
+			ExpectedResult: &models.Allergens{Allergens: []string{"peanut", "milk"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+				mockVariantStore.EXPECT().SetAllergens(ctx, "1", "1", []string{"peanut", "milk"}).Return(nil),
+			},
+		},
+		{
+			Desc:           "Success: none declared",
+			ExpectedResult: &models.Allergens{},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+				mockVariantStore.EXPECT().SetAllergens(ctx, "1", "1", nil).Return(nil),
+			},
+		},
+		{
+			Desc:        "Failure: unknown allergen",
+			Allergens:   []string{"gluten"},
+			ExpectedErr: errors.InvalidParam{Param: []string{"allergens"}},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(variant, nil),
+			},
+		},
+		{
+			Desc:        "Failure: variant not found",
+			ExpectedErr: errors.EntityNotFound{ID: "1", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+				mockVariantStore.EXPECT().GetByID(ctx, "1", "1").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: draft of another user",
+			ExpectedErr: &errors.Response{StatusCode: http.StatusForbidden, Code: "NOT_OWNER", Reason: "only the owner of a draft can change it"},
+			Calls: []*gomock.Call{
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft", Owner: "u1"}, nil),
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.SetAllergens(ctx, "1", "1", test.Allergens)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func TestService_GetByGTIN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockVariantStore := variants.NewMockVariantStore(ctrl)
+	mockProductStore := products.NewMockProductStore(ctrl)
+	mockService := New(mockVariantStore, mockProductStore, options.NewMockOptionStore(ctrl), media.NewMockMediaStore(ctrl),
+		translations.NewMockTranslationStore(ctrl))
+
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		Calls          []*gomock.Call
+	}{
+		{
+			Desc:           "Success: UPC-A",
+			Code:           "012345678905",
+			ExpectedResult: &models.Variant{ID: "1", ProductID: "1", GTIN: "00012345678905"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetByGTIN(ctx, "00012345678905").
+					Return(&models.Variant{ID: "1", ProductID: "1", GTIN: "00012345678905"}, nil),
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: product not published",
+			Code:        "012345678905",
+			ExpectedErr: errors.EntityNotFound{ID: "012345678905", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetByGTIN(ctx, "00012345678905").
+					Return(&models.Variant{ID: "1", ProductID: "1", GTIN: "00012345678905"}, nil),
+				mockProductStore.EXPECT().GetByID(gomock.Any(), "1").Return(&models.ProductWithVariants{ID: "1", Status: "draft"}, nil),
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Code:        "4006381333931",
+			ExpectedErr: errors.EntityNotFound{ID: "4006381333931", Entity: "variants"},
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetByGTIN(ctx, "04006381333931").Return(nil, sql.ErrNoRows),
+			},
+		},
+		{
+			Desc:        "Failure: invalid code",
+			Code:        "12345",
+			ExpectedErr: errors.InvalidParam{Param: []string{"code"}},
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := mockService.GetByGTIN(ctx, test.Code)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_normalizeGTIN(t *testing.T) {
+	testcases := []struct {
+		Desc     string
+		Code     string
+		Expected string
+		Valid    bool
+	}{
+		{Desc: "UPC-A", Code: "036000291452", Expected: "00036000291452", Valid: true},
+		{Desc: "EAN-13", Code: "4006381333931", Expected: "04006381333931", Valid: true},
+		{Desc: "GTIN-14", Code: "10012345678902", Expected: "10012345678902", Valid: true},
+		{Desc: "bad check digit", Code: "036000291453"},
+		{Desc: "too short", Code: "12345670"},
+		{Desc: "not numeric", Code: "03600029145A"},
+	}
+
+	for i, test := range testcases {
+		res, ok := normalizeGTIN(test.Code)
+
+		assert.Equalf(t, test.Valid, ok, "TEST[%v] FAILED - %s", i, test.Desc)
+
+		if test.Valid {
+			assert.Equalf(t, test.Expected, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		}
+	}
+}
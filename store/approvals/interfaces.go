@@ -0,0 +1,15 @@
+package approvals
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type ApprovalStore interface {
+	GetPending(ctx *krogo.Context) ([]models.Approval, error)
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Approval, error)
+	GetByID(ctx *krogo.Context, id string) (*models.Approval, error)
+	Submit(ctx *krogo.Context, transition models.Transition) (*models.Approval, error)
+	Decide(ctx *krogo.Context, approval *models.Approval, transition models.Transition, comment string) (*models.Approval, error)
+	AddComment(ctx *krogo.Context, comment *models.ApprovalComment) (*models.ApprovalComment, error)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package approvals is a generated GoMock package.
+package approvals
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockApprovalStore is a mock of ApprovalStore interface.
+type MockApprovalStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalStoreMockRecorder
+}
+
+// MockApprovalStoreMockRecorder is the mock recorder for MockApprovalStore.
+type MockApprovalStoreMockRecorder struct {
+	mock *MockApprovalStore
+}
+
+// NewMockApprovalStore creates a new mock instance.
+func NewMockApprovalStore(ctrl *gomock.Controller) *MockApprovalStore {
+	mock := &MockApprovalStore{ctrl: ctrl}
+	mock.recorder = &MockApprovalStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalStore) EXPECT() *MockApprovalStoreMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockApprovalStore) AddComment(ctx *krogo.Context, comment *models.ApprovalComment) (*models.ApprovalComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, comment)
+	ret0, _ := ret[0].(*models.ApprovalComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockApprovalStoreMockRecorder) AddComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockApprovalStore)(nil).AddComment), ctx, comment)
+}
+
+// Decide mocks base method.
+func (m *MockApprovalStore) Decide(ctx *krogo.Context, approval *models.Approval, transition models.Transition, comment string) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, approval, transition, comment)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockApprovalStoreMockRecorder) Decide(ctx, approval, transition, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockApprovalStore)(nil).Decide), ctx, approval, transition, comment)
+}
+
+// GetByID mocks base method.
+func (m *MockApprovalStore) GetByID(ctx *krogo.Context, id string) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApprovalStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApprovalStore)(nil).GetByID), ctx, id)
+}
+
+// GetByProductID mocks base method.
+func (m *MockApprovalStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockApprovalStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockApprovalStore)(nil).GetByProductID), ctx, productID)
+}
+
+// GetPending mocks base method.
+func (m *MockApprovalStore) GetPending(ctx *krogo.Context) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockApprovalStoreMockRecorder) GetPending(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockApprovalStore)(nil).GetPending), ctx)
+}
+
+// Submit mocks base method.
+func (m *MockApprovalStore) Submit(ctx *krogo.Context, transition models.Transition) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, transition)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockApprovalStoreMockRecorder) Submit(ctx, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockApprovalStore)(nil).Submit), ctx, transition)
+}
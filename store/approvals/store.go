@@ -0,0 +1,189 @@
+package approvals
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/lifecycle"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const (
+	// approvalQuery reads approval requests with the name of the product they are for.
+	approvalQuery = "SELECT a.id, a.product_id, p.name, a.state, a.submitted_by, a.submitted_at, COALESCE(a.reviewer, ''), " +
+		"a.decided_at FROM approvals a JOIN products p ON p.id = a.product_id "
+	// commentQuery reads the comments of the approval requests matched by the same conditions as approvalQuery.
+	commentQuery = "SELECT c.id, c.approval_id, c.author, c.body, c.created_at FROM approval_comments c " +
+		"JOIN approvals a ON a.id = c.approval_id "
+	insertComment = "INSERT INTO approval_comments(approval_id, author, body) VALUES ($1,$2,$3) RETURNING id, created_at"
+)
+
+// GetPending lists the requests waiting for a reviewer, longest waiting first.
+func (s *Store) GetPending(ctx *krogo.Context) ([]models.Approval, error) {
+	return s.find(ctx, "WHERE a.state='"+models.ApprovalPending+"'")
+}
+
+// GetByProductID lists every request to publish a product, oldest first.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.Approval, error) {
+	return s.find(ctx, "WHERE a.product_id=$1", productID)
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, id string) (*models.Approval, error) {
+	res, err := s.find(ctx, "WHERE a.id=$1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &res[0], nil
+}
+
+// Submit moves a draft into review and opens a request for it, in one transaction. sql.ErrNoRows is returned
+// when the product is no longer in the status the transition moves from.
+func (s *Store) Submit(ctx *krogo.Context, transition models.Transition) (*models.Approval, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	if err = lifecycle.Record(ctx, tx, []models.Transition{transition}); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	a := models.Approval{ProductID: transition.ProductID, State: models.ApprovalPending, SubmittedBy: transition.Actor}
+
+	err = tx.QueryRowContext(ctx, "INSERT INTO approvals(product_id, submitted_by) VALUES ($1,$2) RETURNING id, submitted_at",
+		a.ProductID, a.SubmittedBy).Scan(&a.ID, &a.SubmittedAt)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return &a, nil
+}
+
+// Decide closes a pending request with the state and reviewer set on approval, moves its product out of review
+// and keeps the reviewer's comment, all or none of it. sql.ErrNoRows is returned when the request was decided
+// or the product changed status meanwhile.
+func (s *Store) Decide(ctx *krogo.Context, approval *models.Approval, transition models.Transition,
+	comment string) (*models.Approval, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	err = tx.QueryRowContext(ctx, "UPDATE approvals SET state=$1, reviewer=$2, decided_at=now() WHERE id=$3 AND state='"+
+		models.ApprovalPending+"' RETURNING decided_at", approval.State, approval.Reviewer, approval.ID).Scan(&approval.DecidedAt)
+	if err != nil {
+		_ = tx.Rollback()
+
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if err = lifecycle.Record(ctx, tx, []models.Transition{transition}); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if comment != "" {
+		c := models.ApprovalComment{ApprovalID: approval.ID, Author: approval.Reviewer, Body: comment}
+
+		err = tx.QueryRowContext(ctx, insertComment, c.ApprovalID, c.Author, c.Body).Scan(&c.ID, &c.CreatedAt)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, errors.DB{Err: err}
+		}
+
+		approval.Comments = append(approval.Comments, c)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return approval, nil
+}
+
+func (s *Store) AddComment(ctx *krogo.Context, comment *models.ApprovalComment) (*models.ApprovalComment, error) {
+	err := ctx.DB().QueryRowContext(ctx, insertComment, comment.ApprovalID, comment.Author, comment.Body).
+		Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return comment, nil
+}
+
+// find reads the approval requests matching a condition on approvals a, with their comments in order.
+func (s *Store) find(ctx *krogo.Context, where string, args ...interface{}) ([]models.Approval, error) {
+	var res []models.Approval
+
+	rows, err := ctx.DB().QueryContext(ctx, approvalQuery+where+" ORDER BY a.submitted_at, a.id", args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	index := make(map[int]int)
+
+	for rows.Next() {
+		var a models.Approval
+
+		err = rows.Scan(&a.ID, &a.ProductID, &a.ProductName, &a.State, &a.SubmittedBy, &a.SubmittedAt, &a.Reviewer, &a.DecidedAt)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		index[a.ID] = len(res)
+		res = append(res, a)
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	comments, err := ctx.DB().QueryContext(ctx, commentQuery+where+" ORDER BY c.created_at, c.id", args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer comments.Close()
+
+	for comments.Next() {
+		var c models.ApprovalComment
+
+		if err = comments.Scan(&c.ID, &c.ApprovalID, &c.Author, &c.Body, &c.CreatedAt); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		if i, ok := index[c.ApprovalID]; ok {
+			res[i].Comments = append(res[i].Comments, c)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,296 @@
+package approvals
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var (
+	at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	approvalColumns = []string{"id", "product_id", "name", "state", "submitted_by", "submitted_at", "reviewer", "decided_at"}
+	commentColumns  = []string{"id", "approval_id", "author", "body", "created_at"}
+)
+
+// revision is the row returned when a revision of a product is recorded.
+func revision() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(1, []byte(`{}`), at)
+}
+
+func Test_GetPending(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Approval
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Approval{
+				{ID: 1, ProductID: "1", ProductName: "kettle", State: "pending", SubmittedBy: "u1", SubmittedAt: at,
+					Comments: []models.ApprovalComment{{ID: 1, ApprovalID: 1, Author: "r1", Body: "needs a photo", CreatedAt: at}}},
+				{ID: 2, ProductID: "2", ProductName: "toaster", State: "pending", SubmittedBy: "u2", SubmittedAt: at},
+			},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM approvals a JOIN products p ON p.id = a.product_id WHERE a.state='pending' " +
+					"ORDER BY a.submitted_at, a.id").
+					WillReturnRows(sqlmock.NewRows(approvalColumns).
+						AddRow(1, "1", "kettle", "pending", "u1", at, "", nil).
+						AddRow(2, "2", "toaster", "pending", "u2", at, "", nil))
+				mock.ExpectQuery("FROM approval_comments c JOIN approvals a ON a.id = c.approval_id WHERE a.state='pending'").
+					WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(1, 1, "r1", "needs a photo", at))
+			},
+		},
+		{
+			Desc:      "Success: empty queue",
+			MockCalls: func() { mock.ExpectQuery("FROM approvals").WillReturnRows(sqlmock.NewRows(approvalColumns)) },
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls:   func() { mock.ExpectQuery("FROM approvals").WillReturnError(errors.Error("DB Error")) },
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetPending(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("WHERE a.product_id=\\$1 ORDER BY a.submitted_at, a.id").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(approvalColumns).AddRow(1, "1", "kettle", "rejected", "u1", at, "r1", at))
+	mock.ExpectQuery("FROM approval_comments c .* WHERE a.product_id=\\$1").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(commentColumns))
+
+	res, err := s.GetByProductID(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Approval{{ID: 1, ProductID: "1", ProductName: "kettle", State: "rejected", SubmittedBy: "u1",
+		SubmittedAt: at, Reviewer: "r1", DecidedAt: &at}}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Approval{ID: 1, ProductID: "1", ProductName: "kettle", State: "pending", SubmittedBy: "u1", SubmittedAt: at},
+			MockCalls: func() {
+				mock.ExpectQuery("WHERE a.id=\\$1").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows(approvalColumns).AddRow(1, "1", "kettle", "pending", "u1", at, "", nil))
+				mock.ExpectQuery("FROM approval_comments").WithArgs("1").WillReturnRows(sqlmock.NewRows(commentColumns))
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectQuery("WHERE a.id=\\$1").WithArgs("1").WillReturnRows(sqlmock.NewRows(approvalColumns))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetByID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Submit(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	transition := models.Transition{ProductID: "1", From: "draft", To: "in_review", Actor: "u1"}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Approval{ID: 3, ProductID: "1", State: "pending", SubmittedBy: "u1", SubmittedAt: at},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products SET status=\\$1, updated_by=\\$2 WHERE id=\\$3 AND status=\\$4").
+					WithArgs("in_review", "u1", "1", "draft").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at))
+				mock.ExpectQuery("INSERT INTO approvals\\(product_id, submitted_by\\)").WithArgs("1", "u1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "submitted_at"}).AddRow(3, at))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: no longer a draft",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: already pending",
+			ExpectedErr: errors.DB{Err: errors.Error("duplicate key")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at))
+				mock.ExpectQuery("INSERT INTO approvals").WillReturnError(errors.Error("duplicate key"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Submit(ctx, transition)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Decide(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	transition := models.Transition{ProductID: "1", From: "in_review", To: "active", Actor: "r1"}
+
+	testcases := []struct {
+		Desc           string
+		Comment        string
+		ExpectedResult *models.Approval
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:    "Success",
+			Comment: "looks good",
+			ExpectedResult: &models.Approval{ID: 3, ProductID: "1", State: "approved", SubmittedBy: "u1", Reviewer: "r1",
+				DecidedAt: &at, Comments: []models.ApprovalComment{{ID: 7, ApprovalID: 3, Author: "r1", Body: "looks good", CreatedAt: at}}},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("UPDATE approvals SET state=\\$1, reviewer=\\$2, decided_at=now\\(\\) WHERE id=\\$3 AND state='pending'").
+					WithArgs("approved", "r1", 3).WillReturnRows(sqlmock.NewRows([]string{"decided_at"}).AddRow(at))
+				mock.ExpectExec("UPDATE products SET status=\\$1").WithArgs("active", "r1", "1", "in_review").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at))
+				mock.ExpectQuery("INSERT INTO approval_comments").WithArgs(3, "r1", "looks good").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, at))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: decided meanwhile",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("UPDATE approvals").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: product changed meanwhile",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("UPDATE approvals").WillReturnRows(sqlmock.NewRows([]string{"decided_at"}).AddRow(at))
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		approval := &models.Approval{ID: 3, ProductID: "1", State: "approved", SubmittedBy: "u1", Reviewer: "r1"}
+
+		res, err := s.Decide(ctx, approval, transition, test.Comment)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_AddComment(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("INSERT INTO approval_comments\\(approval_id, author, body\\)").WithArgs(1, "u1", "photo added").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, at))
+	mock.ExpectQuery("INSERT INTO approval_comments").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.AddComment(ctx, &models.ApprovalComment{ApprovalID: 1, Author: "u1", Body: "photo added"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.ApprovalComment{ID: 2, ApprovalID: 1, Author: "u1", Body: "photo added", CreatedAt: at}, res)
+
+	_, err = s.AddComment(ctx, &models.ApprovalComment{ApprovalID: 1, Author: "u1", Body: "again"})
+
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type AuditStore interface {
+	GetAll(ctx *krogo.Context, params map[string]string) ([]models.AuditEntry, error)
+}
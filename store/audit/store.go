@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// DefaultLimit is how many entries are listed when no limit= is given.
+const DefaultLimit = 100
+
+const (
+	selectQuery = "SELECT id, action, entity, entity_id, product_id, COALESCE(revision_id, 0), actor, request_id, before, after, " +
+		"created_at FROM audit_log"
+	// recordQuery takes the state after the change from the revision just made, and the state before it from the
+	// revision preceding it, which there is none of for creations.
+	recordQuery = "INSERT INTO audit_log(action, entity, entity_id, product_id, revision_id, actor, request_id, before, after) " +
+		"SELECT $1, $2, $3, r.product_id, r.id, r.actor, $4, (SELECT b.snapshot FROM revisions b WHERE b.product_id=r.product_id " +
+		"AND b.variant_id=r.variant_id AND b.id<r.id ORDER BY b.id DESC LIMIT 1), r.snapshot FROM revisions r WHERE r.id=$5"
+	// trackQuery takes the product from the variant the change was made to when the change does not name it, as for
+	// stock, which is kept by variant.
+	trackQuery = "INSERT INTO audit_log(action, entity, entity_id, product_id, actor, request_id, before, after) " +
+		"SELECT $1, $2, $3, COALESCE(NULLIF($4, ''), (SELECT v.product_id FROM variants v WHERE v.id=$3)), $5, $6, $7, $8"
+)
+
+// publishedCondition leaves out the entries of products that were not published yet, which are only shown to readers
+// with a preview token for them. Entries of products that were deleted since are kept.
+const publishedCondition = "NOT EXISTS (SELECT 1 FROM products p WHERE p.id=audit_log.product_id AND p.status IN ('" +
+	models.StatusDraft + "','" + models.StatusInReview + "','" + models.StatusScheduled + "'))"
+
+// Change is a change to something that belongs to a product, such as its tags, media or stock, to be recorded in the
+// audit log. Query reads it, with Args as its placeholders, as one JSON object.
+type Change struct {
+	Entity    string
+	EntityID  string
+	ProductID string
+	Query     string
+	Args      []interface{}
+}
+
+// Rows is a Query for a Change that reads the rows of table matching where, listed under the name of the table.
+func Rows(table, where string) string {
+	return "SELECT jsonb_build_object('" + table + "', COALESCE(jsonb_agg(to_jsonb(t)), '[]'::jsonb)) FROM " + table +
+		" t WHERE " + where
+}
+
+// GetAll lists audit entries matching the filters in params, newest first, of the products anyone can read.
+func (s *Store) GetAll(ctx *krogo.Context, params map[string]string) ([]models.AuditEntry, error) {
+	where, values := whereClause(params)
+
+	limit := DefaultLimit
+	if n, err := strconv.Atoi(params["limit"]); err == nil {
+		limit = n
+	}
+
+	values = append(values, limit)
+
+	rows, err := ctx.DB().QueryContext(ctx, selectQuery+where+" ORDER BY id DESC LIMIT $"+strconv.Itoa(len(values)), values...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	var res []models.AuditEntry
+
+	for rows.Next() {
+		var (
+			e             models.AuditEntry
+			before, after []byte
+		)
+
+		err = rows.Scan(&e.ID, &e.Action, &e.Entity, &e.EntityID, &e.ProductID, &e.RevisionID, &e.Actor, &e.RequestID, &before,
+			&after, &e.CreatedAt)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		if before != nil {
+			if err = json.Unmarshal(before, &e.Before); err != nil {
+				return nil, errors.DB{Err: err}
+			}
+		}
+
+		if err = json.Unmarshal(after, &e.After); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+// Record adds an audit entry for the change of a product or variant that revision was just made for, as part of the
+// same transaction of the caller. The actor is the one of the revision and the request ID is read from the
+// X-Request-ID header, when there is a request: background work such as the scheduler has none.
+func Record(ctx *krogo.Context, tx *sql.Tx, action string, revision *models.Revision) error {
+	entity, entityID := "products", revision.ProductID
+	if revision.VariantID != "" {
+		entity, entityID = "variants", revision.VariantID
+	}
+
+	if _, err := tx.ExecContext(ctx, recordQuery, action, entity, entityID, header(ctx, models.RequestIDHeader), revision.ID); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// Track makes a change with write, as part of the transaction of the caller, and adds an audit entry for it by the
+// user making the request, with what changed as it was before and after. Nothing was there before a creation, which
+// is only read after it: write can set the ID and the Args of the change once it knows them. Errors of write are
+// returned as they are.
+func Track(ctx *krogo.Context, tx *sql.Tx, action string, change *Change, write func() error) error {
+	var (
+		before interface{}
+		err    error
+	)
+
+	if action != models.AuditCreate {
+		if before, err = snapshot(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err = write(); err != nil {
+		return err
+	}
+
+	after, err := snapshot(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, trackQuery, action, change.Entity, change.EntityID, change.ProductID, header(ctx, models.UserHeader),
+		header(ctx, models.RequestIDHeader), before, after)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// Exec runs query, which makes a change, in a transaction of its own with an audit entry for it, and returns the
+// number of rows it affected. Nothing is recorded when it affected none.
+func Exec(ctx *krogo.Context, action string, change *Change, query string, args ...interface{}) (int64, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	var n int64
+
+	err = Track(ctx, tx, action, change, func() error {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if n, err = res.RowsAffected(); err != nil {
+			return errors.DB{Err: err}
+		}
+
+		return nil
+	})
+	if err != nil || n == 0 {
+		_ = tx.Rollback()
+
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	return n, nil
+}
+
+// header reads a header of the request being served. Background work such as the scheduler runs on a context
+// without a request, which has no headers.
+func header(ctx *krogo.Context, name string) string {
+	if ctx.Request() == nil {
+		return ""
+	}
+
+	return ctx.Header(name)
+}
+
+// snapshot reads what a change is about, as JSON.
+func snapshot(ctx *krogo.Context, tx *sql.Tx, change *Change) (interface{}, error) {
+	var b []byte
+
+	if err := tx.QueryRowContext(ctx, change.Query, change.Args...).Scan(&b); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return string(b), nil
+}
+
+func whereClause(params map[string]string) (string, []interface{}) {
+	keys := make([]string, 0, len(params))
+
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	// sorted so that the placeholders are numbered the same way on every call
+	sort.Strings(keys)
+
+	var values []interface{}
+
+	conditions := []string{publishedCondition}
+
+	for _, key := range keys {
+		var condition string
+
+		switch key {
+		case "action", "entity", "entity_id", "product_id", "actor", "request_id":
+			condition = key + "=$"
+		case "from":
+			condition = "created_at>=$"
+		case "to":
+			condition = "created_at<$"
+		default:
+			continue
+		}
+
+		values = append(values, params[key])
+		conditions = append(conditions, condition+strconv.Itoa(len(values)))
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), values
+}
@@ -0,0 +1,317 @@
+package audit
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/krogertechnology/krogo/pkg/krogo/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+var columns = []string{"id", "action", "entity", "entity_id", "product_id", "revision_id", "actor", "request_id", "before", "after",
+	"created_at"}
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Params         map[string]string
+		ExpectedResult []models.AuditEntry
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success: no filters",
+			ExpectedResult: []models.AuditEntry{
+				{ID: 2, Action: models.AuditUpdate, Entity: "variants", EntityID: "2", ProductID: "1", RevisionID: 5, Actor: "u1",
+					RequestID: "r1", Before: map[string]interface{}{"price_cents": float64(199)},
+					After: map[string]interface{}{"price_cents": float64(249)}, CreatedAt: at},
+				{ID: 1, Action: models.AuditCreate, Entity: "products", EntityID: "1", ProductID: "1", RevisionID: 4, Actor: "u1",
+					After: map[string]interface{}{"name": "Milk"}, CreatedAt: at},
+			},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM audit_log WHERE NOT EXISTS \\(SELECT 1 FROM products p WHERE p.id=audit_log.product_id " +
+					"AND p.status IN \\('draft','in_review','scheduled'\\)\\) ORDER BY id DESC LIMIT \\$1").WithArgs(DefaultLimit).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, "update", "variants", "2", "1", 5, "u1", "r1", []byte(`{"price_cents":199}`),
+							[]byte(`{"price_cents":249}`), at).
+						AddRow(1, "create", "products", "1", "1", 4, "u1", "", nil, []byte(`{"name":"Milk"}`), at))
+			},
+		},
+		{
+			Desc:   "Success: filters",
+			Params: map[string]string{"actor": "u1", "from": "2023-05-01T00:00:00Z", "to": "2023-06-01T00:00:00Z", "limit": "10", "x": "y"},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM audit_log WHERE NOT EXISTS .* AND actor=\\$1 AND created_at>=\\$2 AND created_at<\\$3 "+
+					"ORDER BY id DESC LIMIT \\$4").
+					WithArgs("u1", "2023-05-01T00:00:00Z", "2023-06-01T00:00:00Z", 10).WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM audit_log").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetAll(ctx, test.Params)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Record(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	r := httptest.NewRequest(http.MethodPut, "/products/1/attributes", nil)
+	r.Header.Set(models.RequestIDHeader, "r1")
+
+	reqCtx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	reqCtx.DataStore = ctx.DataStore
+
+	testcases := []struct {
+		Desc        string
+		Ctx         *krogo.Context
+		Action      string
+		Revision    *models.Revision
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc:     "Success: product in a request",
+			Ctx:      reqCtx,
+			Action:   models.AuditUpdate,
+			Revision: &models.Revision{ID: 4, ProductID: "1", Actor: "u1"},
+			MockCalls: func() {
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "products", "1", "r1", 4).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			Desc:     "Success: variant without a request",
+			Ctx:      ctx,
+			Action:   models.AuditStatus,
+			Revision: &models.Revision{ID: 5, ProductID: "1", VariantID: "2", Actor: "scheduler"},
+			MockCalls: func() {
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("status", "variants", "2", "", 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Ctx:         ctx,
+			Action:      models.AuditCreate,
+			Revision:    &models.Revision{ID: 6, ProductID: "1"},
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		mock.ExpectBegin()
+		test.MockCalls()
+
+		tx, _ := ctx.DB().BeginTx(ctx, nil)
+		err := Record(test.Ctx, tx, test.Action, test.Revision)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Track(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/products/1/tags", nil)
+	r.Header.Set(models.UserHeader, "u1")
+	r.Header.Set(models.RequestIDHeader, "r1")
+
+	reqCtx := krogo.NewContext(nil, request.NewHTTPRequest(r), krogo.New())
+	reqCtx.DataStore = ctx.DataStore
+
+	query := Rows("product_tags", "t.product_id=$1")
+
+	testcases := []struct {
+		Desc        string
+		Action      string
+		WriteErr    error
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc:   "Success: update",
+			Action: models.AuditUpdate,
+			MockCalls: func() {
+				mock.ExpectQuery("SELECT jsonb_build_object\\('product_tags', .* FROM product_tags t WHERE t.product_id=\\$1").
+					WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("UPDATE product_tags").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[{"tag":"vegan"}]}`)))
+				mock.ExpectExec("INSERT INTO audit_log\\(action, entity, entity_id, product_id, actor, request_id, before, after\\)").
+					WithArgs("update", "tags", "1", "1", "u1", "r1", `{"product_tags":[]}`, `{"product_tags":[{"tag":"vegan"}]}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			Desc:   "Success: creation read only after it",
+			Action: models.AuditCreate,
+			MockCalls: func() {
+				mock.ExpectExec("UPDATE product_tags").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[{"tag":"vegan"}]}`)))
+				mock.ExpectExec("INSERT INTO audit_log").
+					WithArgs("create", "tags", "1", "1", "u1", "r1", nil, `{"product_tags":[{"tag":"vegan"}]}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			Desc:        "Failure: write error returned as it is",
+			Action:      models.AuditUpdate,
+			WriteErr:    errors.Error("write failed"),
+			ExpectedErr: errors.Error("write failed"),
+			MockCalls: func() {
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+			},
+		},
+		{
+			Desc:        "Failure: DB error reading before",
+			Action:      models.AuditUpdate,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM product_tags").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+		{
+			Desc:        "Failure: DB error recording",
+			Action:      models.AuditCreate,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectExec("UPDATE product_tags").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		mock.ExpectBegin()
+		test.MockCalls()
+
+		tx, _ := ctx.DB().BeginTx(ctx, nil)
+		change := &Change{Entity: "tags", EntityID: "1", ProductID: "1", Query: query, Args: []interface{}{"1"}}
+
+		err := Track(reqCtx, tx, test.Action, change, func() error {
+			if test.WriteErr != nil {
+				return test.WriteErr
+			}
+
+			_, err := tx.ExecContext(ctx, "UPDATE product_tags SET tag='vegan'")
+
+			return err
+		})
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Exec(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	change := &Change{Entity: "tags", EntityID: "1", ProductID: "1", Query: Rows("product_tags", "t.product_id=$1"),
+		Args: []interface{}{"1"}}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult int64
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: 1,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_tags").WithArgs("1", "vegan").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "tags", "1", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: no rows affected, nothing recorded",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_tags").WithArgs("1", "vegan").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_tags").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error beginning",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin().WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := Exec(ctx, models.AuditDelete, change, "DELETE FROM product_tags WHERE product_id=$1 AND tag=$2", "1", "vegan")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,12 @@
+package blob
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// BlobStore keeps uploaded files. Keys are flat file names; Put returns the URL the file is served from.
+type BlobStore interface {
+	Put(ctx *krogo.Context, key string, data []byte) (string, error)
+	Get(ctx *krogo.Context, key string) ([]byte, error)
+	Delete(ctx *krogo.Context, key string) error
+}
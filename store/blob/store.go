@@ -0,0 +1,87 @@
+package blob
+
+import (
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidKey is returned for keys that are not a plain file name, so that no key can reach outside the store.
+const ErrInvalidKey = errors.Error("invalid blob key")
+
+// Store is a BlobStore on the local filesystem. Files live directly in dir and are served under baseURL.
+type Store struct {
+	dir     string
+	baseURL string
+}
+
+func New(dir, baseURL string) *Store {
+	return &Store{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Put writes the file to a temporary name first and renames it into place, so readers never see a partial file.
+func (s *Store) Put(ctx *krogo.Context, key string, data []byte) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "."+key+".*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return "", err
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *Store) Get(ctx *krogo.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+// Delete removes a file. Deleting a file that does not exist is not an error.
+func (s *Store) Delete(ctx *krogo.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || filepath.Base(key) != key || strings.ContainsAny(key, `/\`) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.dir, key), nil
+}
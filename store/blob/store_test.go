@@ -0,0 +1,62 @@
+package blob
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_PutGetDelete(t *testing.T) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	dir := t.TempDir()
+	s := New(filepath.Join(dir, "blobs"), "/media/files/")
+
+	url, err := s.Put(ctx, "ab12.jpg", []byte("image"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/media/files/ab12.jpg", url)
+
+	data, err := s.Get(ctx, "ab12.jpg")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("image"), data)
+
+	entries, _ := os.ReadDir(filepath.Join(dir, "blobs"))
+	assert.Len(t, entries, 1, "temporary file left behind")
+
+	assert.NoError(t, s.Delete(ctx, "ab12.jpg"))
+	assert.NoError(t, s.Delete(ctx, "ab12.jpg"))
+
+	_, err = s.Get(ctx, "ab12.jpg")
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_InvalidKey(t *testing.T) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	s := New(t.TempDir(), "/media/files")
+
+	testcases := []struct {
+		Desc string
+		Key  string
+	}{
+		{Desc: "empty", Key: ""},
+		{Desc: "parent directory", Key: "../etc/passwd"},
+		{Desc: "nested", Key: "a/b.jpg"},
+		{Desc: "backslash", Key: `a\b.jpg`},
+		{Desc: "hidden", Key: ".ab12.jpg"},
+	}
+
+	for i, test := range testcases {
+		_, err := s.Put(ctx, test.Key, []byte("image"))
+		assert.Equalf(t, ErrInvalidKey, err, "TEST[%v] FAILED - %s", i, test.Desc)
+
+		_, err = s.Get(ctx, test.Key)
+		assert.Equalf(t, ErrInvalidKey, err, "TEST[%v] FAILED - %s", i, test.Desc)
+
+		err = s.Delete(ctx, test.Key)
+		assert.Equalf(t, ErrInvalidKey, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,16 @@
+package brands
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type BrandStore interface {
+	GetByID(ctx *krogo.Context, id string) (*models.Brand, error)
+	GetByName(ctx *krogo.Context, name string) (*models.Brand, error)
+	GetAll(ctx *krogo.Context) ([]models.Brand, error)
+	Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error)
+	Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error)
+	Delete(ctx *krogo.Context, id string) error
+	CountProducts(ctx *krogo.Context, id string) (int, error)
+}
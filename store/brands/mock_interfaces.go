@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package brands is a generated GoMock package.
+package brands
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockBrandStore is a mock of BrandStore interface.
+type MockBrandStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrandStoreMockRecorder
+}
+
+// MockBrandStoreMockRecorder is the mock recorder for MockBrandStore.
+type MockBrandStoreMockRecorder struct {
+	mock *MockBrandStore
+}
+
+// NewMockBrandStore creates a new mock instance.
+func NewMockBrandStore(ctrl *gomock.Controller) *MockBrandStore {
+	mock := &MockBrandStore{ctrl: ctrl}
+	mock.recorder = &MockBrandStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrandStore) EXPECT() *MockBrandStoreMockRecorder {
+	return m.recorder
+}
+
+// CountProducts mocks base method.
+func (m *MockBrandStore) CountProducts(ctx *krogo.Context, id string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProducts", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProducts indicates an expected call of CountProducts.
+func (mr *MockBrandStoreMockRecorder) CountProducts(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockBrandStore)(nil).CountProducts), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockBrandStore) Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, brand)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBrandStoreMockRecorder) Create(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBrandStore)(nil).Create), ctx, brand)
+}
+
+// Delete mocks base method.
+func (m *MockBrandStore) Delete(ctx *krogo.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBrandStoreMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBrandStore)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockBrandStore) GetAll(ctx *krogo.Context) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBrandStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBrandStore)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockBrandStore) GetByID(ctx *krogo.Context, id string) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBrandStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBrandStore)(nil).GetByID), ctx, id)
+}
+
+// GetByName mocks base method.
+func (m *MockBrandStore) GetByName(ctx *krogo.Context, name string) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockBrandStoreMockRecorder) GetByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockBrandStore)(nil).GetByName), ctx, name)
+}
+
+// Update mocks base method.
+func (m *MockBrandStore) Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, brand)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBrandStoreMockRecorder) Update(ctx, brand interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBrandStore)(nil).Update), ctx, brand)
+}
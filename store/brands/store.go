@@ -0,0 +1,131 @@
+package brands
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"strings"
+	"unicode"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// Normalize reduces a brand name to the key brands are unique on: lower case, with every run of characters
+// that are not letters or digits (symbols such as ® included) collapsed to a single space. The dedupe step
+// in migrations/000004_create_brands.up.sql applies the same rules in SQL and has to be kept in sync.
+func Normalize(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Slug derives the default ID of a brand from its name, e.g. "Ben & Jerry's" becomes "ben-jerry-s".
+func Slug(name string) string {
+	return strings.ReplaceAll(Normalize(name), " ", "-")
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, id string) (*models.Brand, error) {
+	return s.get(ctx, "SELECT id, name FROM brands WHERE id=$1", id)
+}
+
+// GetByName finds the brand whose name normalizes to the same key as name.
+func (s *Store) GetByName(ctx *krogo.Context, name string) (*models.Brand, error) {
+	return s.get(ctx, "SELECT id, name FROM brands WHERE normalized_name=$1", Normalize(name))
+}
+
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.Brand, error) {
+	query := "SELECT id, name FROM brands ORDER BY normalized_name"
+
+	var brands []models.Brand
+
+	rows, err := ctx.DB().QueryContext(ctx, query)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var b models.Brand
+
+		err = rows.Scan(&b.ID, &b.Name)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		brands = append(brands, b)
+	}
+
+	return brands, nil
+}
+
+func (s *Store) Create(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	query := "INSERT INTO brands(id, name, normalized_name) VALUES ($1,$2,$3)"
+
+	_, err := ctx.DB().ExecContext(ctx, query, brand.ID, brand.Name, Normalize(brand.Name))
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return brand, nil
+}
+
+func (s *Store) Update(ctx *krogo.Context, brand *models.Brand) (*models.Brand, error) {
+	query := "UPDATE brands SET name=$1, normalized_name=$2 WHERE id=$3"
+
+	_, err := ctx.DB().ExecContext(ctx, query, brand.Name, Normalize(brand.Name), brand.ID)
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return brand, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, id string) error {
+	_, err := ctx.DB().ExecContext(ctx, "DELETE FROM brands WHERE id=$1", id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func (s *Store) CountProducts(ctx *krogo.Context, id string) (int, error) {
+	var count int
+
+	err := ctx.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM products WHERE brand_id=$1", id).Scan(&count)
+	if err != nil {
+		return 0, errors.DB{Err: err}
+	}
+
+	return count, nil
+}
+
+func (s *Store) get(ctx *krogo.Context, query, arg string) (*models.Brand, error) {
+	var b models.Brand
+
+	err := ctx.DB().QueryRowContext(ctx, query, arg).Scan(&b.ID, &b.Name)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return &b, nil
+}
@@ -0,0 +1,144 @@
+package brands
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_Normalize(t *testing.T) {
+	testcases := []struct {
+		Name         string
+		ExpectedNorm string
+		ExpectedSlug string
+	}{
+		{Name: "Kroger", ExpectedNorm: "kroger", ExpectedSlug: "kroger"},
+		{Name: "kroger", ExpectedNorm: "kroger", ExpectedSlug: "kroger"},
+		{Name: "Kroger®", ExpectedNorm: "kroger", ExpectedSlug: "kroger"},
+		{Name: "  KROGER™ ", ExpectedNorm: "kroger", ExpectedSlug: "kroger"},
+		{Name: "Ben & Jerry's", ExpectedNorm: "ben jerry s", ExpectedSlug: "ben-jerry-s"},
+		{Name: "Café  Bustelo", ExpectedNorm: "café bustelo", ExpectedSlug: "café-bustelo"},
+		{Name: "®", ExpectedNorm: "", ExpectedSlug: ""},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.ExpectedNorm, Normalize(test.Name), "TEST[%v] FAILED - %s", i, test.Name)
+		assert.Equalf(t, test.ExpectedSlug, Slug(test.Name), "TEST[%v] FAILED - %s", i, test.Name)
+	}
+}
+
+func Test_GetByIDAndName(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("WHERE id=").WithArgs("kroger").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("kroger", "Kroger"))
+	mock.ExpectQuery("WHERE normalized_name=").WithArgs("kroger").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("kroger", "Kroger"))
+	mock.ExpectQuery("WHERE normalized_name=").WithArgs("nope").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("WHERE id=").WithArgs("kroger").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.GetByID(ctx, "kroger")
+	assert.Equal(t, &models.Brand{ID: "kroger", Name: "Kroger"}, res)
+	assert.NoError(t, err)
+
+	res, err = s.GetByName(ctx, "Kroger®")
+	assert.Equal(t, &models.Brand{ID: "kroger", Name: "Kroger"}, res)
+	assert.NoError(t, err)
+
+	res, err = s.GetByName(ctx, "Nope")
+	assert.Nil(t, res)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	res, err = s.GetByID(ctx, "kroger")
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+}
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Brand
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: []models.Brand{{ID: "kroger", Name: "Kroger"}, {ID: "simple-truth", Name: "Simple Truth"}},
+			MockCall: mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+				AddRow("kroger", "Kroger").AddRow("simple-truth", "Simple Truth")),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_CreateAndUpdate(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	brand := &models.Brand{ID: "kroger", Name: "Kroger®"}
+
+	mock.ExpectExec("INSERT INTO brands").WithArgs("kroger", "Kroger®", "kroger").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE brands").WithArgs("Kroger®", "kroger", "kroger").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO brands").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.Create(ctx, brand)
+	assert.Equal(t, brand, res)
+	assert.NoError(t, err)
+
+	res, err = s.Update(ctx, brand)
+	assert.Equal(t, brand, res)
+	assert.NoError(t, err)
+
+	res, err = s.Create(ctx, brand)
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+}
+
+func Test_DeleteAndCountProducts(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("SELECT COUNT").WithArgs("kroger").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectExec("DELETE FROM brands").WithArgs("kroger").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	count, err := s.CountProducts(ctx, "kroger")
+	assert.Equal(t, 3, count)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Delete(ctx, "kroger"))
+}
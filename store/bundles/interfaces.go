@@ -0,0 +1,11 @@
+package bundles
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type BundleStore interface {
+	Get(ctx *krogo.Context, productID string) (*models.Bundle, error)
+	Set(ctx *krogo.Context, productID string, bundle *models.Bundle) error
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package bundles is a generated GoMock package.
+package bundles
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockBundleStore is a mock of BundleStore interface.
+type MockBundleStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleStoreMockRecorder
+}
+
+// MockBundleStoreMockRecorder is the mock recorder for MockBundleStore.
+type MockBundleStoreMockRecorder struct {
+	mock *MockBundleStore
+}
+
+// NewMockBundleStore creates a new mock instance.
+func NewMockBundleStore(ctrl *gomock.Controller) *MockBundleStore {
+	mock := &MockBundleStore{ctrl: ctrl}
+	mock.recorder = &MockBundleStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleStore) EXPECT() *MockBundleStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBundleStore) Get(ctx *krogo.Context, productID string) (*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID)
+	ret0, _ := ret[0].(*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBundleStoreMockRecorder) Get(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBundleStore)(nil).Get), ctx, productID)
+}
+
+// Set mocks base method.
+func (m *MockBundleStore) Set(ctx *krogo.Context, productID string, bundle *models.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, bundle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockBundleStoreMockRecorder) Set(ctx, productID, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockBundleStore)(nil).Set), ctx, productID, bundle)
+}
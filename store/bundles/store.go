@@ -0,0 +1,110 @@
+package bundles
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// componentQuery reads the components of a bundle along with the product, name, price, unreserved stock and status
+// of each variant, in the order they were given. A variant of a product that is not active takes its product's status.
+const componentQuery = "SELECT c.variant_id, c.quantity, COALESCE(v.product_id, ''), COALESCE(v.variant_name, ''), " +
+	"COALESCE(v.price_cents, 0), COALESCE(i.on_hand - i.reserved, 0), " +
+	"COALESCE(CASE WHEN p.status <> '" + models.StatusActive + "' THEN p.status ELSE v.status END, '') FROM bundle_components c " +
+	"LEFT JOIN variants v ON v.id = c.variant_id LEFT JOIN products p ON p.id = v.product_id " +
+	"LEFT JOIN inventory i ON i.variant_id = c.variant_id WHERE c.bundle_id=$1 ORDER BY c.position"
+
+// Get returns the components and pricing of a bundle, along with the price and stock of each component.
+func (s *Store) Get(ctx *krogo.Context, productID string) (*models.Bundle, error) {
+	var b models.Bundle
+
+	err := ctx.DB().QueryRowContext(ctx, "SELECT pricing, price_cents, discount_cents FROM bundles WHERE product_id=$1", productID).
+		Scan(&b.Pricing, &b.PriceCents, &b.DiscountCents)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	rows, err := ctx.DB().QueryContext(ctx, componentQuery, productID)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.BundleComponent
+
+		if err = rows.Scan(&c.VariantID, &c.Quantity, &c.ProductID, &c.Name, &c.PriceCents, &c.Available, &c.Status); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		b.Components = append(b.Components, c)
+	}
+
+	return &b, nil
+}
+
+// Set replaces the pricing and the components of a bundle.
+func (s *Store) Set(ctx *krogo.Context, productID string, bundle *models.Bundle) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, change(productID), func() error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO bundles(product_id, pricing, price_cents, discount_cents) VALUES ($1,$2,$3,$4) "+
+			"ON CONFLICT (product_id) DO UPDATE SET pricing=EXCLUDED.pricing, price_cents=EXCLUDED.price_cents, "+
+			"discount_cents=EXCLUDED.discount_cents", productID, bundle.Pricing, bundle.PriceCents, bundle.DiscountCents)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM bundle_components WHERE bundle_id=$1", productID); err != nil {
+			return errors.DB{Err: err}
+		}
+
+		for i, c := range bundle.Components {
+			_, err := tx.ExecContext(ctx, "INSERT INTO bundle_components(bundle_id, variant_id, quantity, position) VALUES ($1,$2,$3,$4)",
+				productID, c.VariantID, c.Quantity, i)
+			if err != nil {
+				return errors.DB{Err: err}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// change is the audit record of a change to the pricing and the components of a bundle.
+func change(productID string) *audit.Change {
+	return &audit.Change{Entity: "bundles", EntityID: productID, ProductID: productID,
+		Query: "SELECT jsonb_build_object('bundles', (SELECT COALESCE(jsonb_agg(to_jsonb(b)), '[]'::jsonb) FROM bundles b " +
+			"WHERE b.product_id=$1), 'bundle_components', (SELECT COALESCE(jsonb_agg(to_jsonb(c)), '[]'::jsonb) " +
+			"FROM bundle_components c WHERE c.bundle_id=$1))",
+		Args: []interface{}{productID}}
+}
@@ -0,0 +1,155 @@
+package bundles
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_Get(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"variant_id", "quantity", "product_id", "name", "price_cents", "available", "status"}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Bundle
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Bundle{
+				Components: []models.BundleComponent{
+					{VariantID: "k-1", Quantity: 1, ProductID: "k", Name: "kettle", PriceCents: 2999, Available: 4, Status: "active"},
+					{VariantID: "m-1", Quantity: 2, ProductID: "m", Name: "mug", PriceCents: 799, Available: 5, Status: "discontinued"},
+				},
+				Pricing:       models.BundlePricingSum,
+				DiscountCents: 500,
+			},
+			MockCalls: func() {
+				mock.ExpectQuery("SELECT pricing, price_cents, discount_cents FROM bundles WHERE product_id=\\$1").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"pricing", "price_cents", "discount_cents"}).AddRow("sum", 0, 500))
+				mock.ExpectQuery("FROM bundle_components c .* WHERE c.bundle_id=\\$1 ORDER BY c.position").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("k-1", 1, "k", "kettle", 2999, 4, "active").
+						AddRow("m-1", 2, "m", "mug", 799, 5, "discontinued"))
+			},
+		},
+		{
+			Desc:        "Failure: not a bundle yet",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectQuery("FROM bundles").WithArgs("set").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			Desc:        "Failure: DB error reading components",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM bundles").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"pricing", "price_cents", "discount_cents"}).AddRow("fixed", 4500, 0))
+				mock.ExpectQuery("FROM bundle_components").WithArgs("set").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Get(ctx, "set")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Set(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	bundle := &models.Bundle{
+		Components: []models.BundleComponent{{VariantID: "k-1", Quantity: 1}, {VariantID: "m-1", Quantity: 2}},
+		Pricing:    models.BundlePricingFixed,
+		PriceCents: 4500,
+	}
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM bundle_components").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO bundles\\(product_id, pricing, price_cents, discount_cents\\) .* ON CONFLICT").
+					WithArgs("set", "fixed", int64(4500), int64(0)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM bundle_components WHERE bundle_id=\\$1").WithArgs("set").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO bundle_components").WithArgs("set", "k-1", 1, 0).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO bundle_components").WithArgs("set", "m-1", 2, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("FROM bundle_components").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "bundles", "set", "set", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error inserting a component",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM bundle_components").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO bundles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM bundle_components").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO bundle_components").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error upserting the bundle",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM bundle_components").WithArgs("set").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO bundles").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.Set(ctx, "set", bundle)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
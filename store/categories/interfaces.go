@@ -0,0 +1,20 @@
+package categories
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type CategoryStore interface {
+	GetByID(ctx *krogo.Context, id string) (*models.Category, error)
+	GetAll(ctx *krogo.Context) ([]models.Category, error)
+	Create(ctx *krogo.Context, category *models.Category) (*models.Category, error)
+	Update(ctx *krogo.Context, category *models.Category, oldPath string) (*models.Category, error)
+	Delete(ctx *krogo.Context, id string) error
+	HasChildren(ctx *krogo.Context, id string) (bool, error)
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Category, error)
+	GetLineage(ctx *krogo.Context, productID string) ([]models.Category, error)
+	SetProductCategories(ctx *krogo.Context, productID string, categoryIDs []string) error
+	GetSchema(ctx *krogo.Context, categoryIDs []string) ([]models.AttributeDefinition, error)
+	SetAttributes(ctx *krogo.Context, categoryID string, definitions []models.AttributeDefinition) error
+}
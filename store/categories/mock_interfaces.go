@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package categories is a generated GoMock package.
+package categories
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockCategoryStore is a mock of CategoryStore interface.
+type MockCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryStoreMockRecorder
+}
+
+// MockCategoryStoreMockRecorder is the mock recorder for MockCategoryStore.
+type MockCategoryStoreMockRecorder struct {
+	mock *MockCategoryStore
+}
+
+// NewMockCategoryStore creates a new mock instance.
+func NewMockCategoryStore(ctrl *gomock.Controller) *MockCategoryStore {
+	mock := &MockCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategoryStore) Create(ctx *krogo.Context, category *models.Category) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, category)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryStoreMockRecorder) Create(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryStore)(nil).Create), ctx, category)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryStore) Delete(ctx *krogo.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryStoreMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryStore)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockCategoryStore) GetAll(ctx *krogo.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCategoryStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCategoryStore)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockCategoryStore) GetByID(ctx *krogo.Context, id string) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCategoryStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategoryStore)(nil).GetByID), ctx, id)
+}
+
+// GetByProductID mocks base method.
+func (m *MockCategoryStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockCategoryStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockCategoryStore)(nil).GetByProductID), ctx, productID)
+}
+
+// GetLineage mocks base method.
+func (m *MockCategoryStore) GetLineage(ctx *krogo.Context, productID string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLineage", ctx, productID)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLineage indicates an expected call of GetLineage.
+func (mr *MockCategoryStoreMockRecorder) GetLineage(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLineage", reflect.TypeOf((*MockCategoryStore)(nil).GetLineage), ctx, productID)
+}
+
+// GetSchema mocks base method.
+func (m *MockCategoryStore) GetSchema(ctx *krogo.Context, categoryIDs []string) ([]models.AttributeDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchema", ctx, categoryIDs)
+	ret0, _ := ret[0].([]models.AttributeDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchema indicates an expected call of GetSchema.
+func (mr *MockCategoryStoreMockRecorder) GetSchema(ctx, categoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchema", reflect.TypeOf((*MockCategoryStore)(nil).GetSchema), ctx, categoryIDs)
+}
+
+// HasChildren mocks base method.
+func (m *MockCategoryStore) HasChildren(ctx *krogo.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChildren", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasChildren indicates an expected call of HasChildren.
+func (mr *MockCategoryStoreMockRecorder) HasChildren(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChildren", reflect.TypeOf((*MockCategoryStore)(nil).HasChildren), ctx, id)
+}
+
+// SetAttributes mocks base method.
+func (m *MockCategoryStore) SetAttributes(ctx *krogo.Context, categoryID string, definitions []models.AttributeDefinition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttributes", ctx, categoryID, definitions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttributes indicates an expected call of SetAttributes.
+func (mr *MockCategoryStoreMockRecorder) SetAttributes(ctx, categoryID, definitions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttributes", reflect.TypeOf((*MockCategoryStore)(nil).SetAttributes), ctx, categoryID, definitions)
+}
+
+// SetProductCategories mocks base method.
+func (m *MockCategoryStore) SetProductCategories(ctx *krogo.Context, productID string, categoryIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductCategories", ctx, productID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductCategories indicates an expected call of SetProductCategories.
+func (mr *MockCategoryStoreMockRecorder) SetProductCategories(ctx, productID, categoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductCategories", reflect.TypeOf((*MockCategoryStore)(nil).SetProductCategories), ctx, productID, categoryIDs)
+}
+
+// Update mocks base method.
+func (m *MockCategoryStore) Update(ctx *krogo.Context, category *models.Category, oldPath string) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, category, oldPath)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryStoreMockRecorder) Update(ctx, category, oldPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryStore)(nil).Update), ctx, category, oldPath)
+}
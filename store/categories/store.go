@@ -0,0 +1,301 @@
+package categories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"strconv"
+	"strings"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, id string) (*models.Category, error) {
+	query := "SELECT id, name, parent_id, path FROM categories WHERE id=$1"
+
+	var (
+		c        models.Category
+		parentID sql.NullString
+	)
+
+	err := ctx.DB().QueryRowContext(ctx, query, id).
+		Scan(&c.ID, &c.Name, &parentID, &c.Path)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	c.ParentID = parentID.String
+
+	return &c, nil
+}
+
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.Category, error) {
+	return s.query(ctx, "SELECT id, name, parent_id, path FROM categories ORDER BY path")
+}
+
+func (s *Store) Create(ctx *krogo.Context, category *models.Category) (*models.Category, error) {
+	query := "INSERT INTO categories(id, name, parent_id, path) VALUES ($1,$2,$3,$4)"
+
+	_, err := ctx.DB().ExecContext(ctx, query, category.ID, category.Name, nullable(category.ParentID), category.Path)
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return category, nil
+}
+
+// Update saves the category and, when its path changed, rewrites the paths of the whole subtree below it.
+func (s *Store) Update(ctx *krogo.Context, category *models.Category, oldPath string) (*models.Category, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE categories SET name=$1, parent_id=$2 WHERE id=$3",
+		category.Name, nullable(category.ParentID), category.ID)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if category.Path != oldPath {
+		_, err = tx.ExecContext(ctx, "UPDATE categories SET path = $1 || substr(path, $2) WHERE left(path, $3) = $4",
+			category.Path, len(oldPath)+1, len(oldPath), oldPath)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, errors.DB{Err: err}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return category, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, id string) error {
+	_, err := ctx.DB().ExecContext(ctx, "DELETE FROM categories WHERE id=$1", id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func (s *Store) HasChildren(ctx *krogo.Context, id string) (bool, error) {
+	var exists bool
+
+	err := ctx.DB().QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id=$1)", id).Scan(&exists)
+	if err != nil {
+		return false, errors.DB{Err: err}
+	}
+
+	return exists, nil
+}
+
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.Category, error) {
+	query := "SELECT c.id, c.name, c.parent_id, c.path FROM categories c " +
+		"JOIN product_categories pc ON pc.category_id = c.id WHERE pc.product_id=$1 ORDER BY c.path"
+
+	return s.query(ctx, query, productID)
+}
+
+// GetLineage returns the categories a product is in together with every category above them, each once.
+func (s *Store) GetLineage(ctx *krogo.Context, productID string) ([]models.Category, error) {
+	query := "SELECT DISTINCT anc.id, anc.name, anc.parent_id, anc.path FROM product_categories pc " +
+		"JOIN categories c ON c.id = pc.category_id JOIN categories anc ON left(c.path, length(anc.path)) = anc.path " +
+		"WHERE pc.product_id=$1 ORDER BY anc.path"
+
+	return s.query(ctx, query, productID)
+}
+
+// SetProductCategories replaces the categories a product is assigned to.
+func (s *Store) SetProductCategories(ctx *krogo.Context, productID string, categoryIDs []string) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, productCategories(productID), func() error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM product_categories WHERE product_id=$1", productID)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		for _, categoryID := range categoryIDs {
+			_, err := tx.ExecContext(ctx, "INSERT INTO product_categories(product_id, category_id) VALUES ($1,$2)", productID, categoryID)
+			if err != nil {
+				return errors.DB{Err: err}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// GetSchema returns the attribute definitions that apply to products in the given categories, including the ones
+// inherited from their ancestors. Of several definitions with the same name, the one on the deepest category wins.
+func (s *Store) GetSchema(ctx *krogo.Context, categoryIDs []string) ([]models.AttributeDefinition, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(categoryIDs))
+	values := make([]interface{}, len(categoryIDs))
+
+	for i, id := range categoryIDs {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		values[i] = id
+	}
+
+	query := "SELECT DISTINCT ON (a.name) a.category_id, a.name, a.type, a.required, a.enum_values, a.unit " +
+		"FROM categories c JOIN categories anc ON left(c.path, length(anc.path)) = anc.path " +
+		"JOIN category_attributes a ON a.category_id = anc.id WHERE c.id IN (" + strings.Join(placeholders, ",") + ") " +
+		"ORDER BY a.name, length(anc.path) DESC, a.category_id"
+
+	var definitions []models.AttributeDefinition
+
+	rows, err := ctx.DB().QueryContext(ctx, query, values...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			d          models.AttributeDefinition
+			enumValues []byte
+		)
+
+		if err = rows.Scan(&d.CategoryID, &d.Name, &d.Type, &d.Required, &enumValues, &d.Unit); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		if len(enumValues) > 0 {
+			if err = json.Unmarshal(enumValues, &d.Values); err != nil {
+				return nil, errors.DB{Err: err}
+			}
+		}
+
+		definitions = append(definitions, d)
+	}
+
+	return definitions, nil
+}
+
+// SetAttributes replaces the attribute definitions declared on a category itself.
+func (s *Store) SetAttributes(ctx *krogo.Context, categoryID string, definitions []models.AttributeDefinition) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM category_attributes WHERE category_id=$1", categoryID)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return errors.DB{Err: err}
+	}
+
+	query := "INSERT INTO category_attributes(category_id, name, type, required, enum_values, unit, position) " +
+		"VALUES ($1,$2,$3,$4,$5,$6,$7)"
+
+	for i, d := range definitions {
+		var enumValues interface{}
+
+		if len(d.Values) > 0 {
+			b, _ := json.Marshal(d.Values)
+			enumValues = string(b)
+		}
+
+		_, err = tx.ExecContext(ctx, query, categoryID, d.Name, d.Type, d.Required, enumValues, d.Unit, i)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return errors.DB{Err: err}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func (s *Store) query(ctx *krogo.Context, query string, args ...interface{}) ([]models.Category, error) {
+	var categories []models.Category
+
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			c        models.Category
+			parentID sql.NullString
+		)
+
+		err = rows.Scan(&c.ID, &c.Name, &parentID, &c.Path)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		c.ParentID = parentID.String
+		categories = append(categories, c)
+	}
+
+	return categories, nil
+}
+
+func nullable(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// productCategories is the audit record of a change to the categories a product is assigned to.
+func productCategories(productID string) *audit.Change {
+	return &audit.Change{Entity: "categories", EntityID: productID, ProductID: productID,
+		Query: audit.Rows("product_categories", "t.product_id=$1"), Args: []interface{}{productID}}
+}
@@ -0,0 +1,396 @@
+package categories
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Category
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("milk").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "parent_id", "path"}).AddRow("milk", "Milk", "dairy", "dairy/milk/")),
+		},
+		{
+			Desc:        "Failure: No rows",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("milk").WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("milk").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByID(ctx, "milk")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Category
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Category{
+				{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+				{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+			},
+			MockCall: mock.ExpectQuery("SELECT").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "parent_id", "path"}).
+					AddRow("dairy", "Dairy", nil, "dairy/").
+					AddRow("milk", "Milk", "dairy", "dairy/milk/")),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetLineage(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Category
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success: assigned categories and their ancestors",
+			ExpectedResult: []models.Category{
+				{ID: "dairy", Name: "Dairy", Path: "dairy/"},
+				{ID: "milk", Name: "Milk", ParentID: "dairy", Path: "dairy/milk/"},
+			},
+			MockCall: mock.ExpectQuery("SELECT DISTINCT anc.id.* JOIN categories anc ON left\\(c.path, length\\(anc.path\\)\\) = anc.path").
+				WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id", "path"}).
+				AddRow("dairy", "Dairy", nil, "dairy/").
+				AddRow("milk", "Milk", "dairy", "dairy/milk/")),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT DISTINCT anc.id").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetLineage(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	category := &models.Category{ID: "dairy", Name: "Dairy", Path: "dairy/"}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Category
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedExec
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: category,
+			MockCall: mock.ExpectExec("INSERT INTO categories").
+				WithArgs("dairy", "Dairy", sql.NullString{}, "dairy/").WillReturnResult(sqlmock.NewResult(1, 1)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectExec("INSERT INTO categories").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.Create(ctx, category)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Update(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Category       *models.Category
+		OldPath        string
+		ExpectedResult *models.Category
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success: rename",
+			Category:       &models.Category{ID: "milk", Name: "Milk & Cream", ParentID: "dairy", Path: "dairy/milk/"},
+			OldPath:        "dairy/milk/",
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk & Cream", ParentID: "dairy", Path: "dairy/milk/"},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE categories SET name").WithArgs("Milk & Cream", sql.NullString{String: "dairy", Valid: true}, "milk").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Success: move subtree",
+			Category:       &models.Category{ID: "milk", Name: "Milk", ParentID: "drinks", Path: "drinks/milk/"},
+			OldPath:        "dairy/milk/",
+			ExpectedResult: &models.Category{ID: "milk", Name: "Milk", ParentID: "drinks", Path: "drinks/milk/"},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE categories SET name").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE categories SET path").WithArgs("drinks/milk/", 12, 11, "dairy/milk/").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Category:    &models.Category{ID: "milk", Name: "Milk", Path: "milk/"},
+			OldPath:     "dairy/milk/",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE categories SET name").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE categories SET path").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Update(ctx, test.Category, test.OldPath)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_DeleteAndHasChildren(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("dairy").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec("DELETE FROM categories").WithArgs("dairy").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM categories").WithArgs("dairy").WillReturnError(errors.Error("DB Error"))
+
+	hasChildren, err := s.HasChildren(ctx, "dairy")
+
+	assert.True(t, hasChildren)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Delete(ctx, "dairy"))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, s.Delete(ctx, "dairy"))
+}
+
+func Test_SetProductCategories(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_categories").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_categories").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO product_categories").WithArgs("1", "milk").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO product_categories").WithArgs("1", "organic").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_categories").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "categories", "1", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_categories").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_categories").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO product_categories").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetProductCategories(ctx, "1", []string{"milk", "organic"})
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetSchema(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"category_id", "name", "type", "required", "enum_values", "unit"}
+
+	testcases := []struct {
+		Desc           string
+		CategoryIDs    []string
+		ExpectedResult []models.AttributeDefinition
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:        "Success",
+			CategoryIDs: []string{"kettles", "shirts"},
+			ExpectedResult: []models.AttributeDefinition{
+				{CategoryID: "shirts", Name: "fabric", Type: "enum", Values: []string{"cotton", "linen"}},
+				{CategoryID: "appliances", Name: "wattage", Type: "integer", Required: true, Unit: "W"},
+			},
+			MockCalls: func() {
+				mock.ExpectQuery("SELECT DISTINCT ON \\(a.name\\) .* WHERE c.id IN \\(\\$1,\\$2\\) ORDER BY a.name, length\\(anc.path\\) DESC").
+					WithArgs("kettles", "shirts").WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("shirts", "fabric", "enum", false, []byte(`["cotton","linen"]`), "").
+					AddRow("appliances", "wattage", "integer", true, nil, "W"))
+			},
+		},
+		{
+			Desc:      "Success: no categories",
+			MockCalls: func() {},
+		},
+		{
+			Desc:        "Failure: DB error",
+			CategoryIDs: []string{"kettles"},
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectQuery("SELECT DISTINCT ON").WithArgs("kettles").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetSchema(ctx, test.CategoryIDs)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_SetAttributes(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	definitions := []models.AttributeDefinition{
+		{Name: "wattage", Type: "integer", Required: true, Unit: "W"},
+		{Name: "finish", Type: "enum", Values: []string{"matte", "gloss"}},
+	}
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM category_attributes").WithArgs("appliances").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO category_attributes").WithArgs("appliances", "wattage", "integer", true, nil, "W", 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO category_attributes").
+					WithArgs("appliances", "finish", "enum", false, `["matte","gloss"]`, "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM category_attributes").WithArgs("appliances").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO category_attributes").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetAttributes(ctx, "appliances", definitions)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
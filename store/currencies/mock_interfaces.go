@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package currencies is a generated GoMock package.
+package currencies
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockCurrencyStore is a mock of CurrencyStore interface.
+type MockCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyStoreMockRecorder
+}
+
+// MockCurrencyStoreMockRecorder is the mock recorder for MockCurrencyStore.
+type MockCurrencyStoreMockRecorder struct {
+	mock *MockCurrencyStore
+}
+
+// NewMockCurrencyStore creates a new mock instance.
+func NewMockCurrencyStore(ctrl *gomock.Controller) *MockCurrencyStore {
+	mock := &MockCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyStore) EXPECT() *MockCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCurrencyStore) Delete(ctx *krogo.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCurrencyStoreMockRecorder) Delete(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCurrencyStore)(nil).Delete), ctx, code)
+}
+
+// GetAll mocks base method.
+func (m *MockCurrencyStore) GetAll(ctx *krogo.Context) ([]models.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCurrencyStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCurrencyStore)(nil).GetAll), ctx)
+}
+
+// GetByCode mocks base method.
+func (m *MockCurrencyStore) GetByCode(ctx *krogo.Context, code string) (*models.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*models.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockCurrencyStoreMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockCurrencyStore)(nil).GetByCode), ctx, code)
+}
+
+// Set mocks base method.
+func (m *MockCurrencyStore) Set(ctx *krogo.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, rate)
+	ret0, _ := ret[0].(*models.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCurrencyStoreMockRecorder) Set(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCurrencyStore)(nil).Set), ctx, rate)
+}
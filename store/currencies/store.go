@@ -0,0 +1,82 @@
+package currencies
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const selectQuery = "SELECT currency, rate, rounding, increment, updated_at FROM currency_rates "
+
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.CurrencyRate, error) {
+	var res []models.CurrencyRate
+
+	rows, err := ctx.DB().QueryContext(ctx, selectQuery+"ORDER BY currency")
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.CurrencyRate
+
+		if err = rows.Scan(&r.Currency, &r.Rate, &r.Rounding, &r.Increment, &r.UpdatedAt); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (s *Store) GetByCode(ctx *krogo.Context, code string) (*models.CurrencyRate, error) {
+	var r models.CurrencyRate
+
+	err := ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE currency=$1", code).
+		Scan(&r.Currency, &r.Rate, &r.Rounding, &r.Increment, &r.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return &r, nil
+}
+
+// Set adds the rate of a currency or replaces it; either way the rate is stamped with the time it was set.
+func (s *Store) Set(ctx *krogo.Context, rate *models.CurrencyRate) (*models.CurrencyRate, error) {
+	err := ctx.DB().QueryRowContext(ctx, "INSERT INTO currency_rates(currency, rate, rounding, increment) VALUES ($1,$2,$3,$4) "+
+		"ON CONFLICT (currency) DO UPDATE SET rate=EXCLUDED.rate, rounding=EXCLUDED.rounding, increment=EXCLUDED.increment, "+
+		"updated_at=now() RETURNING updated_at", rate.Currency, rate.Rate, rate.Rounding, rate.Increment).Scan(&rate.UpdatedAt)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return rate, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, code string) error {
+	_, err := ctx.DB().ExecContext(ctx, "DELETE FROM currency_rates WHERE currency=$1", code)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package currencies
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var (
+	columns = []string{"currency", "rate", "rounding", "increment", "updated_at"}
+	updated = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.CurrencyRate
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.CurrencyRate{
+				{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated},
+				{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5, UpdatedAt: updated},
+			},
+			MockCall: mock.ExpectQuery("FROM currency_rates ORDER BY currency").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("CAD", 1.35, "half_up", 1, updated).
+				AddRow("MXN", 17.0512, "up", 5, updated)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM currency_rates").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByCode(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.CurrencyRate
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.CurrencyRate{Currency: "CAD", Rate: 1.35, Rounding: "half_up", Increment: 1, UpdatedAt: updated},
+			MockCall: mock.ExpectQuery("FROM currency_rates WHERE currency=\\$1").WithArgs("CAD").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow("CAD", 1.35, "half_up", 1, updated)),
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("FROM currency_rates").WithArgs("CAD").WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM currency_rates").WithArgs("CAD").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByCode(ctx, "CAD")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Set(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("INSERT INTO currency_rates\\(currency, rate, rounding, increment\\) VALUES .* ON CONFLICT \\(currency\\) "+
+		"DO UPDATE .*updated_at=now\\(\\) RETURNING updated_at").WithArgs("MXN", 17.0512, "up", int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updated))
+	mock.ExpectQuery("INSERT INTO currency_rates").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.Set(ctx, &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5})
+
+	assert.Equal(t, &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5, UpdatedAt: updated}, res)
+	assert.NoError(t, err)
+
+	res, err = s.Set(ctx, &models.CurrencyRate{Currency: "MXN", Rate: 17.0512, Rounding: "up", Increment: 5})
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectExec("DELETE FROM currency_rates WHERE currency=\\$1").WithArgs("CAD").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM currency_rates").WithArgs("CAD").WillReturnError(errors.Error("DB Error"))
+
+	assert.NoError(t, s.Delete(ctx, "CAD"))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, s.Delete(ctx, "CAD"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
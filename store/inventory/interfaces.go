@@ -0,0 +1,14 @@
+package inventory
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type InventoryStore interface {
+	GetByVariantID(ctx *krogo.Context, variantID string) (*models.Inventory, error)
+	Upsert(ctx *krogo.Context, inventory *models.Inventory) (*models.Inventory, error)
+	Reserve(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error)
+	Release(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error)
+	Commit(ctx *krogo.Context, variantID, location string, quantity int) (*models.Inventory, error)
+}
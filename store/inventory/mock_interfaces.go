@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package inventory is a generated GoMock package.
+package inventory
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockInventoryStore is a mock of InventoryStore interface.
+type MockInventoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryStoreMockRecorder
+}
+
+// MockInventoryStoreMockRecorder is the mock recorder for MockInventoryStore.
+type MockInventoryStoreMockRecorder struct {
+	mock *MockInventoryStore
+}
+
+// NewMockInventoryStore creates a new mock instance.
+func NewMockInventoryStore(ctrl *gomock.Controller) *MockInventoryStore {
+	mock := &MockInventoryStore{ctrl: ctrl}
+	mock.recorder = &MockInventoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryStore) EXPECT() *MockInventoryStoreMockRecorder {
+	return m.recorder
+}
+
+// Commit mocks base method.
+func (m *MockInventoryStore) Commit(ctx *krogo.Context, variantID, location string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, variantID, location, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockInventoryStoreMockRecorder) Commit(ctx, variantID, location, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockInventoryStore)(nil).Commit), ctx, variantID, location, quantity)
+}
+
+// GetByVariantID mocks base method.
+func (m *MockInventoryStore) GetByVariantID(ctx *krogo.Context, variantID string) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVariantID", ctx, variantID)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVariantID indicates an expected call of GetByVariantID.
+func (mr *MockInventoryStoreMockRecorder) GetByVariantID(ctx, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVariantID", reflect.TypeOf((*MockInventoryStore)(nil).GetByVariantID), ctx, variantID)
+}
+
+// Release mocks base method.
+func (m *MockInventoryStore) Release(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, variantID, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockInventoryStoreMockRecorder) Release(ctx, variantID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockInventoryStore)(nil).Release), ctx, variantID, quantity)
+}
+
+// Reserve mocks base method.
+func (m *MockInventoryStore) Reserve(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, variantID, quantity)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockInventoryStoreMockRecorder) Reserve(ctx, variantID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockInventoryStore)(nil).Reserve), ctx, variantID, quantity)
+}
+
+// Upsert mocks base method.
+func (m *MockInventoryStore) Upsert(ctx *krogo.Context, inventory *models.Inventory) (*models.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, inventory)
+	ret0, _ := ret[0].(*models.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockInventoryStoreMockRecorder) Upsert(ctx, inventory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockInventoryStore)(nil).Upsert), ctx, inventory)
+}
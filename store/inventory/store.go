@@ -0,0 +1,220 @@
+package inventory
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+)
+
+// ErrInsufficientStock is returned when an adjustment would take on-hand or reserved quantities below zero.
+const ErrInsufficientStock = errors.Error("insufficient stock")
+
+// ErrStockedByLocation is returned when the on-hand quantity of a variant kept by location is set as a whole, or
+// committed without the location it ships from.
+const ErrStockedByLocation = errors.Error("stocked by location")
+
+const (
+	// StockQuery reads the stock of variant $1 for the audit log, as a whole and by location.
+	StockQuery = "SELECT jsonb_build_object('inventory', (SELECT COALESCE(jsonb_agg(to_jsonb(i)), '[]'::jsonb) FROM inventory i " +
+		"WHERE i.variant_id=$1), 'location_inventory', (SELECT COALESCE(jsonb_agg(to_jsonb(l)), '[]'::jsonb) " +
+		"FROM location_inventory l WHERE l.variant_id=$1))"
+	// byLocationQuery reports whether the stock of a variant is kept by location. Its on-hand quantity is then the sum
+	// of the ones at its locations.
+	byLocationQuery = "SELECT EXISTS (SELECT 1 FROM location_inventory WHERE variant_id=$1)"
+	// shipQuery takes a committed quantity out of the location it ships from, unless less than that is there.
+	shipQuery = "UPDATE location_inventory SET on_hand=on_hand-$1 WHERE variant_id=$2 AND location_code=$3 AND on_hand >= $1"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+func (s *Store) GetByVariantID(ctx *krogo.Context, variantID string) (*models.Inventory, error) {
+	query := "SELECT variant_id, on_hand, reserved FROM inventory WHERE variant_id=$1"
+
+	var inv models.Inventory
+
+	err := ctx.DB().QueryRowContext(ctx, query, variantID).
+		Scan(&inv.VariantID, &inv.OnHand, &inv.Reserved)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	inv.Available = inv.OnHand - inv.Reserved
+
+	return &inv, nil
+}
+
+// Upsert sets the on-hand quantity of a variant, unless its stock is kept by location.
+func (s *Store) Upsert(ctx *krogo.Context, inventory *models.Inventory) (*models.Inventory, error) {
+	query := "INSERT INTO inventory(variant_id, on_hand, reserved) VALUES ($1,$2,0) " +
+		"ON CONFLICT (variant_id) DO UPDATE SET on_hand=EXCLUDED.on_hand WHERE inventory.reserved <= EXCLUDED.on_hand " +
+		"RETURNING on_hand, reserved"
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, stockChange(inventory.VariantID), func() error {
+		err := tx.QueryRowContext(ctx, query, inventory.VariantID, inventory.OnHand).Scan(&inventory.OnHand, &inventory.Reserved)
+		if err != nil {
+			// the conditional update skipped the row, so the new on-hand quantity is below what is already reserved
+			if err == sql.ErrNoRows {
+				return ErrInsufficientStock
+			}
+
+			return errors.DB{Err: err}
+		}
+
+		// checked once the row is locked, as stock set at a location locks it first as well
+		return checkTotal(ctx, tx, inventory.VariantID)
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	inventory.Available = inventory.OnHand - inventory.Reserved
+
+	return inventory, nil
+}
+
+func (s *Store) Reserve(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, variantID, func(inv *models.Inventory) {
+		inv.Reserved += quantity
+	}, nil)
+}
+
+func (s *Store) Release(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, variantID, func(inv *models.Inventory) {
+		inv.Reserved -= quantity
+	}, nil)
+}
+
+// Commit ships a reserved quantity. The stock of a variant kept by location is taken out of the location it ships
+// from, which must then be given.
+func (s *Store) Commit(ctx *krogo.Context, variantID, location string, quantity int) (*models.Inventory, error) {
+	return s.adjust(ctx, variantID, func(inv *models.Inventory) {
+		inv.OnHand -= quantity
+		inv.Reserved -= quantity
+	}, func(tx *sql.Tx) error {
+		if location == "" {
+			return checkTotal(ctx, tx, variantID)
+		}
+
+		res, err := tx.ExecContext(ctx, shipQuery, quantity, variantID, location)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if n == 0 {
+			return ErrInsufficientStock
+		}
+
+		return nil
+	})
+}
+
+// adjust applies change to the inventory row of a variant while holding a row-level lock on it, so that
+// concurrent reservations are serialized and can never oversell the on-hand quantity. ship, when set, makes the
+// change at the locations of the variant as well, in the same transaction.
+func (s *Store) adjust(ctx *krogo.Context, variantID string, change func(inv *models.Inventory),
+	ship func(tx *sql.Tx) error) (*models.Inventory, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	var inv models.Inventory
+
+	err = tx.QueryRowContext(ctx, "SELECT variant_id, on_hand, reserved FROM inventory WHERE variant_id=$1 FOR UPDATE", variantID).
+		Scan(&inv.VariantID, &inv.OnHand, &inv.Reserved)
+	if err != nil {
+		_ = tx.Rollback()
+
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	change(&inv)
+
+	if inv.Reserved < 0 || inv.OnHand < inv.Reserved {
+		_ = tx.Rollback()
+
+		return nil, ErrInsufficientStock
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, stockChange(variantID), func() error {
+		if ship != nil {
+			if err := ship(tx); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, "UPDATE inventory SET on_hand=$1, reserved=$2 WHERE variant_id=$3", inv.OnHand, inv.Reserved, variantID)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	inv.Available = inv.OnHand - inv.Reserved
+
+	return &inv, nil
+}
+
+// checkTotal makes sure the stock of a variant is not kept by location, as the on-hand quantity of such a variant
+// only changes with the quantities at its locations.
+func checkTotal(ctx *krogo.Context, tx *sql.Tx, variantID string) error {
+	var byLocation bool
+
+	if err := tx.QueryRowContext(ctx, byLocationQuery, variantID).Scan(&byLocation); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if byLocation {
+		return ErrStockedByLocation
+	}
+
+	return nil
+}
+
+// stockChange is the audit record of a change to the stock of a variant. The audit entry finds the product of the variant
+// on its own.
+func stockChange(variantID string) *audit.Change {
+	return &audit.Change{Entity: "inventory", EntityID: variantID, Query: StockQuery, Args: []interface{}{variantID}}
+}
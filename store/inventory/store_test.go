@@ -0,0 +1,346 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByVariantID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ID             string
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ID:             "1",
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 10, Reserved: 4, Available: 6},
+			ExpectedErr:    nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows([]string{"variant_id", "on_hand", "reserved"}).AddRow("1", 10, 4)),
+		},
+		{
+			Desc:           "Failure: No rows",
+			ID:             "1",
+			ExpectedResult: nil,
+			ExpectedErr:    sql.ErrNoRows,
+			MockCall:       mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:           "Failure: DB error",
+			ID:             "1",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCall:       mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByVariantID(ctx, test.ID)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Upsert(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Inventory
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 10},
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 10, Reserved: 2, Available: 8},
+			ExpectedErr:    nil,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("INSERT INTO inventory").WithArgs("1", 10).WillReturnRows(
+					sqlmock.NewRows([]string{"on_hand", "reserved"}).AddRow(10, 2))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "inventory", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Failure: stocked by location",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 10},
+			ExpectedResult: nil,
+			ExpectedErr:    ErrStockedByLocation,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("INSERT INTO inventory").WithArgs("1", 10).WillReturnRows(
+					sqlmock.NewRows([]string{"on_hand", "reserved"}).AddRow(10, 2))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:           "Failure: below reserved",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 1},
+			ExpectedResult: nil,
+			ExpectedErr:    ErrInsufficientStock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("INSERT INTO inventory").WithArgs("1", 1).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:           "Failure: DB error",
+			Body:           &models.Inventory{VariantID: "1", OnHand: 10},
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("INSERT INTO inventory").WithArgs("1", 10).WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Upsert(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Adjust(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	lockRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"variant_id", "on_hand", "reserved"}).AddRow("1", 10, 4)
+	}
+
+	commit := func(location string) func(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+		return func(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error) {
+			return s.Commit(ctx, variantID, location, quantity)
+		}
+	}
+
+	testcases := []struct {
+		Desc           string
+		Apply          func(ctx *krogo.Context, variantID string, quantity int) (*models.Inventory, error)
+		Quantity       int
+		ExpectedResult *models.Inventory
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success: reserve",
+			Apply:          s.Reserve,
+			Quantity:       6,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 10, Reserved: 10, Available: 0},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE inventory").WithArgs(10, 10, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "inventory", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Success: release",
+			Apply:          s.Release,
+			Quantity:       4,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 10, Reserved: 0, Available: 10},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE inventory").WithArgs(10, 0, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "inventory", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Success: commit",
+			Apply:          commit(""),
+			Quantity:       3,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 7, Reserved: 1, Available: 6},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec("UPDATE inventory").WithArgs(7, 1, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "inventory", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Success: commit from a location",
+			Apply:          commit("CIN1"),
+			Quantity:       3,
+			ExpectedResult: &models.Inventory{VariantID: "1", OnHand: 7, Reserved: 1, Available: 6},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE location_inventory").WithArgs(3, "1", "CIN1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE inventory").WithArgs(7, 1, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "inventory", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: commit more than at the location",
+			Apply:       commit("CIN1"),
+			Quantity:    3,
+			ExpectedErr: ErrInsufficientStock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE location_inventory").WithArgs(3, "1", "CIN1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: commit without the location of a variant stocked by location",
+			Apply:       commit(""),
+			Quantity:    3,
+			ExpectedErr: ErrStockedByLocation,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: oversell",
+			Apply:       s.Reserve,
+			Quantity:    7,
+			ExpectedErr: ErrInsufficientStock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: release more than reserved",
+			Apply:       s.Release,
+			Quantity:    5,
+			ExpectedErr: ErrInsufficientStock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: No rows",
+			Apply:       s.Reserve,
+			Quantity:    1,
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: begin error",
+			Apply:       s.Reserve,
+			Quantity:    1,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin().WillReturnError(errors.Error("DB Error"))
+			},
+		},
+		{
+			Desc:        "Failure: update error",
+			Apply:       s.Reserve,
+			Quantity:    1,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1").WillReturnRows(lockRows())
+				mock.ExpectQuery("FROM inventory i").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE inventory").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := test.Apply(ctx, "1", test.Quantity)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,12 @@
+package lifecycle
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type LifecycleStore interface {
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Transition, error)
+	Apply(ctx *krogo.Context, transitions []models.Transition) ([]models.Transition, error)
+	ApplySchedules(ctx *krogo.Context, actor string) ([]models.Transition, error)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package lifecycle is a generated GoMock package.
+package lifecycle
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockLifecycleStore is a mock of LifecycleStore interface.
+type MockLifecycleStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLifecycleStoreMockRecorder
+}
+
+// MockLifecycleStoreMockRecorder is the mock recorder for MockLifecycleStore.
+type MockLifecycleStoreMockRecorder struct {
+	mock *MockLifecycleStore
+}
+
+// NewMockLifecycleStore creates a new mock instance.
+func NewMockLifecycleStore(ctrl *gomock.Controller) *MockLifecycleStore {
+	mock := &MockLifecycleStore{ctrl: ctrl}
+	mock.recorder = &MockLifecycleStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLifecycleStore) EXPECT() *MockLifecycleStoreMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockLifecycleStore) Apply(ctx *krogo.Context, transitions []models.Transition) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, transitions)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockLifecycleStoreMockRecorder) Apply(ctx, transitions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockLifecycleStore)(nil).Apply), ctx, transitions)
+}
+
+// ApplySchedules mocks base method.
+func (m *MockLifecycleStore) ApplySchedules(ctx *krogo.Context, actor string) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySchedules", ctx, actor)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplySchedules indicates an expected call of ApplySchedules.
+func (mr *MockLifecycleStoreMockRecorder) ApplySchedules(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySchedules", reflect.TypeOf((*MockLifecycleStore)(nil).ApplySchedules), ctx, actor)
+}
+
+// GetByProductID mocks base method.
+func (m *MockLifecycleStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockLifecycleStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockLifecycleStore)(nil).GetByProductID), ctx, productID)
+}
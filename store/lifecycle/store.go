@@ -0,0 +1,250 @@
+package lifecycle
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"practice-app/store/revisions"
+)
+
+// scheduleLock identifies the advisory lock replicas take before applying scheduled changes, so that only one of
+// them does at a time.
+const scheduleLock = 4702
+
+const (
+	// publishQuery selects the approved products whose publication time has come.
+	publishQuery = "SELECT id FROM products WHERE status='" + models.StatusScheduled + "' AND (publish_at IS NULL OR publish_at <= now()) " +
+		"ORDER BY id"
+	// unpublishQuery selects the live products whose time to be taken down has come.
+	unpublishQuery = "SELECT id FROM products WHERE status='" + models.StatusActive + "' AND unpublish_at <= now() ORDER BY id"
+	// variantsQuery selects the variants of a product in a status.
+	variantsQuery = "SELECT id FROM variants WHERE product_id=$1 AND status=$2 ORDER BY id"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// GetByProductID lists the status changes of a product and its variants, oldest first.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.Transition, error) {
+	var res []models.Transition
+
+	rows, err := ctx.DB().QueryContext(ctx, "SELECT id, product_id, COALESCE(variant_id, ''), from_status, to_status, actor, "+
+		"reason, created_at FROM status_transitions WHERE product_id=$1 ORDER BY created_at, id", productID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Transition
+
+		if err = rows.Scan(&t.ID, &t.ProductID, &t.VariantID, &t.From, &t.To, &t.Actor, &t.Reason, &t.CreatedAt); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+// Apply moves products and variants to their new status and records the transitions, all or none of them. Each
+// one only applies while its product or variant is still in the status it moves from; sql.ErrNoRows is returned
+// when another change got there first.
+func (s *Store) Apply(ctx *krogo.Context, transitions []models.Transition) ([]models.Transition, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	if err = Record(ctx, tx, transitions); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return transitions, nil
+}
+
+// Record applies and records transitions as part of a transaction of the caller, setting their ids and times, and
+// keeps a revision and an audit entry of each product and variant changed.
+// It returns sql.ErrNoRows when a product or variant is no longer in the status it moves from.
+func Record(ctx *krogo.Context, tx *sql.Tx, transitions []models.Transition) error {
+	for i := range transitions {
+		t := &transitions[i]
+
+		var (
+			res sql.Result
+			err error
+		)
+
+		if t.VariantID == "" {
+			res, err = tx.ExecContext(ctx, "UPDATE products SET status=$1, updated_by=$2 WHERE id=$3 AND status=$4", t.To, t.Actor,
+				t.ProductID, t.From)
+		} else {
+			res, err = tx.ExecContext(ctx, "UPDATE variants SET status=$1, updated_by=$2 WHERE id=$3 AND product_id=$4 AND status=$5",
+				t.To, t.Actor, t.VariantID, t.ProductID, t.From)
+		}
+
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if n, _ := res.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+
+		r := models.Revision{ProductID: t.ProductID, VariantID: t.VariantID, Actor: t.Actor}
+
+		if err = revisions.Record(ctx, tx, &r); err != nil {
+			return err
+		}
+
+		if err = audit.Record(ctx, tx, models.AuditStatus, &r); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, "INSERT INTO status_transitions(product_id, variant_id, from_status, to_status, actor, reason) "+
+			"VALUES ($1,$2,$3,$4,$5,$6) RETURNING id, created_at", t.ProductID, sql.NullString{String: t.VariantID, Valid: t.VariantID != ""},
+			t.From, t.To, t.Actor, t.Reason).Scan(&t.ID, &t.CreatedAt)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+	}
+
+	return nil
+}
+
+// ApplySchedules publishes the products whose publication time has come and discontinues, with their variants, the
+// ones due to be taken down, recording the changes under actor. Changes due for a while, such as while no replica
+// was running, are applied all the same. When another replica holds the lock nothing is done.
+func (s *Store) ApplySchedules(ctx *krogo.Context, actor string) ([]models.Transition, error) {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	var locked bool
+
+	if err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", scheduleLock).Scan(&locked); err != nil {
+		_ = tx.Rollback()
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if !locked {
+		_ = tx.Rollback()
+
+		return nil, nil
+	}
+
+	published, err := s.publish(ctx, tx, actor)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	// products published just now are taken down right away when that time has passed as well
+	withdrawn, err := s.unpublish(ctx, tx, actor)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return append(published, withdrawn...), nil
+}
+
+func (s *Store) publish(ctx *krogo.Context, tx *sql.Tx, actor string) ([]models.Transition, error) {
+	ids, err := selectIDs(ctx, tx, publishQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]models.Transition, len(ids))
+
+	for i, id := range ids {
+		transitions[i] = models.Transition{ProductID: id, From: models.StatusScheduled, To: models.StatusActive, Actor: actor,
+			Reason: "scheduled publication"}
+	}
+
+	return transitions, Record(ctx, tx, transitions)
+}
+
+func (s *Store) unpublish(ctx *krogo.Context, tx *sql.Tx, actor string) ([]models.Transition, error) {
+	ids, err := selectIDs(ctx, tx, unpublishQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []models.Transition
+
+	for _, id := range ids {
+		transitions = append(transitions, models.Transition{ProductID: id, From: models.StatusActive, To: models.StatusDiscontinued,
+			Actor: actor, Reason: "scheduled withdrawal"})
+
+		// active and draft variants are discontinued along with their product
+		for _, from := range []string{models.StatusActive, models.StatusDraft} {
+			variants, err := selectIDs(ctx, tx, variantsQuery, id, from)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, variantID := range variants {
+				transitions = append(transitions, models.Transition{ProductID: id, VariantID: variantID, From: from,
+					To: models.StatusDiscontinued, Actor: actor, Reason: "scheduled withdrawal"})
+			}
+		}
+
+		// cleared so that bringing the product back by hand does not take it down again
+		if _, err = tx.ExecContext(ctx, "UPDATE products SET unpublish_at=NULL WHERE id=$1", id); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+	}
+
+	return transitions, Record(ctx, tx, transitions)
+}
+
+func selectIDs(ctx *krogo.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	var ids []string
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
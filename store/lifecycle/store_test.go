@@ -0,0 +1,243 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// revision is the row returned when a revision of a product or variant is recorded.
+func revision() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(1, []byte(`{}`), at)
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"id", "product_id", "variant_id", "from_status", "to_status", "actor", "reason", "created_at"}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Transition{
+				{ID: 1, ProductID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled", CreatedAt: at},
+				{ID: 2, ProductID: "1", VariantID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled", CreatedAt: at},
+			},
+			MockCall: mock.ExpectQuery("FROM status_transitions WHERE product_id=\\$1 ORDER BY created_at, id").WithArgs("1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, "1", "", "active", "discontinued", "u1", "recalled", at).
+					AddRow(2, "1", "1", "active", "discontinued", "u1", "recalled", at)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM status_transitions").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	transitions := func() []models.Transition {
+		return []models.Transition{
+			{ProductID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled"},
+			{ProductID: "1", VariantID: "2", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled"},
+		}
+	}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Transition{
+				{ID: 1, ProductID: "1", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled", CreatedAt: at},
+				{ID: 2, ProductID: "1", VariantID: "2", From: "active", To: "discontinued", Actor: "u1", Reason: "recalled", CreatedAt: at},
+			},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products SET status=\\$1, updated_by=\\$2 WHERE id=\\$3 AND status=\\$4").
+					WithArgs("discontinued", "u1", "1", "active").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions\\(product_id, actor, snapshot\\)").WithArgs("1", "u1").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WithArgs("1", sql.NullString{}, "active", "discontinued", "u1", "recalled").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at))
+				mock.ExpectExec("UPDATE variants SET status=\\$1, updated_by=\\$2 WHERE id=\\$3 AND product_id=\\$4 AND status=\\$5").
+					WithArgs("discontinued", "u1", "2", "1", "active").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions\\(product_id, variant_id, actor, snapshot\\)").WithArgs("2", "1", "u1").
+					WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WithArgs("1", sql.NullString{String: "2", Valid: true}, "active", "discontinued", "u1", "recalled").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, at))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: status changed meanwhile",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Apply(ctx, transitions())
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_ApplySchedules(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Transition
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Transition{
+				{ID: 1, ProductID: "1", From: "scheduled", To: "active", Actor: "scheduler", Reason: "scheduled publication", CreatedAt: at},
+				{ID: 2, ProductID: "2", From: "active", To: "discontinued", Actor: "scheduler", Reason: "scheduled withdrawal", CreatedAt: at},
+				{ID: 3, ProductID: "2", VariantID: "5", From: "active", To: "discontinued", Actor: "scheduler",
+					Reason: "scheduled withdrawal", CreatedAt: at},
+				{ID: 4, ProductID: "2", VariantID: "6", From: "draft", To: "discontinued", Actor: "scheduler",
+					Reason: "scheduled withdrawal", CreatedAt: at},
+			},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").WithArgs(scheduleLock).
+					WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+				mock.ExpectQuery("WHERE status='scheduled' AND \\(publish_at IS NULL OR publish_at <= now\\(\\)\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+				mock.ExpectExec("UPDATE products SET status=\\$1").WithArgs("active", "scheduler", "1", "scheduled").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at))
+				mock.ExpectQuery("WHERE status='active' AND unpublish_at <= now\\(\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+				mock.ExpectQuery("SELECT id FROM variants WHERE product_id=\\$1 AND status=\\$2").WithArgs("2", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("5"))
+				mock.ExpectQuery("SELECT id FROM variants WHERE product_id=\\$1 AND status=\\$2").WithArgs("2", "draft").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("6"))
+				mock.ExpectExec("UPDATE products SET unpublish_at=NULL WHERE id=\\$1").WithArgs("2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET status=\\$1").WithArgs("discontinued", "scheduler", "2", "active").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, at))
+				mock.ExpectExec("UPDATE variants SET status=\\$1").WithArgs("discontinued", "scheduler", "5", "2", "active").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, at))
+				mock.ExpectExec("UPDATE variants SET status=\\$1").WithArgs("discontinued", "scheduler", "6", "2", "draft").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO status_transitions").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, at))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: another replica holds the lock",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_try_advisory_xact_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT pg_try_advisory_xact_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+				mock.ExpectQuery("WHERE status='scheduled'").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.ApplySchedules(ctx, "scheduler")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
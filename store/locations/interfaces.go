@@ -0,0 +1,14 @@
+package locations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type LocationStore interface {
+	GetByCode(ctx *krogo.Context, code string) (*models.Location, error)
+	GetAll(ctx *krogo.Context) ([]models.Location, error)
+	Create(ctx *krogo.Context, location *models.Location) (*models.Location, error)
+	SetStock(ctx *krogo.Context, stock *models.LocationStock) (*models.LocationStock, error)
+	GetStock(ctx *krogo.Context, variantID, code string) ([]models.LocationStock, error)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package locations is a generated GoMock package.
+package locations
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockLocationStore is a mock of LocationStore interface.
+type MockLocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationStoreMockRecorder
+}
+
+// MockLocationStoreMockRecorder is the mock recorder for MockLocationStore.
+type MockLocationStoreMockRecorder struct {
+	mock *MockLocationStore
+}
+
+// NewMockLocationStore creates a new mock instance.
+func NewMockLocationStore(ctrl *gomock.Controller) *MockLocationStore {
+	mock := &MockLocationStore{ctrl: ctrl}
+	mock.recorder = &MockLocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationStore) EXPECT() *MockLocationStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLocationStore) Create(ctx *krogo.Context, location *models.Location) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, location)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLocationStoreMockRecorder) Create(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLocationStore)(nil).Create), ctx, location)
+}
+
+// GetAll mocks base method.
+func (m *MockLocationStore) GetAll(ctx *krogo.Context) ([]models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLocationStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLocationStore)(nil).GetAll), ctx)
+}
+
+// GetByCode mocks base method.
+func (m *MockLocationStore) GetByCode(ctx *krogo.Context, code string) (*models.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*models.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockLocationStoreMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockLocationStore)(nil).GetByCode), ctx, code)
+}
+
+// GetStock mocks base method.
+func (m *MockLocationStore) GetStock(ctx *krogo.Context, variantID, code string) ([]models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, variantID, code)
+	ret0, _ := ret[0].([]models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockLocationStoreMockRecorder) GetStock(ctx, variantID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockLocationStore)(nil).GetStock), ctx, variantID, code)
+}
+
+// SetStock mocks base method.
+func (m *MockLocationStore) SetStock(ctx *krogo.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", ctx, stock)
+	ret0, _ := ret[0].(*models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockLocationStoreMockRecorder) SetStock(ctx, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockLocationStore)(nil).SetStock), ctx, stock)
+}
@@ -0,0 +1,176 @@
+package locations
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"practice-app/store/inventory"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+func (s *Store) GetByCode(ctx *krogo.Context, code string) (*models.Location, error) {
+	query := "SELECT code, name, type, latitude, longitude FROM locations WHERE code=$1"
+
+	var l models.Location
+
+	err := ctx.DB().QueryRowContext(ctx, query, code).
+		Scan(&l.Code, &l.Name, &l.Type, &l.Latitude, &l.Longitude)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return &l, nil
+}
+
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.Location, error) {
+	query := "SELECT code, name, type, latitude, longitude FROM locations ORDER BY code"
+
+	var locations []models.Location
+
+	rows, err := ctx.DB().QueryContext(ctx, query)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var l models.Location
+
+		err = rows.Scan(&l.Code, &l.Name, &l.Type, &l.Latitude, &l.Longitude)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		locations = append(locations, l)
+	}
+
+	return locations, nil
+}
+
+func (s *Store) Create(ctx *krogo.Context, location *models.Location) (*models.Location, error) {
+	query := "INSERT INTO locations(code, name, type, latitude, longitude) VALUES ($1,$2,$3,$4,$5)"
+
+	_, err := ctx.DB().ExecContext(ctx, query, location.Code, location.Name, location.Type, location.Latitude, location.Longitude)
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return location, nil
+}
+
+// SetStock sets the stock of a variant at a location, and the on-hand quantity of the variant to the sum of its stock
+// at every location. The stock is refused when that sum would fall below the reserved quantity of the variant.
+func (s *Store) SetStock(ctx *krogo.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	// the inventory row is locked before the location one, in the order the inventory store takes them on commit
+	lockQuery := "INSERT INTO inventory(variant_id, on_hand, reserved) VALUES ($1,0,0) " +
+		"ON CONFLICT (variant_id) DO UPDATE SET on_hand=inventory.on_hand"
+	query := "INSERT INTO location_inventory(variant_id, location_code, on_hand) VALUES ($1,$2,$3) " +
+		"ON CONFLICT (variant_id, location_code) DO UPDATE SET on_hand=EXCLUDED.on_hand"
+	totalQuery := "UPDATE inventory i SET on_hand=t.on_hand FROM (SELECT SUM(on_hand) AS on_hand FROM location_inventory " +
+		"WHERE variant_id=$1) t WHERE i.variant_id=$1 AND i.reserved <= t.on_hand"
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	// the product of the stock is the one of its variant, which the audit entry finds on its own
+	change := &audit.Change{Entity: "stock", EntityID: stock.VariantID, Query: inventory.StockQuery, Args: []interface{}{stock.VariantID}}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, change, func() error {
+		if _, err := tx.ExecContext(ctx, lockQuery, stock.VariantID); err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if _, err := tx.ExecContext(ctx, query, stock.VariantID, stock.Code, stock.OnHand); err != nil {
+			return errors.DB{Err: err}
+		}
+
+		res, err := tx.ExecContext(ctx, totalQuery, stock.VariantID)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		if n == 0 {
+			return inventory.ErrInsufficientStock
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return stock, nil
+}
+
+// GetStock returns the stock of a variant at every location carrying it, or only at the given location when code is set.
+func (s *Store) GetStock(ctx *krogo.Context, variantID, code string) ([]models.LocationStock, error) {
+	query := "SELECT l.code, l.name, l.type, l.latitude, l.longitude, li.variant_id, li.on_hand " +
+		"FROM location_inventory li JOIN locations l ON l.code = li.location_code WHERE li.variant_id=$1"
+	values := []interface{}{variantID}
+
+	if code != "" {
+		query += " AND li.location_code=$2"
+
+		values = append(values, code)
+	}
+
+	var stocks []models.LocationStock
+
+	rows, err := ctx.DB().QueryContext(ctx, query+" ORDER BY l.code", values...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var ls models.LocationStock
+
+		err = rows.Scan(&ls.Code, &ls.Name, &ls.Type, &ls.Latitude, &ls.Longitude, &ls.VariantID, &ls.OnHand)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		stocks = append(stocks, ls)
+	}
+
+	return stocks, nil
+}
@@ -0,0 +1,260 @@
+package locations
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"practice-app/store/inventory"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByCode(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult *models.Location
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			Code:           "CIN1",
+			ExpectedResult: &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5},
+			ExpectedErr:    nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("CIN1").WillReturnRows(
+				sqlmock.NewRows([]string{"code", "name", "type", "latitude", "longitude"}).
+					AddRow("CIN1", "Cincinnati", "store", 39.1, -84.5)),
+		},
+		{
+			Desc:           "Failure: No rows",
+			Code:           "CIN1",
+			ExpectedResult: nil,
+			ExpectedErr:    sql.ErrNoRows,
+			MockCall:       mock.ExpectQuery("SELECT").WithArgs("CIN1").WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:           "Failure: DB error",
+			Code:           "CIN1",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCall:       mock.ExpectQuery("SELECT").WithArgs("CIN1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByCode(ctx, test.Code)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Location
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: []models.Location{{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5}},
+			ExpectedErr:    nil,
+			MockCall: mock.ExpectQuery("SELECT").WillReturnRows(
+				sqlmock.NewRows([]string{"code", "name", "type", "latitude", "longitude"}).
+					AddRow("CIN1", "Cincinnati", "store", 39.1, -84.5)),
+		},
+		{
+			Desc:           "Failure: DB error",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCall:       mock.ExpectQuery("SELECT").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	location := &models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Location
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedExec
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: location,
+			ExpectedErr:    nil,
+			MockCall: mock.ExpectExec("INSERT INTO locations").WithArgs("CIN1", "Cincinnati", "store", 39.1, -84.5).
+				WillReturnResult(sqlmock.NewResult(1, 1)),
+		},
+		{
+			Desc:           "Failure: DB error",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCall:       mock.ExpectExec("INSERT INTO locations").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.Create(ctx, location)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_SetStock(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	stock := &models.LocationStock{Location: models.Location{Code: "CIN1"}, VariantID: "1", OnHand: 4}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.LocationStock
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: stock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM location_inventory l").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO inventory").WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO location_inventory").WithArgs("1", "CIN1", 4).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE inventory").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM location_inventory l").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "stock", "1", "", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: below reserved stock",
+			ExpectedErr: inventory.ErrInsufficientStock,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM location_inventory l").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO inventory").WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO location_inventory").WithArgs("1", "CIN1", 4).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE inventory").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM location_inventory l").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO inventory").WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO location_inventory").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.SetStock(ctx, stock)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetStock(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"code", "name", "type", "latitude", "longitude", "variant_id", "on_hand"}
+
+	testcases := []struct {
+		Desc           string
+		Code           string
+		ExpectedResult []models.LocationStock
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success: all locations",
+			ExpectedResult: []models.LocationStock{{
+				Location:  models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5},
+				VariantID: "1",
+				OnHand:    4,
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows(columns).AddRow("CIN1", "Cincinnati", "store", 39.1, -84.5, "1", 4)),
+		},
+		{
+			Desc: "Success: single location",
+			Code: "CIN1",
+			ExpectedResult: []models.LocationStock{{
+				Location:  models.Location{Code: "CIN1", Name: "Cincinnati", Type: "store", Latitude: 39.1, Longitude: -84.5},
+				VariantID: "1",
+				OnHand:    4,
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("location_code=\\$2").WithArgs("1", "CIN1").WillReturnRows(
+				sqlmock.NewRows(columns).AddRow("CIN1", "Cincinnati", "store", 39.1, -84.5, "1", 4)),
+		},
+		{
+			Desc:           "Failure: DB error",
+			ExpectedResult: nil,
+			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
+			MockCall:       mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetStock(ctx, "1", test.Code)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,15 @@
+package media
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type MediaStore interface {
+	GetByID(ctx *krogo.Context, productID string, id int) (*models.Media, error)
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Media, error)
+	GetByVariantID(ctx *krogo.Context, productID, variantID string) ([]models.Media, error)
+	Create(ctx *krogo.Context, media *models.Media) (*models.Media, error)
+	Update(ctx *krogo.Context, media *models.Media) (*models.Media, error)
+	Delete(ctx *krogo.Context, productID string, id int) error
+}
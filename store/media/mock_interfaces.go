@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package media is a generated GoMock package.
+package media
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockMediaStore is a mock of MediaStore interface.
+type MockMediaStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaStoreMockRecorder
+}
+
+// MockMediaStoreMockRecorder is the mock recorder for MockMediaStore.
+type MockMediaStoreMockRecorder struct {
+	mock *MockMediaStore
+}
+
+// NewMockMediaStore creates a new mock instance.
+func NewMockMediaStore(ctrl *gomock.Controller) *MockMediaStore {
+	mock := &MockMediaStore{ctrl: ctrl}
+	mock.recorder = &MockMediaStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaStore) EXPECT() *MockMediaStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMediaStore) Create(ctx *krogo.Context, media *models.Media) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, media)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaStoreMockRecorder) Create(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaStore)(nil).Create), ctx, media)
+}
+
+// Delete mocks base method.
+func (m *MockMediaStore) Delete(ctx *krogo.Context, productID string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaStoreMockRecorder) Delete(ctx, productID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaStore)(nil).Delete), ctx, productID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockMediaStore) GetByID(ctx *krogo.Context, productID string, id int) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, productID, id)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMediaStoreMockRecorder) GetByID(ctx, productID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMediaStore)(nil).GetByID), ctx, productID, id)
+}
+
+// GetByProductID mocks base method.
+func (m *MockMediaStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockMediaStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockMediaStore)(nil).GetByProductID), ctx, productID)
+}
+
+// GetByVariantID mocks base method.
+func (m *MockMediaStore) GetByVariantID(ctx *krogo.Context, productID, variantID string) ([]models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVariantID", ctx, productID, variantID)
+	ret0, _ := ret[0].([]models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVariantID indicates an expected call of GetByVariantID.
+func (mr *MockMediaStoreMockRecorder) GetByVariantID(ctx, productID, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVariantID", reflect.TypeOf((*MockMediaStore)(nil).GetByVariantID), ctx, productID, variantID)
+}
+
+// Update mocks base method.
+func (m *MockMediaStore) Update(ctx *krogo.Context, media *models.Media) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, media)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMediaStoreMockRecorder) Update(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMediaStore)(nil).Update), ctx, media)
+}
@@ -0,0 +1,169 @@
+package media
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"practice-app/store/sqlrow"
+	"strconv"
+)
+
+const selectQuery = "SELECT id, product_id, COALESCE(variant_id, ''), url, alt_text, role, width, height, position, " +
+	"COALESCE(blob_key, ''), thumbnails FROM media "
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, productID string, id int) (*models.Media, error) {
+	m, err := scan(ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE product_id=$1 AND id=$2", productID, id))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return m, nil
+}
+
+// GetByProductID returns the whole gallery of a product, the images of its variants included, in display order.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.Media, error) {
+	return s.query(ctx, selectQuery+"WHERE product_id=$1 ORDER BY position, id", productID)
+}
+
+func (s *Store) GetByVariantID(ctx *krogo.Context, productID, variantID string) ([]models.Media, error) {
+	return s.query(ctx, selectQuery+"WHERE product_id=$1 AND variant_id=$2 ORDER BY position, id", productID, variantID)
+}
+
+// Create appends an image to the end of the gallery of its product or variant.
+func (s *Store) Create(ctx *krogo.Context, media *models.Media) (*models.Media, error) {
+	query := "INSERT INTO media(product_id, variant_id, url, alt_text, role, width, height, blob_key, thumbnails, position) " +
+		"SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE(MAX(position) + 1, 0) FROM media " +
+		"WHERE product_id=$1 AND variant_id IS NOT DISTINCT FROM $2 RETURNING id, position"
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	c := change(media.ProductID, 0)
+
+	err = audit.Track(ctx, tx, models.AuditCreate, c, func() error {
+		err := tx.QueryRowContext(ctx, query, media.ProductID, nullable(media.VariantID), media.URL, media.AltText,
+			media.Role, media.Width, media.Height, nullable(media.BlobKey), thumbnails(media.Thumbnails)).
+			Scan(&media.ID, &media.Position)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		*c = *change(media.ProductID, media.ID)
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return media, nil
+}
+
+func (s *Store) Update(ctx *krogo.Context, media *models.Media) (*models.Media, error) {
+	query := "UPDATE media SET url=$1, alt_text=$2, role=$3, width=$4, height=$5, position=$6 WHERE product_id=$7 AND id=$8"
+
+	_, err := audit.Exec(ctx, models.AuditUpdate, change(media.ProductID, media.ID), query, media.URL, media.AltText, media.Role,
+		media.Width, media.Height, media.Position, media.ProductID, media.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, productID string, id int) error {
+	_, err := audit.Exec(ctx, models.AuditDelete, change(productID, id), "DELETE FROM media WHERE product_id=$1 AND id=$2", productID, id)
+
+	return err
+}
+
+// change is the audit record of a change to an image of a product.
+func change(productID string, id int) *audit.Change {
+	return &audit.Change{Entity: "media", EntityID: strconv.Itoa(id), ProductID: productID,
+		Query: audit.Rows("media", "t.product_id=$1 AND t.id=$2"), Args: []interface{}{productID, id}}
+}
+
+func (s *Store) query(ctx *krogo.Context, query string, args ...interface{}) ([]models.Media, error) {
+	var media []models.Media
+
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := scan(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		media = append(media, *m)
+	}
+
+	return media, nil
+}
+
+func scan(row sqlrow.Scanner) (*models.Media, error) {
+	var (
+		m     models.Media
+		thumb []byte
+	)
+
+	err := row.Scan(&m.ID, &m.ProductID, &m.VariantID, &m.URL, &m.AltText, &m.Role, &m.Width, &m.Height, &m.Position,
+		&m.BlobKey, &thumb)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(thumb) > 0 {
+		if err = json.Unmarshal(thumb, &m.Thumbnails); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+func thumbnails(t []models.Thumbnail) interface{} {
+	if len(t) == 0 {
+		return nil
+	}
+
+	b, _ := json.Marshal(t)
+
+	return string(b)
+}
+
+func nullable(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
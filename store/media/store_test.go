@@ -0,0 +1,289 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var columns = []string{"id", "product_id", "variant_id", "url", "alt_text", "role", "width", "height", "position", "blob_key", "thumbnails"}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Media
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Media{
+				ID: 3, ProductID: "1", URL: "url", AltText: "front", Role: "primary", Width: 800, Height: 600,
+			},
+			MockCall: mock.ExpectQuery("SELECT .* FROM media WHERE product_id=\\$1 AND id=\\$2").WithArgs("1", 3).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "1", "", "url", "front", "primary", 800, 600, 0, "", nil)),
+		},
+		{
+			Desc:        "sql no rows",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1", 3).WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "db error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1", 3).WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByID(ctx, "1", 3)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Media
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Media{
+				{ID: 3, ProductID: "1", URL: "front", Role: "primary"},
+				{ID: 4, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch", Position: 1, BlobKey: "ab12.jpg",
+					Thumbnails: []models.Thumbnail{{Width: 160, Height: 120, URL: "/media/files/ab12_w160.jpg"}}},
+			},
+			MockCall: mock.ExpectQuery("WHERE product_id=\\$1 ORDER BY position, id").WithArgs("1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(3, "1", "", "front", "", "primary", 0, 0, 0, "", nil).
+					AddRow(4, "1", "1-red", "red", "", "swatch", 0, 0, 1, "ab12.jpg",
+						[]byte(`[{"width":160,"height":120,"url":"/media/files/ab12_w160.jpg"}]`))),
+		},
+		{
+			Desc:     "Failure: No rows",
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByVariantID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("WHERE product_id=\\$1 AND variant_id=\\$2").WithArgs("1", "1-red").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "1", "1-red", "red", "", "swatch", 0, 0, 0, "", nil))
+
+	res, err := s.GetByVariantID(ctx, "1", "1-red")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Media{{ID: 4, ProductID: "1", VariantID: "1-red", URL: "red", Role: "swatch"}}, res)
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Media
+		ExpectedResult *models.Media
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			Body:           &models.Media{ProductID: "1", URL: "url", Role: "lifestyle"},
+			ExpectedResult: &models.Media{ID: 5, ProductID: "1", URL: "url", Role: "lifestyle", Position: 2},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO media").
+					WithArgs("1", sql.NullString{}, "url", "", "lifestyle", 0, 0, sql.NullString{}, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).AddRow(5, 2))
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("create", "media", "5", "1", "", "", nil, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: uploaded",
+			Body: &models.Media{ProductID: "1", URL: "/media/files/ab12.jpg", Role: "lifestyle", Width: 640, Height: 480,
+				BlobKey: "ab12.jpg", Thumbnails: []models.Thumbnail{{Width: 160, Height: 120, URL: "/media/files/ab12_w160.jpg"}}},
+			ExpectedResult: &models.Media{ID: 6, ProductID: "1", URL: "/media/files/ab12.jpg", Role: "lifestyle", Width: 640,
+				Height: 480, Position: 3, BlobKey: "ab12.jpg",
+				Thumbnails: []models.Thumbnail{{Width: 160, Height: 120, URL: "/media/files/ab12_w160.jpg"}}},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO media").
+					WithArgs("1", sql.NullString{}, "/media/files/ab12.jpg", "", "lifestyle", 640, 480,
+						sql.NullString{String: "ab12.jpg", Valid: true}, `[{"width":160,"height":120,"url":"/media/files/ab12_w160.jpg"}]`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).AddRow(6, 3))
+				mock.ExpectQuery("FROM media t").WithArgs("1", 6).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("create", "media", "6", "1", "", "", nil, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Body:        &models.Media{ProductID: "1", VariantID: "1-red", URL: "url", Role: "swatch"},
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO media").
+					WithArgs("1", sql.NullString{String: "1-red", Valid: true}, "url", "", "swatch", 0, 0, sql.NullString{}, nil).
+					WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Update(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	body := &models.Media{ID: 5, ProductID: "1", URL: "url", AltText: "side", Role: "lifestyle", Position: 1}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Media
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: body,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE media").WithArgs("url", "side", "lifestyle", 0, 0, 1, "1", 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "media", "5", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("UPDATE media").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Update(ctx, body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM media").WithArgs("1", 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "media", "5", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM media t").WithArgs("1", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM media").WithArgs("1", 5).WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.Delete(ctx, "1", 5)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,11 @@
+package options
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type OptionStore interface {
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.OptionAxis, error)
+	Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) error
+}
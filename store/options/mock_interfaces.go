@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package options is a generated GoMock package.
+package options
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockOptionStore is a mock of OptionStore interface.
+type MockOptionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOptionStoreMockRecorder
+}
+
+// MockOptionStoreMockRecorder is the mock recorder for MockOptionStore.
+type MockOptionStoreMockRecorder struct {
+	mock *MockOptionStore
+}
+
+// NewMockOptionStore creates a new mock instance.
+func NewMockOptionStore(ctrl *gomock.Controller) *MockOptionStore {
+	mock := &MockOptionStore{ctrl: ctrl}
+	mock.recorder = &MockOptionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOptionStore) EXPECT() *MockOptionStoreMockRecorder {
+	return m.recorder
+}
+
+// GetByProductID mocks base method.
+func (m *MockOptionStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.OptionAxis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.OptionAxis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockOptionStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockOptionStore)(nil).GetByProductID), ctx, productID)
+}
+
+// Set mocks base method.
+func (m *MockOptionStore) Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, axes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockOptionStoreMockRecorder) Set(ctx, productID, axes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockOptionStore)(nil).Set), ctx, productID, axes)
+}
@@ -0,0 +1,99 @@
+package options
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// GetByProductID returns the option axes of a product in their declared order.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.OptionAxis, error) {
+	query := "SELECT name, option_values FROM product_options WHERE product_id=$1 ORDER BY position"
+
+	var axes []models.OptionAxis
+
+	rows, err := ctx.DB().QueryContext(ctx, query, productID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			a      models.OptionAxis
+			values []byte
+		)
+
+		if err = rows.Scan(&a.Name, &values); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		if err = json.Unmarshal(values, &a.Values); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		axes = append(axes, a)
+	}
+
+	return axes, nil
+}
+
+// Set replaces the option axes of a product.
+func (s *Store) Set(ctx *krogo.Context, productID string, axes []models.OptionAxis) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, change(productID), func() error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM product_options WHERE product_id=$1", productID)
+		if err != nil {
+			return errors.DB{Err: err}
+		}
+
+		for i, a := range axes {
+			values, _ := json.Marshal(a.Values)
+
+			_, err := tx.ExecContext(ctx, "INSERT INTO product_options(product_id, name, position, option_values) VALUES ($1,$2,$3,$4)",
+				productID, a.Name, i, string(values))
+			if err != nil {
+				return errors.DB{Err: err}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// change is the audit record of a change to the option axes of a product.
+func change(productID string) *audit.Change {
+	return &audit.Change{Entity: "options", EntityID: productID, ProductID: productID,
+		Query: audit.Rows("product_options", "t.product_id=$1"), Args: []interface{}{productID}}
+}
@@ -0,0 +1,117 @@
+package options
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.OptionAxis
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.OptionAxis{
+				{Name: "Size", Values: []string{"S", "M"}},
+				{Name: "Color", Values: []string{"Red"}},
+			},
+			MockCall: mock.ExpectQuery("SELECT name, option_values FROM product_options").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows([]string{"name", "option_values"}).
+					AddRow("Size", []byte(`["S","M"]`)).
+					AddRow("Color", []byte(`["Red"]`))),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Set(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	axes := []models.OptionAxis{
+		{Name: "Size", Values: []string{"S", "M"}},
+		{Name: "Color", Values: []string{"Red"}},
+	}
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_options").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_options").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO product_options").WithArgs("1", "Size", 0, `["S","M"]`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO product_options").WithArgs("1", "Color", 1, `["Red"]`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_options").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "options", "1", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_options").WithArgs("1").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("DELETE FROM product_options").WithArgs("1").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.Set(ctx, "1", axes)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -3,10 +3,17 @@ package products
 import (
 	"github.com/krogertechnology/krogo/pkg/krogo"
 	"practice-app/models"
+	"time"
 )
 
 type ProductStore interface {
 	GetByID(ctx *krogo.Context, id string) (*models.ProductWithVariants, error)
+	GetByIDAsOf(ctx *krogo.Context, id string, at time.Time) (*models.ProductWithVariants, error)
 	GetAll(ctx *krogo.Context, params map[string]string) ([]models.ProductWithVariants, error)
 	Create(ctx *krogo.Context, product *models.Product) (*models.Product, error)
+	SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) error
+	SetTaxClass(ctx *krogo.Context, id, taxClass string) error
+	SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) error
+	CreatePreview(ctx *krogo.Context, token *models.PreviewToken) (*models.PreviewToken, error)
+	CheckPreview(ctx *krogo.Context, id, token string) (bool, error)
 }
@@ -7,6 +7,7 @@ package products
 import (
 	models "practice-app/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	krogo "github.com/krogertechnology/krogo/pkg/krogo"
@@ -35,6 +36,21 @@ func (m *MockProductStore) EXPECT() *MockProductStoreMockRecorder {
 	return m.recorder
 }
 
+// CheckPreview mocks base method.
+func (m *MockProductStore) CheckPreview(ctx *krogo.Context, id, token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPreview", ctx, id, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPreview indicates an expected call of CheckPreview.
+func (mr *MockProductStoreMockRecorder) CheckPreview(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPreview", reflect.TypeOf((*MockProductStore)(nil).CheckPreview), ctx, id, token)
+}
+
 // Create mocks base method.
 func (m *MockProductStore) Create(ctx *krogo.Context, product *models.Product) (*models.Product, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +66,21 @@ func (mr *MockProductStoreMockRecorder) Create(ctx, product interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductStore)(nil).Create), ctx, product)
 }
 
+// CreatePreview mocks base method.
+func (m *MockProductStore) CreatePreview(ctx *krogo.Context, token *models.PreviewToken) (*models.PreviewToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreview", ctx, token)
+	ret0, _ := ret[0].(*models.PreviewToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreview indicates an expected call of CreatePreview.
+func (mr *MockProductStoreMockRecorder) CreatePreview(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreview", reflect.TypeOf((*MockProductStore)(nil).CreatePreview), ctx, token)
+}
+
 // GetAll mocks base method.
 func (m *MockProductStore) GetAll(ctx *krogo.Context, params map[string]string) ([]models.ProductWithVariants, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +110,60 @@ func (mr *MockProductStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProductStore)(nil).GetByID), ctx, id)
 }
+
+// GetByIDAsOf mocks base method.
+func (m *MockProductStore) GetByIDAsOf(ctx *krogo.Context, id string, at time.Time) (*models.ProductWithVariants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDAsOf", ctx, id, at)
+	ret0, _ := ret[0].(*models.ProductWithVariants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDAsOf indicates an expected call of GetByIDAsOf.
+func (mr *MockProductStoreMockRecorder) GetByIDAsOf(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDAsOf", reflect.TypeOf((*MockProductStore)(nil).GetByIDAsOf), ctx, id, at)
+}
+
+// SetAttributes mocks base method.
+func (m *MockProductStore) SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttributes", ctx, id, attributes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttributes indicates an expected call of SetAttributes.
+func (mr *MockProductStoreMockRecorder) SetAttributes(ctx, id, attributes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttributes", reflect.TypeOf((*MockProductStore)(nil).SetAttributes), ctx, id, attributes)
+}
+
+// SetSchedule mocks base method.
+func (m *MockProductStore) SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", ctx, id, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockProductStoreMockRecorder) SetSchedule(ctx, id, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockProductStore)(nil).SetSchedule), ctx, id, schedule)
+}
+
+// SetTaxClass mocks base method.
+func (m *MockProductStore) SetTaxClass(ctx *krogo.Context, id, taxClass string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaxClass", ctx, id, taxClass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaxClass indicates an expected call of SetTaxClass.
+func (mr *MockProductStoreMockRecorder) SetTaxClass(ctx, id, taxClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaxClass", reflect.TypeOf((*MockProductStore)(nil).SetTaxClass), ctx, id, taxClass)
+}
@@ -2,11 +2,19 @@ package products
 
 import (
 	"database/sql"
+	"encoding/json"
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
 	"practice-app/models"
+	"practice-app/nutrition"
+	"practice-app/store/audit"
+	"practice-app/store/revisions"
 	"practice-app/store/variants"
+	"practice-app/vocabulary"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Store struct {
@@ -18,12 +26,25 @@ func New(variantStore variants.VariantStore) *Store {
 }
 
 func (s *Store) GetByID(ctx *krogo.Context, id string) (*models.ProductWithVariants, error) {
-	query := "SELECT id, name, brand_name, details, image_url FROM products where id=$1"
+	return get(ctx, selectQuery+"WHERE p.id=$1", id)
+}
+
+// GetByIDAsOf reads a product as it was at a point in time. sql.ErrNoRows is returned when it did not exist yet.
+func (s *Store) GetByIDAsOf(ctx *krogo.Context, id string, at time.Time) (*models.ProductWithVariants, error) {
+	return get(ctx, asOfQuery, id, at)
+}
 
-	var p models.ProductWithVariants
+func get(ctx *krogo.Context, query string, args ...interface{}) (*models.ProductWithVariants, error) {
+	var (
+		p          models.ProductWithVariants
+		attributes []byte
+		tagList    string
+	)
 
-	err := ctx.DB().QueryRowContext(ctx, query, id).
-		Scan(&p.ID, &p.Name, &p.BrandName, &p.Details, &p.ImageUrl)
+	err := ctx.DB().QueryRowContext(ctx, query, args...).
+		Scan(&p.ID, &p.Name, &p.BrandID, &p.BrandName, &p.Details, &p.ImageUrl, &attributes, &p.Type, &p.TaxClass,
+			&p.Status, &p.Owner, &p.PublishAt, &p.UnpublishAt, &tagList, &p.Rating.Average, &p.Rating.Count, &p.CreatedAt,
+			&p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -33,17 +54,21 @@ func (s *Store) GetByID(ctx *krogo.Context, id string) (*models.ProductWithVaria
 		return nil, errors.DB{Err: err}
 	}
 
+	if p.Attributes, err = unmarshalAttributes(attributes); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	p.Tags = vocabulary.Split(tagList)
+
 	return &p, nil
 }
 
 func (s *Store) GetAll(ctx *krogo.Context, params map[string]string) ([]models.ProductWithVariants, error) {
-	query := "SELECT id, name, brand_name, details, image_url FROM products "
-
 	whereClause, values := generateWhereClause(params)
 
 	var productArray []models.ProductWithVariants
 
-	rows, err := ctx.DB().QueryContext(ctx, query+whereClause, values...)
+	rows, err := ctx.DB().QueryContext(ctx, selectQuery+whereClause+orderClause(params["sort"]), values...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -55,14 +80,28 @@ func (s *Store) GetAll(ctx *krogo.Context, params map[string]string) ([]models.P
 
 	defer rows.Close()
 
-	for rows.Next() {
-		var p models.ProductWithVariants
+	statuses := listedStatuses(params)
 
-		err = rows.Scan(&p.ID, &p.Name, &p.BrandName, &p.Details, &p.ImageUrl)
+	for rows.Next() {
+		var (
+			p          models.ProductWithVariants
+			attributes []byte
+			tagList    string
+		)
+
+		err = rows.Scan(&p.ID, &p.Name, &p.BrandID, &p.BrandName, &p.Details, &p.ImageUrl, &attributes, &p.Type, &p.TaxClass,
+			&p.Status, &p.Owner, &p.PublishAt, &p.UnpublishAt, &tagList, &p.Rating.Average, &p.Rating.Count, &p.CreatedAt,
+			&p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy)
 		if err != nil {
 			return nil, errors.DB{Err: err}
 		}
 
+		if p.Attributes, err = unmarshalAttributes(attributes); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		p.Tags = vocabulary.Split(tagList)
+
 		var variantInfo []models.VariantInfo
 
 		vid, ok := params["vid"]
@@ -75,14 +114,33 @@ func (s *Store) GetAll(ctx *krogo.Context, params map[string]string) ([]models.P
 			}
 
 			var varInfo = models.VariantInfo{
-				ID:      variant.ID,
-				Name:    variant.Name,
-				Details: variant.Details,
+				ID:        variant.ID,
+				Name:      variant.Name,
+				Details:   variant.Details,
+				SKU:       variant.SKU,
+				GTIN:      variant.GTIN,
+				Available: variant.Available,
+				Status:    variant.Status,
+				Options:   variant.Options,
+				Tags:      variant.Tags,
+
+				PriceCents:   variant.PriceCents,
+				Measurements: variant.Measurements,
+				UnitPrice:    variant.UnitPrice,
+				Nutrition:    variant.Nutrition,
+				Allergens:    variant.Allergens,
+
+				CreatedAt: variant.CreatedAt,
+				CreatedBy: variant.CreatedBy,
+				UpdatedAt: variant.UpdatedAt,
+				UpdatedBy: variant.UpdatedBy,
 			}
 
-			variantInfo = append(variantInfo, varInfo)
+			if listed(statuses, variant.Status) {
+				variantInfo = append(variantInfo, varInfo)
+			}
 		} else {
-			variantInfo, _ = s.variantStore.GetVariantData(ctx, p.ID)
+			variantInfo, _ = s.variantStore.GetListedVariants(ctx, p.ID, statuses)
 		}
 
 		p.Variant = variantInfo
@@ -93,42 +151,391 @@ func (s *Store) GetAll(ctx *krogo.Context, params map[string]string) ([]models.P
 	return productArray, nil
 }
 
+// Create inserts a product along with the categories it is assigned to. Its image_url, when it has one, is stored as
+// the primary image of the product's media gallery.
 func (s *Store) Create(ctx *krogo.Context, product *models.Product) (*models.Product, error) {
-	query := "INSERT INTO products(id, name, brand_name, details, image_url) VALUES ($1,$2,$3,$4,$5)"
-
-	_, err := ctx.DB().ExecContext(ctx, query, product.ID, product.Name, product.BrandName, product.Details, product.ImageUrl)
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
 
+	err = tx.QueryRowContext(ctx, "INSERT INTO products(id, name, brand_id, details, attributes, type, tax_class, status, owner, "+
+		"publish_at, unpublish_at, created_by) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$9) "+
+		"RETURNING created_at, created_by, updated_at, updated_by", product.ID, product.Name, product.BrandID, product.Details,
+		marshalAttributes(product.Attributes), product.Type, product.TaxClass, product.Status, product.Owner, product.PublishAt,
+		product.UnpublishAt).Scan(&product.CreatedAt, &product.CreatedBy, &product.UpdatedAt, &product.UpdatedBy)
 	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, errors.DB{Err: err}
+	}
+
+	for _, categoryID := range product.CategoryIDs {
+		_, err = tx.ExecContext(ctx, "INSERT INTO product_categories(product_id, category_id) VALUES ($1,$2)",
+			product.ID, categoryID)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, errors.DB{Err: err}
+		}
+	}
+
+	if product.ImageUrl != "" {
+		_, err = tx.ExecContext(ctx, "INSERT INTO media(product_id, url, alt_text, role, position) VALUES ($1,$2,$3,'primary',0)",
+			product.ID, product.ImageUrl, product.Name)
+		if err != nil {
+			_ = tx.Rollback()
+
+			return nil, errors.DB{Err: err}
+		}
+	}
+
+	r := models.Revision{ProductID: product.ID, Actor: product.Owner}
+
+	if err = revisions.Record(ctx, tx, &r); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = audit.Record(ctx, tx, models.AuditCreate, &r); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return nil, errors.DB{Err: err}
 	}
 
 	return product, nil
 }
 
+// SetAttributes replaces the attribute values of a product.
+func (s *Store) SetAttributes(ctx *krogo.Context, id string, attributes map[string]interface{}) error {
+	return update(ctx, id, "UPDATE products SET updated_by=$1, attributes=$2 WHERE id=$3", marshalAttributes(attributes), id)
+}
+
+// SetTaxClass changes the tax class of a product.
+func (s *Store) SetTaxClass(ctx *krogo.Context, id, taxClass string) error {
+	return update(ctx, id, "UPDATE products SET updated_by=$1, tax_class=$2 WHERE id=$3", taxClass, id)
+}
+
+// SetSchedule changes when a product goes live and when it is taken down.
+func (s *Store) SetSchedule(ctx *krogo.Context, id string, schedule *models.Schedule) error {
+	return update(ctx, id, "UPDATE products SET updated_by=$1, publish_at=$2, unpublish_at=$3 WHERE id=$4", schedule.PublishAt,
+		schedule.UnpublishAt, id)
+}
+
+// CreatePreview stores a token letting its holder read a product before it is published, and sets when it expires.
+func (s *Store) CreatePreview(ctx *krogo.Context, token *models.PreviewToken) (*models.PreviewToken, error) {
+	err := ctx.DB().QueryRowContext(ctx, "INSERT INTO preview_tokens(token, product_id, created_by) VALUES ($1,$2,$3) "+
+		"RETURNING expires_at", token.Token, token.ProductID, token.CreatedBy).Scan(&token.ExpiresAt)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return token, nil
+}
+
+// CheckPreview reports whether a token was handed out for a product and has not expired yet.
+func (s *Store) CheckPreview(ctx *krogo.Context, id, token string) (bool, error) {
+	var ok bool
+
+	err := ctx.DB().QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM preview_tokens WHERE token=$1 AND product_id=$2 "+
+		"AND expires_at > now())", token, id).Scan(&ok)
+	if err != nil {
+		return false, errors.DB{Err: err}
+	}
+
+	return ok, nil
+}
+
+// update changes a product and records a revision of it by the user making the request, with an audit entry, in one
+// transaction. The user is passed to query as $1, ahead of args.
+func update(ctx *krogo.Context, id, query string, args ...interface{}) error {
+	actor := ctx.Header(models.UserHeader)
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if _, err = tx.ExecContext(ctx, query, append([]interface{}{actor}, args...)...); err != nil {
+		_ = tx.Rollback()
+
+		return errors.DB{Err: err}
+	}
+
+	r := models.Revision{ProductID: id, Actor: actor}
+
+	if err = revisions.Record(ctx, tx, &r); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = audit.Record(ctx, tx, models.AuditUpdate, &r); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func marshalAttributes(attributes map[string]interface{}) string {
+	if len(attributes) == 0 {
+		return "{}"
+	}
+
+	b, _ := json.Marshal(attributes)
+
+	return string(b)
+}
+
+func unmarshalAttributes(b []byte) (map[string]interface{}, error) {
+	var attributes map[string]interface{}
+
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return nil, err
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	return attributes, nil
+}
+
+// selectColumns reads products with the display name of their brand, so that renaming a brand shows up everywhere.
+// image_url is kept for older clients and computed from the gallery by imageQuery.
+var selectColumns = "SELECT p.id, p.name, COALESCE(p.brand_id, ''), COALESCE(b.name, ''), p.details, " +
+	"COALESCE((" + imageQuery + "), ''), p.attributes, p.type, p.tax_class, p.status, p.owner, p.publish_at, p.unpublish_at, " +
+	vocabulary.Aggregate("p.id", "''") + ", p.rating_average, p.rating_count, p.created_at, p.created_by, p.updated_at, p.updated_by "
+
+var selectQuery = selectColumns + "FROM products p LEFT JOIN brands b ON b.id = p.brand_id "
+
+// asOfQuery reads a product from its latest revision made up to a point in time. Galleries and tags are not
+// versioned, so they are read as they are now. Revisions made before products kept their times and actors take them
+// from the revision itself.
+var asOfQuery = selectColumns + "FROM (SELECT (jsonb_populate_record(NULL::products, " +
+	"jsonb_build_object('created_at', r.created_at, 'created_by', '', 'updated_at', r.created_at, 'updated_by', r.actor) " +
+	"|| r.snapshot)).* FROM revisions r " +
+	"WHERE r.product_id=$1 AND r.variant_id='' AND r.created_at <= $2 ORDER BY r.id DESC LIMIT 1) p " +
+	"LEFT JOIN brands b ON b.id = p.brand_id"
+
+const (
+	// imageQuery selects the primary image of a product, falling back to the first image of its gallery.
+	imageQuery = "SELECT m.url FROM media m WHERE m.product_id = p.id AND m.variant_id IS NULL " +
+		"ORDER BY m.role = 'primary' DESC, m.position, m.id LIMIT 1"
+	// inStockQuery selects the products having at least one active variant with unreserved stock.
+	inStockQuery = "SELECT v.product_id FROM variants v JOIN inventory i ON i.variant_id = v.id " +
+		"WHERE v.status='" + models.StatusActive + "' AND i.on_hand > i.reserved"
+	// locationQuery selects the products having at least one active variant in stock at a given location.
+	locationQuery = "SELECT v.product_id FROM variants v JOIN location_inventory li ON li.variant_id = v.id " +
+		"WHERE v.status='" + models.StatusActive + "' AND li.on_hand > 0 AND li.location_code=$"
+	// categoryQuery selects the products assigned to a given category or to any category below it.
+	categoryQuery = "SELECT pc.product_id FROM product_categories pc JOIN categories c ON c.id = pc.category_id " +
+		"JOIN categories root ON left(c.path, length(root.path)) = root.path WHERE root.id=$"
+	// allergenQuery selects the products having a variant that contains one of the allergens that follow.
+	allergenQuery = "SELECT v.product_id FROM variants v WHERE "
+	// taggedQuery selects the products carrying a tag, themselves or on one of their variants.
+	taggedQuery = "SELECT pt.product_id FROM product_tags pt WHERE pt.tag "
+)
+
+// publishedCondition leaves out the products that were never published; listings do not show them in any case.
+const publishedCondition = "p.status NOT IN ('" + models.StatusDraft + "','" + models.StatusInReview + "','" +
+	models.StatusScheduled + "')"
+
+// attributePrefix marks the listing parameters that filter on a product attribute.
+const attributePrefix = "attr."
+
 func generateWhereClause(params map[string]string) (string, []interface{}) {
-	clause := "WHERE "
-	i := 1
-	and := ""
+	keys := make([]string, 0, len(params))
+
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	// sorted so that the placeholders are numbered the same way on every call
+	sort.Strings(keys)
+
+	var (
+		conditions []string
+		values     []interface{}
+	)
 
-	var values []interface{}
+	for _, key := range keys {
+		value := params[key]
 
-	for key, value := range params {
-		if key != "vid" {
+		switch key {
+		case "pid":
 			values = append(values, value)
-			column := ""
-			if key == "pid" {
-				column = "id"
+			conditions = append(conditions, "p.id=$"+strconv.Itoa(len(values)))
+		case "name":
+			values = append(values, value)
+			conditions = append(conditions, "p.name=$"+strconv.Itoa(len(values)))
+		case "in_stock":
+			if inStock, _ := strconv.ParseBool(value); inStock {
+				conditions = append(conditions, "p.id IN ("+inStockQuery+")")
+			} else {
+				conditions = append(conditions, "p.id NOT IN ("+inStockQuery+")")
 			}
-			if key == "name" {
-				column = "name"
+		case "brand":
+			values = append(values, value)
+			conditions = append(conditions, "p.brand_id=$"+strconv.Itoa(len(values)))
+		case "updated_since":
+			// a change to any variant counts as a change to the product listing it
+			values = append(values, value)
+			n := strconv.Itoa(len(values))
+			conditions = append(conditions, "(p.updated_at>=$"+n+" OR p.id IN (SELECT v.product_id FROM variants v "+
+				"WHERE v.updated_at>=$"+n+"))")
+		case "category":
+			values = append(values, value)
+			conditions = append(conditions, "p.id IN ("+categoryQuery+strconv.Itoa(len(values))+")")
+		case "location":
+			values = append(values, value)
+			conditions = append(conditions, "p.id IN ("+locationQuery+strconv.Itoa(len(values))+")")
+		case "exclude_allergens":
+			var allergenConditions []string
+
+			for _, name := range strings.Split(value, ",") {
+				if allergen, ok := nutrition.NormalizeAllergen(name); ok {
+					values = append(values, allergen)
+					allergenConditions = append(allergenConditions, "v.allergens ? $"+strconv.Itoa(len(values)))
+				}
+			}
+
+			if len(allergenConditions) > 0 {
+				conditions = append(conditions, "p.id NOT IN ("+allergenQuery+strings.Join(allergenConditions, " OR ")+")")
 			}
-			if i > 1 {
-				and = " AND "
+		case "status":
+			// status=all lists products in every status they can be published in
+			if value == "all" {
+				conditions = append(conditions, publishedCondition)
+
+				continue
+			}
+
+			var placeholders []string
+
+			for _, status := range strings.Split(value, ",") {
+				values = append(values, strings.TrimSpace(status))
+				placeholders = append(placeholders, "$"+strconv.Itoa(len(values)))
+			}
+
+			conditions = append(conditions, "p.status IN ("+strings.Join(placeholders, ",")+")")
+		case "min_rating":
+			values = append(values, value)
+			conditions = append(conditions, "p.rating_average>=$"+strconv.Itoa(len(values)))
+		case "tags":
+			var tagConditions []string
+
+			tagConditions, values = tagFilter(value, params["tags_match"], values)
+			conditions = append(conditions, tagConditions...)
+		default:
+			// attr.<name>=<value> matches products whose attribute has that value, compared in its text form
+			if name := strings.TrimPrefix(key, attributePrefix); name != key && name != "" {
+				values = append(values, name, value)
+				conditions = append(conditions,
+					"p.attributes->>$"+strconv.Itoa(len(values)-1)+"=$"+strconv.Itoa(len(values)))
 			}
-			clause += and + column + "=$" + strconv.Itoa(i)
-			i++
 		}
 	}
 
-	return clause, values
+	// listings only show active products unless asked for other statuses
+	if _, ok := params["status"]; !ok {
+		conditions = append(conditions, "p.status='"+models.StatusActive+"'")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), values
+}
+
+// listedStatuses are the statuses of the variants listed with products. Like the products themselves, they are the
+// ones asked for with status=, or only active ones by default.
+func listedStatuses(params map[string]string) []string {
+	value, ok := params["status"]
+
+	switch {
+	case !ok:
+		return []string{models.StatusActive}
+	case value == "all":
+		return []string{models.StatusActive, models.StatusDiscontinued, models.StatusArchived}
+	}
+
+	var statuses []string
+
+	for _, status := range strings.Split(value, ",") {
+		statuses = append(statuses, strings.TrimSpace(status))
+	}
+
+	return statuses
+}
+
+func listed(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderClause sorts listings by rating, highest first for sort=rating and lowest first for sort=rating_asc.
+// Products with more reviews come first among equal averages. sort=newest lists the latest created first. Other
+// listings keep the database's order.
+func orderClause(sort string) string {
+	switch sort {
+	case "newest":
+		return " ORDER BY p.created_at DESC, p.id"
+	case "rating":
+		return " ORDER BY p.rating_average DESC, p.rating_count DESC, p.id"
+	case "rating_asc":
+		return " ORDER BY p.rating_average, p.rating_count DESC, p.id"
+	}
+
+	return ""
+}
+
+// tagFilter turns tags=a,b into conditions matching the products that carry every listed tag, or at least one
+// of them when match is "any".
+func tagFilter(value, match string, values []interface{}) ([]string, []interface{}) {
+	var placeholders []string
+
+	for _, tag := range strings.Split(value, ",") {
+		if tag = vocabulary.Normalize(tag); tag != "" {
+			values = append(values, tag)
+			placeholders = append(placeholders, "$"+strconv.Itoa(len(values)))
+		}
+	}
+
+	if len(placeholders) == 0 {
+		return nil, values
+	}
+
+	if match == "any" {
+		return []string{"p.id IN (" + taggedQuery + "IN (" + strings.Join(placeholders, ",") + "))"}, values
+	}
+
+	conditions := make([]string, len(placeholders))
+
+	for i, placeholder := range placeholders {
+		conditions[i] = "p.id IN (" + taggedQuery + "= " + placeholder + ")"
+	}
+
+	return conditions, values
 }
@@ -12,6 +12,7 @@ import (
 	"practice-app/models"
 	"practice-app/store/variants"
 	"testing"
+	"time"
 )
 
 func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
@@ -28,6 +29,13 @@ func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
 	return ctx, mock
 }
 
+// columns are the columns read by selectQuery.
+var columns = []string{"id", "name", "brand_id", "brand_name", "details", "image_url", "attributes", "type", "tax_class",
+	"status", "owner", "publish_at", "unpublish_at", "tags", "rating_average", "rating_count", "created_at", "created_by", "updated_at",
+	"updated_by"}
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
 func Test_GetByID(t *testing.T) {
 	ctx, mock := getSqlMock(t)
 
@@ -48,14 +56,37 @@ func Test_GetByID(t *testing.T) {
 			ExpectedResult: &models.ProductWithVariants{
 				ID:        "1",
 				Name:      "product_1",
+				BrandID:   "b1",
 				BrandName: "brand_1",
 				Details:   "details",
 				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
 			},
 			ExpectedErr: nil,
 			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(
-				sqlmock.NewRows([]string{"id", "name", "brand_name", "details", "image_url"}).
-					AddRow("1", "product_1", "brand_1", "details", "url")),
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+		},
+		{
+			Desc: "Success: with attributes",
+			ID:   "1",
+			ExpectedResult: &models.ProductWithVariants{
+				ID:         "1",
+				Name:       "product_1",
+				BrandID:    "b1",
+				BrandName:  "brand_1",
+				Details:    "details",
+				ImageUrl:   "url",
+				Type:       "standard",
+				TaxClass:   "standard",
+				Status:     "active",
+				Attributes: map[string]interface{}{"wattage": float64(1500), "cordless": true},
+			},
+			MockCall: mock.ExpectQuery("SELECT .*, p.attributes, p.type, .* FROM products p").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte(`{"wattage":1500,"cordless":true}`), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
 		},
 		{
 			Desc:           "Failure: No rows",
@@ -102,16 +133,20 @@ func Test_GetAll(t *testing.T) {
 			ExpectedResult: []models.ProductWithVariants{{
 				ID:        "1",
 				Name:      "product_1",
+				BrandID:   "b1",
 				BrandName: "brand_1",
 				Details:   "details",
 				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
 			}},
 			ExpectedErr: nil,
-			MockCall: mock.ExpectQuery("SELECT").WithArgs().WillReturnRows(
-				sqlmock.NewRows([]string{"id", "name", "brand_name", "details", "image_url"}).
-					AddRow("1", "product_1", "brand_1", "details", "url")),
+			MockCall: mock.ExpectQuery("SELECT p.id, .* FROM products p LEFT JOIN brands b ON b.id = p.brand_id WHERE p.id=\\$1 AND p.status='active'$").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
 			Calls: []*gomock.Call{
-				mockVariantStore.EXPECT().GetVariantData(gomock.Any(), "1").Return(nil, nil),
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
 			},
 		},
 		{
@@ -120,27 +155,342 @@ func Test_GetAll(t *testing.T) {
 			ExpectedResult: []models.ProductWithVariants{{
 				ID:        "1",
 				Name:      "product_1",
+				BrandID:   "b1",
 				BrandName: "brand_1",
 				Details:   "details",
 				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
 				Variant: []models.VariantInfo{{
 					ID:      "1",
 					Name:    "variant_1",
 					Details: "details",
+					Status:  "active",
 				}},
 			}},
 			ExpectedErr: nil,
-			MockCall: mock.ExpectQuery("SELECT").WithArgs("1", "product_1").WillReturnRows(
-				sqlmock.NewRows([]string{"id", "name", "brand_name", "details", "image_url"}).
-					AddRow("1", "product_1", "brand_1", "details", "url")),
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("product_1", "1").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
 			Calls: []*gomock.Call{
 				mockVariantStore.EXPECT().GetByID(gomock.Any(), "1", "1").Return(&models.Variant{
 					ID:      "1",
 					Name:    "variant_1",
 					Details: "details",
+					Status:  "active",
 				}, nil),
 			},
 		},
+		{
+			Desc:   "Success: archived variant left out",
+			Params: map[string]string{"pid": "1", "vid": "1"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetByID(gomock.Any(), "1", "1").Return(&models.Variant{ID: "1", Status: "archived"}, nil),
+			},
+		},
+		{
+			Desc:   "Success: in stock filter",
+			Params: map[string]string{"pid": "1", "in_stock": "true"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+				Variant: []models.VariantInfo{{
+					ID:        "1",
+					Name:      "variant_1",
+					Details:   "details",
+					Available: 3,
+				}},
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id IN \\(SELECT v.product_id .* AND p.id=\\$1").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return([]models.VariantInfo{{
+					ID:        "1",
+					Name:      "variant_1",
+					Details:   "details",
+					Available: 3,
+				}}, nil),
+			},
+		},
+		{
+			Desc:   "Success: location filter",
+			Params: map[string]string{"pid": "1", "location": "CIN1"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id IN \\(SELECT .* li.location_code=\\$1\\) AND p.id=\\$2").WithArgs("CIN1", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: excluding allergens",
+			Params: map[string]string{"pid": "1", "exclude_allergens": "Peanuts,milk"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id NOT IN \\(SELECT v.product_id FROM variants v WHERE v.allergens \\? \\$1 OR v.allergens \\? \\$2\\) "+
+				"AND p.id=\\$3").WithArgs("peanut", "milk", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: category filter",
+			Params: map[string]string{"pid": "1", "category": "dairy"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id IN \\(SELECT .* root.id=\\$1\\) AND p.id=\\$2").WithArgs("dairy", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: brand filter",
+			Params: map[string]string{"pid": "1", "brand": "b1"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.brand_id=\\$1 AND p.id=\\$2").WithArgs("b1", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: attribute filter",
+			Params: map[string]string{"pid": "1", "attr.fabric": "cotton", "attr.wattage": "1500"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.attributes->>\\$1=\\$2 AND p.attributes->>\\$3=\\$4 AND p.id=\\$5 AND p.status='active'$").
+				WithArgs("fabric", "cotton", "wattage", "1500", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: all of the tags",
+			Params: map[string]string{"pid": "1", "tags": "Organic, vegan"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+				Tags:      []string{"organic", "vegan"},
+			}},
+			MockCall: mock.ExpectQuery("WHERE p.id=\\$1 AND p.id IN \\(SELECT pt.product_id FROM product_tags pt WHERE pt.tag = \\$2\\) "+
+				"AND p.id IN \\(SELECT pt.product_id FROM product_tags pt WHERE pt.tag = \\$3\\) AND p.status='active'$").
+				WithArgs("1", "organic", "vegan").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "organic,vegan", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: any of the tags",
+			Params: map[string]string{"pid": "1", "tags": "organic,Gluten Free", "tags_match": "any"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+				Tags:      []string{"organic"},
+			}},
+			MockCall: mock.ExpectQuery("WHERE p.id=\\$1 AND p.id IN \\(SELECT pt.product_id FROM product_tags pt WHERE pt.tag IN \\(\\$2,\\$3\\)\\) AND p.status='active'$").
+				WithArgs("1", "organic", "gluten-free").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "organic", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: minimum rating, best rated first",
+			Params: map[string]string{"pid": "1", "min_rating": "4", "sort": "rating"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+				Rating:    models.Rating{Average: 4.5, Count: 12},
+			}},
+			MockCall: mock.ExpectQuery("WHERE p.rating_average>=\\$1 AND p.id=\\$2 AND p.status='active' ORDER BY p.rating_average DESC, p.rating_count DESC, p.id$").
+				WithArgs("4", "1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", "4.50", 12, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: status filter",
+			Params: map[string]string{"pid": "1", "status": "draft,discontinued"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "draft",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id=\\$1 AND p.status IN \\(\\$2,\\$3\\)$").WithArgs("1", "draft", "discontinued").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "draft", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"draft", "discontinued"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: every status",
+			Params: map[string]string{"pid": "1", "status": "all"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "archived",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id=\\$1 AND p.status NOT IN \\('draft','in_review','scheduled'\\)$").WithArgs("1").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "archived", "", nil, nil, "", 0, 0, time.Time{}, "", time.Time{}, "")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active", "discontinued", "archived"}).Return(nil, nil),
+			},
+		},
+		{
+			Desc:   "Success: updated since, newest first",
+			Params: map[string]string{"pid": "1", "updated_since": "2023-05-01T00:00:00Z", "sort": "newest"},
+			ExpectedResult: []models.ProductWithVariants{{
+				ID:        "1",
+				Name:      "product_1",
+				BrandID:   "b1",
+				BrandName: "brand_1",
+				Details:   "details",
+				ImageUrl:  "url",
+				Type:      "standard",
+				TaxClass:  "standard",
+				Status:    "active",
+				CreatedAt: at,
+				CreatedBy: "u1",
+				UpdatedAt: at,
+				UpdatedBy: "u2",
+			}},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("WHERE p.id=\\$1 AND \\(p.updated_at>=\\$2 OR p.id IN \\(SELECT v.product_id FROM variants v "+
+				"WHERE v.updated_at>=\\$2\\)\\) AND p.status='active' ORDER BY p.created_at DESC, p.id$").
+				WithArgs("1", "2023-05-01T00:00:00Z").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1", "product_1", "b1", "brand_1", "details", "url", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, at, "u1", at, "u2")),
+			Calls: []*gomock.Call{
+				mockVariantStore.EXPECT().GetListedVariants(gomock.Any(), "1", []string{"active"}).Return(nil, nil),
+			},
+		},
 		{
 			Desc:           "Failure: No rows",
 			Params:         map[string]string{"pid": "1"},
@@ -165,6 +515,55 @@ func Test_GetAll(t *testing.T) {
 	}
 }
 
+func Test_GetByIDAsOf(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.ProductWithVariants
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.ProductWithVariants{ID: "1", Name: "old name", BrandID: "b1", BrandName: "brand_1",
+				Details: "details", Type: "standard", TaxClass: "standard", Status: "active", CreatedAt: at, CreatedBy: "u1", UpdatedAt: at,
+				UpdatedBy: "u2"},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM \\(SELECT \\(jsonb_populate_record\\(NULL::products, jsonb_build_object\\('created_at', r.created_at, "+
+					".*\\) \\|\\| r.snapshot\\)\\).\\* FROM revisions r "+
+					"WHERE r.product_id=\\$1 AND r.variant_id='' AND r.created_at <= \\$2").WithArgs("1", at).WillReturnRows(
+					sqlmock.NewRows(columns).
+						AddRow("1", "old name", "b1", "brand_1", "details", "", []byte("{}"), "standard", "standard", "active", "", nil, nil, "", 0, 0, at, "u1", at, "u2"))
+			},
+		},
+		{
+			Desc:        "Failure: did not exist yet",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectQuery("FROM revisions").WithArgs("1", at).WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := mockProductStore.GetByIDAsOf(ctx, "1", at)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+// revision is the row returned when a revision of a product is recorded.
+func revision() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(1, []byte(`{}`), at)
+}
+
 func Test_Create(t *testing.T) {
 	ctx, mock := getSqlMock(t)
 
@@ -172,54 +571,195 @@ func Test_Create(t *testing.T) {
 	mockVariantStore := variants.NewMockVariantStore(ctrl)
 	mockProductStore := New(mockVariantStore)
 
+	publishAt := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	product := &models.Product{
+		ID:        "1",
+		Name:      "product_1",
+		BrandID:   "b1",
+		BrandName: "brand_1",
+		Details:   "details",
+		ImageUrl:  "url",
+		Type:      "standard",
+		TaxClass:  "food",
+		Status:    "draft",
+		Owner:     "u1",
+		PublishAt: &publishAt,
+
+		CategoryIDs: []string{"kettles"},
+		Attributes:  map[string]interface{}{"wattage": 1500},
+	}
+
+	created := *product
+	created.CreatedAt, created.CreatedBy, created.UpdatedAt, created.UpdatedBy = at, "u1", at, "u1"
+
 	testcases := []struct {
 		Desc           string
-		ID             string
-		Body           *models.Product
 		ExpectedResult *models.Product
 		ExpectedErr    error
-		MockCall       *sqlmock.ExpectedExec
+		MockCalls      func()
 	}{
 		{
-			Desc: "Success",
-			ID:   "1",
-			Body: &models.Product{
-				ID:        "1",
-				Name:      "product_1",
-				BrandName: "brand_1",
-				Details:   "details",
-				ImageUrl:  "url",
+			Desc:           "Success",
+			ExpectedResult: &created,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO products\\(id, name, brand_id, details, attributes, type, tax_class, status, owner, "+
+					"publish_at, unpublish_at, created_by\\) .* RETURNING created_at, created_by, updated_at, updated_by").
+					WithArgs("1", "product_1", "b1", "details", `{"wattage":1500}`, "standard", "food", "draft", "u1", &publishAt, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "created_by", "updated_at", "updated_by"}).AddRow(at, "u1", at, "u1"))
+				mock.ExpectExec("INSERT INTO product_categories").WithArgs("1", "kettles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO media").WithArgs("1", "url", "product_1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WithArgs("1", "u1").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
-			ExpectedResult: &models.Product{
-				ID:        "1",
-				Name:      "product_1",
-				BrandName: "brand_1",
-				Details:   "details",
-				ImageUrl:  "url",
+		},
+		{
+			Desc:        "Failure: unknown category",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO products").WillReturnRows(sqlmock.NewRows([]string{"created_at", "created_by", "updated_at",
+					"updated_by"}).AddRow(at, "u1", at, "u1"))
+				mock.ExpectExec("INSERT INTO product_categories").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
 			},
-			ExpectedErr: nil,
-			MockCall:    mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1)).WillReturnError(nil),
 		},
 		{
-			Desc: "Failure: DB error",
-			ID:   "1",
-			Body: &models.Product{
-				ID:        "1",
-				Name:      "product_1",
-				BrandName: "brand_1",
-				Details:   "details",
-				ImageUrl:  "url",
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO products").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
 			},
-			ExpectedResult: nil,
-			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
-			MockCall:       mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.Error("DB Error")),
 		},
 	}
 
 	for i, test := range testcases {
-		res, err := mockProductStore.Create(ctx, test.Body)
+		test.MockCalls()
+
+		p := *product
+
+		res, err := mockProductStore.Create(ctx, &p)
 
 		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func Test_SetAttributes(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET updated_by=\\$1, attributes=\\$2 WHERE id=\\$3").WithArgs("", `{"fabric":"cotton"}`, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO revisions").WithArgs("1", "").WillReturnRows(revision())
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WithArgs("", "{}", "1").WillReturnError(errors.Error("DB Error"))
+	mock.ExpectRollback()
+
+	assert.NoError(t, mockProductStore.SetAttributes(ctx, "1", map[string]interface{}{"fabric": "cotton"}))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, mockProductStore.SetAttributes(ctx, "1", nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_SetTaxClass(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET updated_by=\\$1, tax_class=\\$2 WHERE id=\\$3").WithArgs("", "food", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO revisions").WithArgs("1", "").WillReturnRows(revision())
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WithArgs("", "exempt", "1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO revisions").WillReturnError(errors.Error("DB Error"))
+	mock.ExpectRollback()
+
+	assert.NoError(t, mockProductStore.SetTaxClass(ctx, "1", "food"))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, mockProductStore.SetTaxClass(ctx, "1", "exempt"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_SetSchedule(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	publishAt := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	unpublishAt := time.Date(2023, 12, 26, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET updated_by=\\$1, publish_at=\\$2, unpublish_at=\\$3 WHERE id=\\$4").
+		WithArgs("", &publishAt, &unpublishAt, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO revisions").WithArgs("1", "").WillReturnRows(revision())
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WillReturnError(errors.Error("DB Error"))
+	mock.ExpectRollback()
+
+	assert.NoError(t, mockProductStore.SetSchedule(ctx, "1", &models.Schedule{PublishAt: &publishAt, UnpublishAt: &unpublishAt}))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, mockProductStore.SetSchedule(ctx, "1", &models.Schedule{}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreatePreview(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	expires := time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO preview_tokens\\(token, product_id, created_by\\)").WithArgs("abc", "1", "u1").
+		WillReturnRows(sqlmock.NewRows([]string{"expires_at"}).AddRow(expires))
+	mock.ExpectQuery("INSERT INTO preview_tokens").WillReturnError(errors.Error("DB Error"))
+
+	res, err := mockProductStore.CreatePreview(ctx, &models.PreviewToken{Token: "abc", ProductID: "1", CreatedBy: "u1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.PreviewToken{Token: "abc", ProductID: "1", CreatedBy: "u1", ExpiresAt: expires}, res)
+
+	_, err = mockProductStore.CreatePreview(ctx, &models.PreviewToken{Token: "def", ProductID: "1", CreatedBy: "u1"})
+
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CheckPreview(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+
+	ctrl := gomock.NewController(t)
+	mockProductStore := New(variants.NewMockVariantStore(ctrl))
+
+	mock.ExpectQuery("FROM preview_tokens WHERE token=\\$1 AND product_id=\\$2 AND expires_at > now\\(\\)").WithArgs("abc", "1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("FROM preview_tokens").WithArgs("old", "1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	ok, err := mockProductStore.CheckPreview(ctx, "1", "abc")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = mockProductStore.CheckPreview(ctx, "1", "old")
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
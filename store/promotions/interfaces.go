@@ -0,0 +1,15 @@
+package promotions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"time"
+)
+
+type PromotionStore interface {
+	GetAll(ctx *krogo.Context) ([]models.Promotion, error)
+	GetActive(ctx *krogo.Context, at *time.Time) ([]models.Promotion, error)
+	GetByID(ctx *krogo.Context, id int) (*models.Promotion, error)
+	Create(ctx *krogo.Context, promotion *models.Promotion) (*models.Promotion, error)
+	Delete(ctx *krogo.Context, id int) error
+}
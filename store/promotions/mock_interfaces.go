@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package promotions is a generated GoMock package.
+package promotions
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockPromotionStore is a mock of PromotionStore interface.
+type MockPromotionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionStoreMockRecorder
+}
+
+// MockPromotionStoreMockRecorder is the mock recorder for MockPromotionStore.
+type MockPromotionStoreMockRecorder struct {
+	mock *MockPromotionStore
+}
+
+// NewMockPromotionStore creates a new mock instance.
+func NewMockPromotionStore(ctrl *gomock.Controller) *MockPromotionStore {
+	mock := &MockPromotionStore{ctrl: ctrl}
+	mock.recorder = &MockPromotionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionStore) EXPECT() *MockPromotionStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionStore) Create(ctx *krogo.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promotion)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionStoreMockRecorder) Create(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionStore)(nil).Create), ctx, promotion)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionStore) Delete(ctx *krogo.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionStoreMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionStore)(nil).Delete), ctx, id)
+}
+
+// GetActive mocks base method.
+func (m *MockPromotionStore) GetActive(ctx *krogo.Context, at *time.Time) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, at)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockPromotionStoreMockRecorder) GetActive(ctx, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockPromotionStore)(nil).GetActive), ctx, at)
+}
+
+// GetAll mocks base method.
+func (m *MockPromotionStore) GetAll(ctx *krogo.Context) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromotionStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromotionStore)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockPromotionStore) GetByID(ctx *krogo.Context, id int) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPromotionStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPromotionStore)(nil).GetByID), ctx, id)
+}
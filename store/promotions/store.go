@@ -0,0 +1,128 @@
+package promotions
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/sqlrow"
+	"time"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const selectQuery = "SELECT id, name, type, percent_off, amount_off_cents, buy_quantity, get_quantity, price_cents, targets, " +
+	"starts_at, ends_at, priority, exclusive FROM promotions "
+
+// order is the order promotions are applied in: highest priority first, the oldest promotion first among equals.
+const order = " ORDER BY priority DESC, id"
+
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.Promotion, error) {
+	return s.query(ctx, selectQuery+order)
+}
+
+// GetActive lists the promotions running at a given time, or now when at is nil, in the order they are applied.
+func (s *Store) GetActive(ctx *krogo.Context, at *time.Time) ([]models.Promotion, error) {
+	return s.query(ctx, "WITH t AS (SELECT COALESCE($1, now()) AS at) "+selectQuery+
+		"WHERE starts_at <= (SELECT at FROM t) AND (ends_at IS NULL OR ends_at > (SELECT at FROM t))"+order, at)
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, id int) (*models.Promotion, error) {
+	p, err := scan(ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE id=$1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return p, nil
+}
+
+// Create inserts a promotion. One without a start time starts right away.
+func (s *Store) Create(ctx *krogo.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	query := "INSERT INTO promotions(name, type, percent_off, amount_off_cents, buy_quantity, get_quantity, price_cents, " +
+		"targets, starts_at, ends_at, priority, exclusive) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,COALESCE($9, now()),$10,$11,$12) " +
+		"RETURNING id, starts_at"
+
+	targets, _ := json.Marshal(promotion.Targets)
+
+	err := ctx.DB().QueryRowContext(ctx, query, promotion.Name, promotion.Type, promotion.PercentOff, promotion.AmountOffCents,
+		promotion.BuyQuantity, promotion.GetQuantity, promotion.PriceCents, string(targets),
+		sql.NullTime{Time: promotion.StartsAt, Valid: !promotion.StartsAt.IsZero()}, promotion.EndsAt,
+		promotion.Priority, promotion.Exclusive).Scan(&promotion.ID, &promotion.StartsAt)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return promotion, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, id int) error {
+	_, err := ctx.DB().ExecContext(ctx, "DELETE FROM promotions WHERE id=$1", id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func (s *Store) query(ctx *krogo.Context, query string, args ...interface{}) ([]models.Promotion, error) {
+	var res []models.Promotion
+
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scan(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, *p)
+	}
+
+	return res, nil
+}
+
+func scan(row sqlrow.Scanner) (*models.Promotion, error) {
+	var (
+		p       models.Promotion
+		targets []byte
+		endsAt  sql.NullTime
+	)
+
+	err := row.Scan(&p.ID, &p.Name, &p.Type, &p.PercentOff, &p.AmountOffCents, &p.BuyQuantity, &p.GetQuantity, &p.PriceCents,
+		&targets, &p.StartsAt, &endsAt, &p.Priority, &p.Exclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+
+	if len(targets) > 0 {
+		if err = json.Unmarshal(targets, &p.Targets); err != nil {
+			return nil, err
+		}
+	}
+
+	return &p, nil
+}
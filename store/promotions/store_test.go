@@ -0,0 +1,227 @@
+package promotions
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var (
+	columns = []string{"id", "name", "type", "percent_off", "amount_off_cents", "buy_quantity", "get_quantity", "price_cents",
+		"targets", "starts_at", "ends_at", "priority", "exclusive"}
+	starts = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	ends   = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Promotion
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Promotion{
+				{ID: 2, Name: "Summer sale", Type: "percent_off", PercentOff: 10, StartsAt: starts, EndsAt: &ends, Priority: 5,
+					Targets: []models.PromotionTarget{{Type: "brand", ID: "acme"}}},
+				{ID: 1, Name: "3 for $5", Type: "multi_buy", BuyQuantity: 3, PriceCents: 500, StartsAt: starts, Exclusive: true,
+					Targets: []models.PromotionTarget{{Type: "category", ID: "snacks"}}},
+			},
+			MockCall: mock.ExpectQuery("FROM promotions ORDER BY priority DESC, id").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, "Summer sale", "percent_off", 10, 0, 0, 0, 0, []byte(`[{"type":"brand","id":"acme"}]`), starts, ends, 5, false).
+				AddRow(1, "3 for $5", "multi_buy", 0, 0, 3, 0, 500, []byte(`[{"type":"category","id":"snacks"}]`), starts, nil, 0, true)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM promotions").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetActive(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	at := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		Desc           string
+		At             *time.Time
+		ExpectedResult []models.Promotion
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success: at a given time",
+			At:   &at,
+			ExpectedResult: []models.Promotion{{ID: 2, Name: "Summer sale", Type: "percent_off", PercentOff: 10, StartsAt: starts,
+				EndsAt: &ends, Targets: []models.PromotionTarget{{Type: "brand", ID: "acme"}}}},
+			MockCall: mock.ExpectQuery("WITH t AS \\(SELECT COALESCE\\(\\$1, now\\(\\)\\) AS at\\) SELECT .* WHERE starts_at <=").
+				WithArgs(at).WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, "Summer sale", "percent_off", 10, 0, 0, 0, 0, []byte(`[{"type":"brand","id":"acme"}]`), starts, ends, 0, false)),
+		},
+		{
+			Desc:     "Success: now, nothing running",
+			MockCall: mock.ExpectQuery("FROM promotions").WithArgs(nil).WillReturnRows(sqlmock.NewRows(columns)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			At:          &at,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM promotions").WithArgs(at).WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetActive(ctx, test.At)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Promotion
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Promotion{ID: 1, Name: "BOGO", Type: "buy_x_get_y", BuyQuantity: 1, GetQuantity: 1,
+				StartsAt: starts, Targets: []models.PromotionTarget{{Type: "product", ID: "1"}}},
+			MockCall: mock.ExpectQuery("FROM promotions WHERE id=\\$1").WithArgs(1).WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "BOGO", "buy_x_get_y", 0, 0, 1, 1, 0, []byte(`[{"type":"product","id":"1"}]`), starts, nil, 0, false)),
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("FROM promotions").WithArgs(1).WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM promotions").WithArgs(1).WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByID(ctx, 1)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	targets := []models.PromotionTarget{{Type: "variant", ID: "1-s"}}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Promotion
+		ExpectedResult *models.Promotion
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success: starts now",
+			Body:           &models.Promotion{Name: "$1 off", Type: "amount_off", AmountOffCents: 100, Targets: targets},
+			ExpectedResult: &models.Promotion{ID: 3, Name: "$1 off", Type: "amount_off", AmountOffCents: 100, Targets: targets, StartsAt: starts},
+			MockCall: mock.ExpectQuery("INSERT INTO promotions.*COALESCE\\(\\$9, now\\(\\)\\).* RETURNING id, starts_at").
+				WithArgs("$1 off", "amount_off", 0, int64(100), 0, 0, int64(0), `[{"type":"variant","id":"1-s"}]`, sql.NullTime{},
+					nil, 0, false).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "starts_at"}).AddRow(3, starts)),
+		},
+		{
+			Desc: "Success: scheduled",
+			Body: &models.Promotion{Name: "$1 off", Type: "amount_off", AmountOffCents: 100, Targets: targets, StartsAt: starts,
+				EndsAt: &ends, Priority: 2},
+			ExpectedResult: &models.Promotion{ID: 4, Name: "$1 off", Type: "amount_off", AmountOffCents: 100, Targets: targets,
+				StartsAt: starts, EndsAt: &ends, Priority: 2},
+			MockCall: mock.ExpectQuery("INSERT INTO promotions").
+				WithArgs("$1 off", "amount_off", 0, int64(100), 0, 0, int64(0), `[{"type":"variant","id":"1-s"}]`,
+					sql.NullTime{Time: starts, Valid: true}, &ends, 2, false).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "starts_at"}).AddRow(4, starts)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			Body:        &models.Promotion{Name: "$1 off", Type: "amount_off", AmountOffCents: 100, Targets: targets},
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("INSERT INTO promotions").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.Create(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCall    *sqlmock.ExpectedExec
+	}{
+		{
+			Desc:     "Success",
+			MockCall: mock.ExpectExec("DELETE FROM promotions WHERE id=\\$1").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectExec("DELETE FROM promotions").WithArgs(1).WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		err := s.Delete(ctx, 1)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package relationships is a generated GoMock package.
+package relationships
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockRelationshipStore is a mock of RelationshipStore interface.
+type MockRelationshipStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationshipStoreMockRecorder
+}
+
+// MockRelationshipStoreMockRecorder is the mock recorder for MockRelationshipStore.
+type MockRelationshipStoreMockRecorder struct {
+	mock *MockRelationshipStore
+}
+
+// NewMockRelationshipStore creates a new mock instance.
+func NewMockRelationshipStore(ctrl *gomock.Controller) *MockRelationshipStore {
+	mock := &MockRelationshipStore{ctrl: ctrl}
+	mock.recorder = &MockRelationshipStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelationshipStore) EXPECT() *MockRelationshipStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRelationshipStore) Delete(ctx *krogo.Context, productID, relType, relatedID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, relType, relatedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelationshipStoreMockRecorder) Delete(ctx, productID, relType, relatedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelationshipStore)(nil).Delete), ctx, productID, relType, relatedID)
+}
+
+// GetByProductID mocks base method.
+func (m *MockRelationshipStore) GetByProductID(ctx *krogo.Context, productID, relType string) ([]models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID, relType)
+	ret0, _ := ret[0].([]models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockRelationshipStoreMockRecorder) GetByProductID(ctx, productID, relType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockRelationshipStore)(nil).GetByProductID), ctx, productID, relType)
+}
+
+// Set mocks base method.
+func (m *MockRelationshipStore) Set(ctx *krogo.Context, relationship *models.Relationship) (*models.Relationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, relationship)
+	ret0, _ := ret[0].(*models.Relationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRelationshipStoreMockRecorder) Set(ctx, relationship interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRelationshipStore)(nil).Set), ctx, relationship)
+}
@@ -0,0 +1,157 @@
+package relationships
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+)
+
+// ErrCycle is returned by Set when a replaced_by link would have a product replaced, directly or through other
+// replacements, by itself.
+const ErrCycle = errors.Error("replacement cycle")
+
+// cycleLock identifies the advisory locks that links of a type are made under, with the hash of the type as the second
+// key, so that two links made at the same time cannot close a cycle that neither of them closes alone.
+const cycleLock = 4703
+
+// publishedCondition leaves out the links to products that were not published yet.
+const publishedCondition = "COALESCE(p.status, '') NOT IN ('" + models.StatusDraft + "','" + models.StatusInReview + "','" +
+	models.StatusScheduled + "')"
+
+// reachesQuery reports whether $2 can be reached from $1 by following links of type $3. UNION discards rows already
+// visited, so the walk ends even if the links already form a cycle.
+const reachesQuery = "WITH RECURSIVE chain(id) AS (SELECT $1::VARCHAR UNION SELECT r.related_id FROM product_relationships r " +
+	"JOIN chain c ON r.product_id = c.id WHERE r.type=$3) SELECT EXISTS (SELECT 1 FROM chain WHERE id=$2)"
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// GetByProductID returns the links of a product, ordered by type and position. An empty relType returns all of them.
+// Links to products that were not published yet are left out.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID, relType string) ([]models.Relationship, error) {
+	query := "SELECT r.product_id, r.related_id, COALESCE(p.name, ''), r.type, r.position FROM product_relationships r " +
+		"LEFT JOIN products p ON p.id = r.related_id WHERE r.product_id=$1 AND " + publishedCondition
+	args := []interface{}{productID}
+
+	if relType != "" {
+		query += " AND r.type=$2"
+
+		args = append(args, relType)
+	}
+
+	query += " ORDER BY r.type, r.position, r.related_id"
+
+	var res []models.Relationship
+
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.Relationship
+
+		if err = rows.Scan(&r.ProductID, &r.RelatedID, &r.RelatedName, &r.Type, &r.Position); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+// Set creates a link, or moves it to a new position when it already exists. A replaced_by link that would form a
+// cycle is refused with ErrCycle.
+func (s *Store) Set(ctx *krogo.Context, relationship *models.Relationship) (*models.Relationship, error) {
+	query := "INSERT INTO product_relationships(product_id, related_id, type, position) VALUES ($1,$2,$3,$4) " +
+		"ON CONFLICT (product_id, type, related_id) DO UPDATE SET position=EXCLUDED.position"
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	if relationship.Type == models.RelationshipReplacedBy {
+		if err = checkCycle(ctx, tx, relationship); err != nil {
+			_ = tx.Rollback()
+
+			return nil, err
+		}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, change(relationship.ProductID, relationship.Type, relationship.RelatedID),
+		func() error {
+			_, err := tx.ExecContext(ctx, query, relationship.ProductID, relationship.RelatedID, relationship.Type,
+				relationship.Position)
+			if err != nil {
+				return errors.DB{Err: err}
+			}
+
+			return nil
+		})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return relationship, nil
+}
+
+// checkCycle makes sure the related product is not already replaced by the product, directly or through other
+// replacements. It takes the lock of the type first and holds it until the transaction ends, so links of the type
+// made at the same time are checked one after the other, each seeing the ones made before.
+func checkCycle(ctx *krogo.Context, tx *sql.Tx, relationship *models.Relationship) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", cycleLock, relationship.Type); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	var cycle bool
+
+	err := tx.QueryRowContext(ctx, reachesQuery, relationship.RelatedID, relationship.ProductID, relationship.Type).Scan(&cycle)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if cycle {
+		return ErrCycle
+	}
+
+	return nil
+}
+
+// Delete removes a link and reports whether there was one.
+func (s *Store) Delete(ctx *krogo.Context, productID, relType, relatedID string) (bool, error) {
+	n, err := audit.Exec(ctx, models.AuditDelete, change(productID, relType, relatedID),
+		"DELETE FROM product_relationships WHERE product_id=$1 AND type=$2 AND related_id=$3", productID, relType, relatedID)
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// change is the audit record of a change to a link from a product to another.
+func change(productID, relType, relatedID string) *audit.Change {
+	return &audit.Change{Entity: "relationships", EntityID: productID, ProductID: productID,
+		Query: audit.Rows("product_relationships", "t.product_id=$1 AND t.type=$2 AND t.related_id=$3"),
+		Args:  []interface{}{productID, relType, relatedID}}
+}
@@ -0,0 +1,216 @@
+package relationships
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"product_id", "related_id", "name", "type", "position"}
+
+	testcases := []struct {
+		Desc           string
+		Type           string
+		ExpectedResult []models.Relationship
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success: all types",
+			ExpectedResult: []models.Relationship{
+				{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory"},
+				{ProductID: "1", RelatedID: "3", RelatedName: "kettle v2", Type: "replaced_by"},
+			},
+			MockCall: mock.ExpectQuery("FROM product_relationships r .* WHERE r.product_id=\\$1 " +
+				"AND COALESCE\\(p.status, ''\\) NOT IN \\('draft','in_review','scheduled'\\) ORDER BY r.type, r.position").
+				WithArgs("1").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "2", "lid", "accessory", 0).
+				AddRow("1", "3", "kettle v2", "replaced_by", 0)),
+		},
+		{
+			Desc:           "Success: one type",
+			Type:           "accessory",
+			ExpectedResult: []models.Relationship{{ProductID: "1", RelatedID: "2", RelatedName: "lid", Type: "accessory", Position: 1}},
+			MockCall: mock.ExpectQuery("WHERE r.product_id=\\$1 AND .* AND r.type=\\$2 ORDER BY").WithArgs("1", "accessory").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow("1", "2", "lid", "accessory", 1)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1", test.Type)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Set(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	accessory := &models.Relationship{ProductID: "1", RelatedID: "2", Type: "accessory", Position: 3}
+	replacement := &models.Relationship{ProductID: "1", RelatedID: "3", Type: "replaced_by"}
+
+	testcases := []struct {
+		Desc           string
+		Body           *models.Relationship
+		ExpectedResult *models.Relationship
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			Body:           accessory,
+			ExpectedResult: accessory,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO product_relationships.* ON CONFLICT").WithArgs("1", "2", "accessory", 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "relationships", "1", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:           "Success: replacement checked under the lock",
+			Body:           replacement,
+			ExpectedResult: replacement,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1, hashtext\\(\\$2\\)\\)").WithArgs(cycleLock, "replaced_by").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE chain").WithArgs("3", "1", "replaced_by").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "replaced_by", "3").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO product_relationships").WithArgs("1", "3", "replaced_by", 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "replaced_by", "3").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: replacement cycle",
+			Body:        replacement,
+			ExpectedErr: ErrCycle,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE chain").WithArgs("3", "1", "replaced_by").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error in the cycle check",
+			Body:        replacement,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE chain").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Body:        accessory,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO product_relationships").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Set(ctx, test.Body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("DELETE FROM product_relationships").WithArgs("1", "accessory", "2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "relationships", "1", "1", "", "", `{}`, `{}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("DELETE FROM product_relationships").WithArgs("1", "accessory", "2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "relationships", "1", "1", "", "", `{}`, `{}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM product_relationships t").WithArgs("1", "accessory", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("DELETE FROM product_relationships").WillReturnError(errors.Error("DB Error"))
+	mock.ExpectRollback()
+
+	deleted, err := s.Delete(ctx, "1", "accessory", "2")
+
+	assert.True(t, deleted)
+	assert.NoError(t, err)
+
+	deleted, err = s.Delete(ctx, "1", "accessory", "2")
+
+	assert.False(t, deleted)
+	assert.NoError(t, err)
+
+	_, err = s.Delete(ctx, "1", "accessory", "2")
+
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
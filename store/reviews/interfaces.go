@@ -0,0 +1,14 @@
+package reviews
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type ReviewStore interface {
+	GetByProductID(ctx *krogo.Context, productID, status string) ([]models.Review, error)
+	GetByID(ctx *krogo.Context, productID string, id int) (*models.Review, error)
+	Create(ctx *krogo.Context, review *models.Review) (*models.Review, error)
+	SetStatus(ctx *krogo.Context, productID string, id int, status string) error
+	Delete(ctx *krogo.Context, productID string, id int) error
+}
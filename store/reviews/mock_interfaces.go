@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package reviews is a generated GoMock package.
+package reviews
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockReviewStore is a mock of ReviewStore interface.
+type MockReviewStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewStoreMockRecorder
+}
+
+// MockReviewStoreMockRecorder is the mock recorder for MockReviewStore.
+type MockReviewStoreMockRecorder struct {
+	mock *MockReviewStore
+}
+
+// NewMockReviewStore creates a new mock instance.
+func NewMockReviewStore(ctrl *gomock.Controller) *MockReviewStore {
+	mock := &MockReviewStore{ctrl: ctrl}
+	mock.recorder = &MockReviewStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewStore) EXPECT() *MockReviewStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewStore) Create(ctx *krogo.Context, review *models.Review) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewStoreMockRecorder) Create(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewStore)(nil).Create), ctx, review)
+}
+
+// Delete mocks base method.
+func (m *MockReviewStore) Delete(ctx *krogo.Context, productID string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReviewStoreMockRecorder) Delete(ctx, productID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReviewStore)(nil).Delete), ctx, productID, id)
+}
+
+// GetByID mocks base method.
+func (m *MockReviewStore) GetByID(ctx *krogo.Context, productID string, id int) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, productID, id)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReviewStoreMockRecorder) GetByID(ctx, productID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReviewStore)(nil).GetByID), ctx, productID, id)
+}
+
+// GetByProductID mocks base method.
+func (m *MockReviewStore) GetByProductID(ctx *krogo.Context, productID, status string) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID, status)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockReviewStoreMockRecorder) GetByProductID(ctx, productID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockReviewStore)(nil).GetByProductID), ctx, productID, status)
+}
+
+// SetStatus mocks base method.
+func (m *MockReviewStore) SetStatus(ctx *krogo.Context, productID string, id int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, productID, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockReviewStoreMockRecorder) SetStatus(ctx, productID, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockReviewStore)(nil).SetStatus), ctx, productID, id, status)
+}
@@ -0,0 +1,190 @@
+package reviews
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const selectQuery = "SELECT id, product_id, variant_id, rating, title, body, status, author, created_at FROM reviews "
+
+// GetByProductID lists the reviews of a product in one moderation state, newest first.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID, status string) ([]models.Review, error) {
+	var res []models.Review
+
+	rows, err := ctx.DB().QueryContext(ctx, selectQuery+"WHERE product_id=$1 AND status=$2 ORDER BY created_at DESC, id DESC",
+		productID, status)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.Review
+
+		err = rows.Scan(&r.ID, &r.ProductID, &r.VariantID, &r.Rating, &r.Title, &r.Body, &r.Status, &r.Author, &r.CreatedAt)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, productID string, id int) (*models.Review, error) {
+	var r models.Review
+
+	err := ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE product_id=$1 AND id=$2", productID, id).
+		Scan(&r.ID, &r.ProductID, &r.VariantID, &r.Rating, &r.Title, &r.Body, &r.Status, &r.Author, &r.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return &r, nil
+}
+
+func (s *Store) Create(ctx *krogo.Context, review *models.Review) (*models.Review, error) {
+	query := "INSERT INTO reviews(product_id, variant_id, rating, title, body, status, author) VALUES ($1,$2,$3,$4,$5,$6,$7) " +
+		"RETURNING id, created_at"
+
+	err := ctx.DB().QueryRowContext(ctx, query, review.ProductID, review.VariantID, review.Rating, review.Title, review.Body,
+		review.Status, review.Author).Scan(&review.ID, &review.CreatedAt)
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return review, nil
+}
+
+// SetStatus moves a review to another moderation state, counting it into or out of the product's rating
+// when it becomes or stops being approved.
+func (s *Store) SetStatus(ctx *krogo.Context, productID string, id int, status string) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	old, rating, err := lock(ctx, tx, productID, id)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE reviews SET status=$1 WHERE id=$2", status, id); err != nil {
+		_ = tx.Rollback()
+
+		return errors.DB{Err: err}
+	}
+
+	count := 0
+
+	switch {
+	case old != models.ReviewApproved && status == models.ReviewApproved:
+		count = 1
+	case old == models.ReviewApproved && status != models.ReviewApproved:
+		count = -1
+	}
+
+	if err = adjustRating(ctx, tx, productID, count, rating); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// Delete removes a review, taking it out of the product's rating if it was approved.
+func (s *Store) Delete(ctx *krogo.Context, productID string, id int) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	old, rating, err := lock(ctx, tx, productID, id)
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM reviews WHERE id=$1", id); err != nil {
+		_ = tx.Rollback()
+
+		return errors.DB{Err: err}
+	}
+
+	if old == models.ReviewApproved {
+		if err = adjustRating(ctx, tx, productID, -1, rating); err != nil {
+			_ = tx.Rollback()
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// lock reads the status and rating of a review and holds its row until the transaction ends, so that two
+// moderators acting on it at once cannot both count it.
+func lock(ctx *krogo.Context, tx *sql.Tx, productID string, id int) (status string, rating int, err error) {
+	err = tx.QueryRowContext(ctx, "SELECT status, rating FROM reviews WHERE product_id=$1 AND id=$2 FOR UPDATE", productID, id).
+		Scan(&status, &rating)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, sql.ErrNoRows
+		}
+
+		return "", 0, errors.DB{Err: err}
+	}
+
+	return status, rating, nil
+}
+
+// adjustRating adds count reviews of the given rating to the running totals of a product; a negative count
+// takes them out.
+func adjustRating(ctx *krogo.Context, tx *sql.Tx, productID string, count, rating int) error {
+	if count == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "UPDATE products SET rating_count=rating_count+$1, rating_sum=rating_sum+$2 WHERE id=$3",
+		count, count*rating, productID)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package reviews
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var (
+	columns = []string{"id", "product_id", "variant_id", "rating", "title", "body", "status", "author", "created_at"}
+	created = time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+)
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Review
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Review{
+				{ID: 2, ProductID: "1", VariantID: "1-s", Rating: 5, Title: "Great", Body: "Loved it", Status: "approved", Author: "u1",
+					CreatedAt: created},
+				{ID: 1, ProductID: "1", Rating: 3, Title: "Fine", Body: "Does the job", Status: "approved", CreatedAt: created},
+			},
+			MockCall: mock.ExpectQuery("FROM reviews WHERE product_id=\\$1 AND status=\\$2 ORDER BY created_at DESC, id DESC").
+				WithArgs("1", "approved").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, "1", "1-s", 5, "Great", "Loved it", "approved", "u1", created).
+				AddRow(1, "1", "", 3, "Fine", "Does the job", "approved", "", created)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM reviews").WithArgs("1", "approved").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1", "approved")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Review
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Review{ID: 1, ProductID: "1", Rating: 4, Title: "Good", Body: "Nice", Status: "pending", Author: "u1",
+				CreatedAt: created},
+			MockCall: mock.ExpectQuery("SELECT id, product_id, variant_id, rating, title, body, status, author, created_at "+
+				"FROM reviews WHERE product_id=\\$1 AND id=\\$2").WithArgs("1", 1).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "1", "", 4, "Good", "Nice", "pending", "u1", created)),
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("FROM reviews").WithArgs("1", 1).WillReturnError(sql.ErrNoRows),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByID(ctx, "1", 1)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("INSERT INTO reviews\\(product_id, variant_id, rating, title, body, status, author\\) .* RETURNING id, created_at").
+		WithArgs("1", "", 4, "Good", "Nice", "pending", "u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, created))
+	mock.ExpectQuery("INSERT INTO reviews").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.Create(ctx, &models.Review{ProductID: "1", Rating: 4, Title: "Good", Body: "Nice", Status: "pending", Author: "u1"})
+
+	assert.Equal(t, &models.Review{ID: 7, ProductID: "1", Rating: 4, Title: "Good", Body: "Nice", Status: "pending", Author: "u1",
+		CreatedAt: created}, res)
+	assert.NoError(t, err)
+
+	res, err = s.Create(ctx, &models.Review{ProductID: "1", Rating: 4, Title: "Good", Body: "Nice", Status: "pending"})
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+}
+
+func Test_SetStatus(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		Status      string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc:   "Success: approving counts the review",
+			Status: "approved",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT status, rating FROM reviews WHERE product_id=\\$1 AND id=\\$2 FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("pending", 4))
+				mock.ExpectExec("UPDATE reviews SET status=\\$1 WHERE id=\\$2").WithArgs("approved", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products SET rating_count=rating_count\\+\\$1, rating_sum=rating_sum\\+\\$2 WHERE id=\\$3").
+					WithArgs(1, 4, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:   "Success: rejecting an approved review takes it out",
+			Status: "rejected",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("approved", 4))
+				mock.ExpectExec("UPDATE reviews").WithArgs("rejected", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products").WithArgs(-1, -4, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:   "Success: approving twice counts once",
+			Status: "approved",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("approved", 4))
+				mock.ExpectExec("UPDATE reviews").WithArgs("approved", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			Status:      "approved",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetStatus(ctx, "1", 1, test.Status)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success: approved review",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("approved", 2))
+				mock.ExpectExec("DELETE FROM reviews WHERE id=\\$1").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE products").WithArgs(-1, -2, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: pending review",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("pending", 2))
+				mock.ExpectExec("DELETE FROM reviews").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WithArgs("1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "rating"}).AddRow("approved", 2))
+				mock.ExpectExec("DELETE FROM reviews").WithArgs(1).WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.Delete(ctx, "1", 1)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
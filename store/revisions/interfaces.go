@@ -0,0 +1,12 @@
+package revisions
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type RevisionStore interface {
+	GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error)
+	GetByID(ctx *krogo.Context, productID, id string) (*models.Revision, error)
+	Revert(ctx *krogo.Context, revision *models.Revision, actor string) (*models.Revision, error)
+}
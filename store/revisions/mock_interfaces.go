@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package revisions is a generated GoMock package.
+package revisions
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockRevisionStore is a mock of RevisionStore interface.
+type MockRevisionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionStoreMockRecorder
+}
+
+// MockRevisionStoreMockRecorder is the mock recorder for MockRevisionStore.
+type MockRevisionStoreMockRecorder struct {
+	mock *MockRevisionStore
+}
+
+// NewMockRevisionStore creates a new mock instance.
+func NewMockRevisionStore(ctrl *gomock.Controller) *MockRevisionStore {
+	mock := &MockRevisionStore{ctrl: ctrl}
+	mock.recorder = &MockRevisionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionStore) EXPECT() *MockRevisionStoreMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockRevisionStore) GetByID(ctx *krogo.Context, productID, id string) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, productID, id)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRevisionStoreMockRecorder) GetByID(ctx, productID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRevisionStore)(nil).GetByID), ctx, productID, id)
+}
+
+// GetByProductID mocks base method.
+func (m *MockRevisionStore) GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProductID", ctx, productID)
+	ret0, _ := ret[0].([]models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockRevisionStoreMockRecorder) GetByProductID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockRevisionStore)(nil).GetByProductID), ctx, productID)
+}
+
+// Revert mocks base method.
+func (m *MockRevisionStore) Revert(ctx *krogo.Context, revision *models.Revision, actor string) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, revision, actor)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockRevisionStoreMockRecorder) Revert(ctx, revision, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockRevisionStore)(nil).Revert), ctx, revision, actor)
+}
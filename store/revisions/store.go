@@ -0,0 +1,172 @@
+package revisions
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"practice-app/store/sqlrow"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const (
+	selectQuery = "SELECT id, product_id, variant_id, actor, snapshot, created_at FROM revisions "
+	// recordProduct and recordVariant copy a product or a variant, with all of its columns, into a new revision.
+	recordProduct = "INSERT INTO revisions(product_id, actor, snapshot) SELECT p.id, $2, to_jsonb(p) FROM products p " +
+		"WHERE p.id=$1 RETURNING id, snapshot, created_at"
+	recordVariant = "INSERT INTO revisions(product_id, variant_id, actor, snapshot) SELECT v.product_id, v.id, $3, to_jsonb(v) " +
+		"FROM variants v WHERE v.id=$1 AND v.product_id=$2 RETURNING id, snapshot, created_at"
+	// revertProduct and revertVariant restore the content of a product or a variant from a snapshot. Statuses change
+	// through their lifecycle and identifiers stay as they are, so neither is restored; the reverting actor becomes
+	// the last to have changed it.
+	revertProduct = "UPDATE products p SET name=r.name, brand_id=r.brand_id, details=r.details, attributes=r.attributes, " +
+		"tax_class=r.tax_class, publish_at=r.publish_at, unpublish_at=r.unpublish_at, updated_by=$3 " +
+		"FROM jsonb_populate_record(NULL::products, $1::jsonb) r WHERE p.id=$2"
+	revertVariant = "UPDATE variants v SET variant_name=r.variant_name, variant_details=r.variant_details, " +
+		"price_cents=r.price_cents, measurements=r.measurements, nutrition=r.nutrition, allergens=r.allergens, updated_by=$4 " +
+		"FROM jsonb_populate_record(NULL::variants, $1::jsonb) r WHERE v.id=$2 AND v.product_id=$3"
+)
+
+// GetByProductID lists the revisions of a product and its variants, oldest first.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string) ([]models.Revision, error) {
+	var res []models.Revision
+
+	rows, err := ctx.DB().QueryContext(ctx, selectQuery+"WHERE product_id=$1 ORDER BY id", productID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *r)
+	}
+
+	return res, nil
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, productID, id string) (*models.Revision, error) {
+	return scan(ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE id=$1 AND product_id=$2", id, productID))
+}
+
+// Revert restores a product or a variant as it was in a revision and records the result as a new revision by actor,
+// with an audit entry, in one transaction. sql.ErrNoRows is returned when the product or variant no longer exists.
+func (s *Store) Revert(ctx *krogo.Context, revision *models.Revision, actor string) (*models.Revision, error) {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	var res sql.Result
+
+	if revision.VariantID == "" {
+		res, err = tx.ExecContext(ctx, revertProduct, string(snapshot), revision.ProductID, actor)
+	} else {
+		res, err = tx.ExecContext(ctx, revertVariant, string(snapshot), revision.VariantID, revision.ProductID, actor)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		_ = tx.Rollback()
+
+		return nil, sql.ErrNoRows
+	}
+
+	r := models.Revision{ProductID: revision.ProductID, VariantID: revision.VariantID, Actor: actor}
+
+	if err = Record(ctx, tx, &r); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = audit.Record(ctx, tx, models.AuditRevert, &r); err != nil {
+		_ = tx.Rollback()
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return &r, nil
+}
+
+// Record copies a product, or one of its variants when revision.VariantID is set, into a new revision as part of a
+// transaction of the caller, setting its id, snapshot and time. It returns sql.ErrNoRows when there is no such
+// product or variant.
+func Record(ctx *krogo.Context, tx *sql.Tx, revision *models.Revision) error {
+	var row *sql.Row
+
+	if revision.VariantID == "" {
+		row = tx.QueryRowContext(ctx, recordProduct, revision.ProductID, revision.Actor)
+	} else {
+		row = tx.QueryRowContext(ctx, recordVariant, revision.VariantID, revision.ProductID, revision.Actor)
+	}
+
+	var snapshot []byte
+
+	if err := row.Scan(&revision.ID, &snapshot, &revision.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+
+		return errors.DB{Err: err}
+	}
+
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func scan(row sqlrow.Scanner) (*models.Revision, error) {
+	var (
+		r        models.Revision
+		snapshot []byte
+	)
+
+	if err := row.Scan(&r.ID, &r.ProductID, &r.VariantID, &r.Actor, &snapshot, &r.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if err := json.Unmarshal(snapshot, &r.Snapshot); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,204 @@
+package revisions
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+var columns = []string{"id", "product_id", "variant_id", "actor", "snapshot", "created_at"}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Revision
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Revision{
+				{ID: 1, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"id": "1", "name": "Milk"}, CreatedAt: at},
+				{ID: 2, ProductID: "1", VariantID: "2", Actor: "u1", Snapshot: map[string]interface{}{"id": "2", "price_cents": float64(199)},
+					CreatedAt: at},
+			},
+			MockCalls: func() {
+				mock.ExpectQuery("SELECT id, product_id, variant_id, actor, snapshot, created_at FROM revisions WHERE product_id=\\$1").
+					WithArgs("1").WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, "1", "", "u1", []byte(`{"id":"1","name":"Milk"}`), at).
+					AddRow(2, "1", "2", "u1", []byte(`{"id":"2","price_cents":199}`), at))
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM revisions").WillReturnError(errors.Error("DB Error"))
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetByProductID(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Revision
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.Revision{ID: 3, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}, CreatedAt: at},
+			MockCalls: func() {
+				mock.ExpectQuery("FROM revisions WHERE id=\\$1 AND product_id=\\$2").WithArgs("3", "1").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "1", "", "u1", []byte(`{"name":"Milk"}`), at))
+			},
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectQuery("FROM revisions").WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.GetByID(ctx, "1", "3")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Revert(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	product := &models.Revision{ID: 3, ProductID: "1", Snapshot: map[string]interface{}{"name": "Milk"}}
+	variant := &models.Revision{ID: 4, ProductID: "1", VariantID: "2", Snapshot: map[string]interface{}{"price_cents": float64(199)}}
+
+	testcases := []struct {
+		Desc           string
+		Revision       *models.Revision
+		ExpectedResult *models.Revision
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success: product",
+			Revision:       product,
+			ExpectedResult: &models.Revision{ID: 9, ProductID: "1", Actor: "u1", Snapshot: map[string]interface{}{"name": "Milk"}, CreatedAt: at},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products p SET name=r.name").WithArgs(`{"name":"Milk"}`, "1", "u1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions\\(product_id, actor, snapshot\\)").WithArgs("1", "u1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(9, []byte(`{"name":"Milk"}`), at))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs(models.AuditRevert, "products", "1", "", 9).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:     "Success: variant",
+			Revision: variant,
+			ExpectedResult: &models.Revision{ID: 10, ProductID: "1", VariantID: "2", Actor: "u1",
+				Snapshot: map[string]interface{}{"price_cents": float64(199)}, CreatedAt: at},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants v SET variant_name=r.variant_name").WithArgs(`{"price_cents":199}`, "2", "1", "u1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions\\(product_id, variant_id, actor, snapshot\\)").WithArgs("2", "1", "u1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(10, []byte(`{"price_cents":199}`), at))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs(models.AuditRevert, "variants", "2", "", 10).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: variant no longer exists",
+			Revision:    variant,
+			ExpectedErr: sql.ErrNoRows,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Revision:    product,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: audit entry not written",
+			Revision:    product,
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE products").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).
+					AddRow(9, []byte(`{}`), at))
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Revert(ctx, test.Revision, "u1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
@@ -0,0 +1,7 @@
+// Package sqlrow holds what the stores share about reading rows.
+package sqlrow
+
+// Scanner is implemented by both *sql.Row and *sql.Rows, so that one function can scan a row read either way.
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,13 @@
+package tags
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type TagStore interface {
+	GetAll(ctx *krogo.Context) ([]models.Tag, error)
+	Get(ctx *krogo.Context, productID, variantID string) ([]string, error)
+	Add(ctx *krogo.Context, productID, variantID string, tags []string) error
+	Remove(ctx *krogo.Context, productID, variantID, tag string) (bool, error)
+}
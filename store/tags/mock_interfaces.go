@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package tags is a generated GoMock package.
+package tags
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockTagStore is a mock of TagStore interface.
+type MockTagStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagStoreMockRecorder
+}
+
+// MockTagStoreMockRecorder is the mock recorder for MockTagStore.
+type MockTagStoreMockRecorder struct {
+	mock *MockTagStore
+}
+
+// NewMockTagStore creates a new mock instance.
+func NewMockTagStore(ctrl *gomock.Controller) *MockTagStore {
+	mock := &MockTagStore{ctrl: ctrl}
+	mock.recorder = &MockTagStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagStore) EXPECT() *MockTagStoreMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTagStore) Add(ctx *krogo.Context, productID, variantID string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, productID, variantID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTagStoreMockRecorder) Add(ctx, productID, variantID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTagStore)(nil).Add), ctx, productID, variantID, tags)
+}
+
+// Get mocks base method.
+func (m *MockTagStore) Get(ctx *krogo.Context, productID, variantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, productID, variantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagStoreMockRecorder) Get(ctx, productID, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagStore)(nil).Get), ctx, productID, variantID)
+}
+
+// GetAll mocks base method.
+func (m *MockTagStore) GetAll(ctx *krogo.Context) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagStoreMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTagStore)(nil).GetAll), ctx)
+}
+
+// Remove mocks base method.
+func (m *MockTagStore) Remove(ctx *krogo.Context, productID, variantID, tag string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, productID, variantID, tag)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTagStoreMockRecorder) Remove(ctx, productID, variantID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTagStore)(nil).Remove), ctx, productID, variantID, tag)
+}
@@ -0,0 +1,119 @@
+package tags
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"practice-app/vocabulary"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// GetAll lists the tag vocabulary with the number of products and variants using each tag.
+func (s *Store) GetAll(ctx *krogo.Context) ([]models.Tag, error) {
+	query := "SELECT t.name, COUNT(pt.tag) FILTER (WHERE pt.variant_id = ''), COUNT(pt.tag) FILTER (WHERE pt.variant_id <> '') " +
+		"FROM tags t LEFT JOIN product_tags pt ON pt.tag = t.name GROUP BY t.name ORDER BY t.name"
+
+	var res []models.Tag
+
+	rows, err := ctx.DB().QueryContext(ctx, query)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Tag
+
+		if err = rows.Scan(&t.Name, &t.Products, &t.Variants); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+// Get returns the tags of a product, or of one of its variants when variantID is set.
+func (s *Store) Get(ctx *krogo.Context, productID, variantID string) ([]string, error) {
+	var tags string
+
+	err := ctx.DB().QueryRowContext(ctx, "SELECT "+vocabulary.Aggregate("$1", "$2"), productID, variantID).Scan(&tags)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return vocabulary.Split(tags), nil
+}
+
+// Add adds normalized tags to a product or variant, registering the ones new to the vocabulary.
+// Tags it already has are left alone.
+func (s *Store) Add(ctx *krogo.Context, productID, variantID string, tags []string) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	err = audit.Track(ctx, tx, models.AuditUpdate, change(productID, variantID), func() error {
+		for _, tag := range tags {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO tags(name) VALUES ($1) ON CONFLICT DO NOTHING", tag); err != nil {
+				return errors.DB{Err: err}
+			}
+
+			_, err := tx.ExecContext(ctx, "INSERT INTO product_tags(product_id, variant_id, tag) VALUES ($1,$2,$3) ON CONFLICT DO NOTHING",
+				productID, variantID, tag)
+			if err != nil {
+				return errors.DB{Err: err}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// Remove takes a tag off a product or variant and reports whether it had it. The tag stays in the vocabulary.
+func (s *Store) Remove(ctx *krogo.Context, productID, variantID, tag string) (bool, error) {
+	n, err := audit.Exec(ctx, models.AuditDelete, change(productID, variantID),
+		"DELETE FROM product_tags WHERE product_id=$1 AND variant_id=$2 AND tag=$3", productID, variantID, tag)
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// change is the audit record of a change to the tags of a product, or of one of its variants when variantID is set.
+func change(productID, variantID string) *audit.Change {
+	entityID := productID
+	if variantID != "" {
+		entityID = variantID
+	}
+
+	return &audit.Change{Entity: "tags", EntityID: entityID, ProductID: productID,
+		Query: audit.Rows("product_tags", "t.product_id=$1 AND t.variant_id=$2"), Args: []interface{}{productID, variantID}}
+}
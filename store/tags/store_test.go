@@ -0,0 +1,192 @@
+package tags
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Tag
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: []models.Tag{{Name: "new", Products: 0, Variants: 1}, {Name: "organic", Products: 3}},
+			MockCall: mock.ExpectQuery("FROM tags t LEFT JOIN product_tags pt ON pt.tag = t.name GROUP BY t.name ORDER BY t.name").
+				WillReturnRows(sqlmock.NewRows([]string{"name", "products", "variants"}).AddRow("new", 0, 1).AddRow("organic", 3, 0)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM tags").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Get(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectQuery("SELECT COALESCE\\(\\(SELECT string_agg\\(t.tag, ',' ORDER BY t.tag\\) FROM product_tags t "+
+		"WHERE t.product_id = \\$1 AND t.variant_id = \\$2\\), ''\\)").WithArgs("1", "1-s").
+		WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow("new,organic"))
+	mock.ExpectQuery("FROM product_tags").WithArgs("1", "").WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow(""))
+
+	res, err := s.Get(ctx, "1", "1-s")
+
+	assert.Equal(t, []string{"new", "organic"}, res)
+	assert.NoError(t, err)
+
+	res, err = s.Get(ctx, "1", "")
+
+	assert.Nil(t, res)
+	assert.NoError(t, err)
+}
+
+func Test_Add(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO tags\\(name\\) VALUES \\(\\$1\\) ON CONFLICT DO NOTHING").WithArgs("organic").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO product_tags\\(product_id, variant_id, tag\\)").WithArgs("1", "", "organic").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO tags").WithArgs("vegan").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO product_tags").WithArgs("1", "", "vegan").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "tags", "1", "1", "", "", `{"product_tags":[]}`, `{"product_tags":[]}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO tags").WithArgs("organic").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO product_tags").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.Add(ctx, "1", "", []string{"organic", "vegan"})
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Remove(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult bool
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: true,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "1-s").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("DELETE FROM product_tags WHERE product_id=\\$1 AND variant_id=\\$2 AND tag=\\$3").
+					WithArgs("1", "1-s", "new").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "1-s").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "tags", "1-s", "1", "", "", `{"product_tags":[]}`, `{"product_tags":[]}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: not tagged",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "1-s").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("DELETE FROM product_tags").WithArgs("1", "1-s", "new").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "1-s").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "tags", "1-s", "1", "", "", `{"product_tags":[]}`, `{"product_tags":[]}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM product_tags t WHERE t.product_id=\\$1 AND t.variant_id=\\$2").WithArgs("1", "1-s").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{"product_tags":[]}`)))
+				mock.ExpectExec("DELETE FROM product_tags").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Remove(ctx, "1", "1-s", "new")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
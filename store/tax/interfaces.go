@@ -0,0 +1,16 @@
+package tax
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"time"
+)
+
+type TaxStore interface {
+	GetAll(ctx *krogo.Context, region string) ([]models.TaxRate, error)
+	GetEffective(ctx *krogo.Context, region string, at *time.Time) ([]models.TaxRate, error)
+	GetByID(ctx *krogo.Context, id int) (*models.TaxRate, error)
+	Create(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	Update(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	Delete(ctx *krogo.Context, id int) error
+}
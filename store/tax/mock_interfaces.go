@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package tax is a generated GoMock package.
+package tax
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockTaxStore is a mock of TaxStore interface.
+type MockTaxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxStoreMockRecorder
+}
+
+// MockTaxStoreMockRecorder is the mock recorder for MockTaxStore.
+type MockTaxStoreMockRecorder struct {
+	mock *MockTaxStore
+}
+
+// NewMockTaxStore creates a new mock instance.
+func NewMockTaxStore(ctrl *gomock.Controller) *MockTaxStore {
+	mock := &MockTaxStore{ctrl: ctrl}
+	mock.recorder = &MockTaxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxStore) EXPECT() *MockTaxStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaxStore) Create(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxStoreMockRecorder) Create(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxStore)(nil).Create), ctx, rate)
+}
+
+// Delete mocks base method.
+func (m *MockTaxStore) Delete(ctx *krogo.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaxStoreMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaxStore)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockTaxStore) GetAll(ctx *krogo.Context, region string) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, region)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTaxStoreMockRecorder) GetAll(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaxStore)(nil).GetAll), ctx, region)
+}
+
+// GetByID mocks base method.
+func (m *MockTaxStore) GetByID(ctx *krogo.Context, id int) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTaxStoreMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTaxStore)(nil).GetByID), ctx, id)
+}
+
+// GetEffective mocks base method.
+func (m *MockTaxStore) GetEffective(ctx *krogo.Context, region string, at *time.Time) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffective", ctx, region, at)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffective indicates an expected call of GetEffective.
+func (mr *MockTaxStoreMockRecorder) GetEffective(ctx, region, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffective", reflect.TypeOf((*MockTaxStore)(nil).GetEffective), ctx, region, at)
+}
+
+// Update mocks base method.
+func (m *MockTaxStore) Update(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaxStoreMockRecorder) Update(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaxStore)(nil).Update), ctx, rate)
+}
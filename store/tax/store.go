@@ -0,0 +1,123 @@
+package tax
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/sqlrow"
+	"time"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+const selectQuery = "SELECT id, region, tax_class, rate, effective_from, effective_to FROM tax_rates "
+
+const order = " ORDER BY region, tax_class, effective_from"
+
+// GetAll lists the rates of a region, or of every region when region is empty, past and future ones included.
+func (s *Store) GetAll(ctx *krogo.Context, region string) ([]models.TaxRate, error) {
+	if region == "" {
+		return s.query(ctx, selectQuery+order)
+	}
+
+	return s.query(ctx, selectQuery+"WHERE region=$1"+order, region)
+}
+
+// GetEffective lists the rates of a region in effect at a given time, or now when at is nil; one per tax class.
+func (s *Store) GetEffective(ctx *krogo.Context, region string, at *time.Time) ([]models.TaxRate, error) {
+	return s.query(ctx, "WITH t AS (SELECT COALESCE($2, now()) AS at) "+selectQuery+"WHERE region=$1 AND "+
+		"effective_from <= (SELECT at FROM t) AND (effective_to IS NULL OR effective_to > (SELECT at FROM t))"+order, region, at)
+}
+
+func (s *Store) GetByID(ctx *krogo.Context, id int) (*models.TaxRate, error) {
+	r, err := scan(ctx.DB().QueryRowContext(ctx, selectQuery+"WHERE id=$1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	return r, nil
+}
+
+func (s *Store) Create(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	err := ctx.DB().QueryRowContext(ctx, "INSERT INTO tax_rates(region, tax_class, rate, effective_from, effective_to) "+
+		"VALUES ($1,$2,$3,$4,$5) RETURNING id", rate.Region, rate.TaxClass, rate.Rate, rate.EffectiveFrom, rate.EffectiveTo).
+		Scan(&rate.ID)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return rate, nil
+}
+
+func (s *Store) Update(ctx *krogo.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	_, err := ctx.DB().ExecContext(ctx, "UPDATE tax_rates SET region=$1, tax_class=$2, rate=$3, effective_from=$4, "+
+		"effective_to=$5 WHERE id=$6", rate.Region, rate.TaxClass, rate.Rate, rate.EffectiveFrom, rate.EffectiveTo, rate.ID)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return rate, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, id int) error {
+	_, err := ctx.DB().ExecContext(ctx, "DELETE FROM tax_rates WHERE id=$1", id)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+func (s *Store) query(ctx *krogo.Context, query string, args ...interface{}) ([]models.TaxRate, error) {
+	var res []models.TaxRate
+
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scan(rows)
+		if err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, *r)
+	}
+
+	return res, nil
+}
+
+func scan(row sqlrow.Scanner) (*models.TaxRate, error) {
+	var (
+		r  models.TaxRate
+		to sql.NullTime
+	)
+
+	if err := row.Scan(&r.ID, &r.Region, &r.TaxClass, &r.Rate, &r.EffectiveFrom, &to); err != nil {
+		return nil, err
+	}
+
+	if to.Valid {
+		r.EffectiveTo = &to.Time
+	}
+
+	return &r, nil
+}
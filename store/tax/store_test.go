@@ -0,0 +1,192 @@
+package tax
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+	"time"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+var (
+	columns = []string{"id", "region", "tax_class", "rate", "effective_from", "effective_to"}
+	from    = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to      = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func Test_GetAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		Region         string
+		ExpectedResult []models.TaxRate
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.TaxRate{
+				{ID: 1, Region: "US-OH", TaxClass: "food", Rate: 0, EffectiveFrom: from},
+				{ID: 2, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from, EffectiveTo: &to},
+			},
+			MockCall: mock.ExpectQuery("FROM tax_rates ORDER BY region, tax_class, effective_from").WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow(1, "US-OH", "food", 0, from, nil).
+					AddRow(2, "US-OH", "standard", 5.75, from, to)),
+		},
+		{
+			Desc:           "Success: region",
+			Region:         "US-CA",
+			ExpectedResult: []models.TaxRate{{ID: 3, Region: "US-CA", TaxClass: "standard", Rate: 7.25, EffectiveFrom: from}},
+			MockCall: mock.ExpectQuery("FROM tax_rates WHERE region=\\$1 ORDER BY").WithArgs("US-CA").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "US-CA", "standard", 7.25, from, nil)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM tax_rates").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetAll(ctx, test.Region)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetEffective(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	at := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("WITH t AS \\(SELECT COALESCE\\(\\$2, now\\(\\)\\) AS at\\).*FROM tax_rates WHERE region=\\$1 AND").
+		WithArgs("US-OH", &at).WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "US-OH", "standard", 5.75, from, to))
+	mock.ExpectQuery("FROM tax_rates").WithArgs("US-OH", nil).WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.GetEffective(ctx, "US-OH", &at)
+
+	assert.Equal(t, []models.TaxRate{{ID: 2, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from, EffectiveTo: &to}}, res)
+	assert.NoError(t, err)
+
+	res, err = s.GetEffective(ctx, "US-OH", nil)
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetByID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.TaxRate
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: &models.TaxRate{ID: 2, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from},
+			MockCall: mock.ExpectQuery("FROM tax_rates WHERE id=\\$1").WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "US-OH", "standard", 5.75, from, nil)),
+		},
+		{
+			Desc:        "Failure: not found",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("FROM tax_rates").WithArgs(2).WillReturnError(sql.ErrNoRows),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("FROM tax_rates").WithArgs(2).WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByID(ctx, 2)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	rate := &models.TaxRate{Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from, EffectiveTo: &to}
+
+	mock.ExpectQuery("INSERT INTO tax_rates\\(region, tax_class, rate, effective_from, effective_to\\)").
+		WithArgs("US-OH", "standard", 5.75, from, &to).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery("INSERT INTO tax_rates").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.Create(ctx, rate)
+
+	assert.Equal(t, &models.TaxRate{ID: 4, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from, EffectiveTo: &to}, res)
+	assert.NoError(t, err)
+
+	res, err = s.Create(ctx, &models.TaxRate{Region: "US-OH", TaxClass: "food", EffectiveFrom: from})
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Update(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	rate := &models.TaxRate{ID: 4, Region: "US-OH", TaxClass: "standard", Rate: 5.75, EffectiveFrom: from}
+
+	mock.ExpectExec("UPDATE tax_rates SET region=\\$1, tax_class=\\$2, rate=\\$3, effective_from=\\$4, effective_to=\\$5 WHERE id=\\$6").
+		WithArgs("US-OH", "standard", 5.75, from, nil, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tax_rates").WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.Update(ctx, rate)
+
+	assert.Equal(t, rate, res)
+	assert.NoError(t, err)
+
+	res, err = s.Update(ctx, rate)
+
+	assert.Nil(t, res)
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectExec("DELETE FROM tax_rates WHERE id=\\$1").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM tax_rates").WithArgs(4).WillReturnError(errors.Error("DB Error"))
+
+	assert.NoError(t, s.Delete(ctx, 4))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, s.Delete(ctx, 4))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
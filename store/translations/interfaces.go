@@ -0,0 +1,13 @@
+package translations
+
+import (
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+)
+
+type TranslationStore interface {
+	GetByProductID(ctx *krogo.Context, productID string, locales ...string) ([]models.Translation, error)
+	GetByProductIDs(ctx *krogo.Context, productIDs []string, locales ...string) (map[string][]models.Translation, error)
+	Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error)
+	Delete(ctx *krogo.Context, productID, variantID, locale string) error
+}
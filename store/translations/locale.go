@@ -0,0 +1,175 @@
+package translations
+
+import (
+	"practice-app/models"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BaseLocale is the language of the text stored on products and variants themselves. It never needs a translation.
+const BaseLocale = "en"
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8}){0,2}$`)
+
+// Normalize returns the canonical form of a language tag: a lower case language, an upper case region and a
+// title case script, e.g. es_mx becomes es-MX and zh-hant-tw becomes zh-Hant-TW.
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+
+	if !localePattern.MatchString(tag) {
+		return "", false
+	}
+
+	parts := strings.Split(tag, "-")
+
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "-"), true
+}
+
+// Fallbacks lists a locale followed by the more general locales it falls back to, e.g. es-MX then es.
+// The base locale and anything after it is left out, since the stored text already covers it.
+func Fallbacks(locale string) []string {
+	var res []string
+
+	for locale != "" && locale != BaseLocale {
+		res = append(res, locale)
+
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+
+		locale = locale[:i]
+	}
+
+	return res
+}
+
+// Chain turns an Accept-Language header into the locales to look translations up in, most preferred first.
+// Each requested locale is followed by its fallbacks, so "es-MX, fr;q=0.5" gives es-MX, es, fr. The chain ends
+// where the base locale is accepted, and is empty when the client asked for nothing but the base language.
+func Chain(acceptLanguage string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var tags []weighted
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+
+		locale, ok := Normalize(fields[0])
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > 0 {
+			tags = append(tags, weighted{locale: locale, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	var chain []string
+
+	for _, t := range tags {
+		for _, l := range Fallbacks(t.locale) {
+			if !contains(chain, l) {
+				chain = append(chain, l)
+			}
+		}
+
+		// every locale past this one would only be consulted after the base text, which always exists
+		if t.locale == BaseLocale || strings.HasPrefix(t.locale, BaseLocale+"-") {
+			break
+		}
+	}
+
+	return chain
+}
+
+// Resolve picks, field by field, the first translation of a product (variantID "") or variant along a chain.
+// Fields left empty have no translation and keep their base text.
+func Resolve(translations []models.Translation, variantID string, chain []string) models.Translation {
+	res := models.Translation{VariantID: variantID}
+
+	for _, locale := range chain {
+		for i := range translations {
+			t := translations[i]
+
+			if t.VariantID != variantID || t.Locale != locale {
+				continue
+			}
+
+			if res.Name == "" {
+				res.Name = t.Name
+			}
+
+			if res.Details == "" {
+				res.Details = t.Details
+			}
+		}
+	}
+
+	return res
+}
+
+// Localize replaces the text of a product and its variants with its translation along a chain.
+func Localize(p *models.ProductWithVariants, translations []models.Translation, chain []string) {
+	p.Name, p.Details = apply(Resolve(translations, "", chain), p.Name, p.Details)
+
+	for i := range p.Variant {
+		v := &p.Variant[i]
+		v.Name, v.Details = apply(Resolve(translations, v.ID, chain), v.Name, v.Details)
+	}
+}
+
+// LocalizeVariant replaces the text of a single variant with its translation along a chain.
+func LocalizeVariant(v *models.Variant, translations []models.Translation, chain []string) {
+	v.Name, v.Details = apply(Resolve(translations, v.ID, chain), v.Name, v.Details)
+}
+
+func apply(t models.Translation, name, details string) (string, string) {
+	if t.Name != "" {
+		name = t.Name
+	}
+
+	if t.Details != "" {
+		details = t.Details
+	}
+
+	return name, details
+}
+
+func contains(locales []string, locale string) bool {
+	for _, l := range locales {
+		if l == locale {
+			return true
+		}
+	}
+
+	return false
+}
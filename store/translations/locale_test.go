@@ -0,0 +1,80 @@
+package translations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	testcases := []struct {
+		Desc     string
+		Tag      string
+		Expected string
+		OK       bool
+	}{
+		{Desc: "language", Tag: "ES", Expected: "es", OK: true},
+		{Desc: "language and region", Tag: "es_mx", Expected: "es-MX", OK: true},
+		{Desc: "script and region", Tag: "zh-hant-tw", Expected: "zh-Hant-TW", OK: true},
+		{Desc: "numeric region", Tag: "es-419", Expected: "es-419", OK: true},
+		{Desc: "wildcard", Tag: "*"},
+		{Desc: "garbage", Tag: "spanish please"},
+	}
+
+	for i, test := range testcases {
+		res, ok := Normalize(test.Tag)
+
+		assert.Equalf(t, test.Expected, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.OK, ok, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Chain(t *testing.T) {
+	testcases := []struct {
+		Desc     string
+		Header   string
+		Expected []string
+	}{
+		{Desc: "no header", Header: ""},
+		{Desc: "base language", Header: "en-US,en;q=0.9", Expected: []string{"en-US"}},
+		{Desc: "regional fallback", Header: "es-MX", Expected: []string{"es-MX", "es"}},
+		{Desc: "ordered by quality", Header: "fr;q=0.5, es-MX, es;q=0.8", Expected: []string{"es-MX", "es", "fr"}},
+		{Desc: "stops at base language", Header: "es-MX, en;q=0.9, fr;q=0.8", Expected: []string{"es-MX", "es"}},
+		{Desc: "rejected and invalid entries", Header: "de;q=0, *, es", Expected: []string{"es"}},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.Expected, Chain(test.Header), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Localize(t *testing.T) {
+	translations := []models.Translation{
+		{Locale: "es", Name: "Leche", Details: "Leche entera"},
+		{Locale: "es-MX", Name: "Leche de vaca"},
+		{VariantID: "1-s", Locale: "es", Name: "Chica"},
+	}
+
+	p := &models.ProductWithVariants{
+		ID:      "1",
+		Name:    "Milk",
+		Details: "Whole milk",
+		Variant: []models.VariantInfo{
+			{ID: "1-s", Name: "Small", Details: "Half gallon"},
+			{ID: "1-l", Name: "Large", Details: "Gallon"},
+		},
+	}
+
+	Localize(p, translations, []string{"es-MX", "es"})
+
+	assert.Equal(t, "Leche de vaca", p.Name)
+	assert.Equal(t, "Leche entera", p.Details)
+	assert.Equal(t, models.VariantInfo{ID: "1-s", Name: "Chica", Details: "Half gallon"}, p.Variant[0])
+	assert.Equal(t, models.VariantInfo{ID: "1-l", Name: "Large", Details: "Gallon"}, p.Variant[1])
+
+	v := &models.Variant{ID: "1-s", ProductID: "1", Name: "Small", Details: "Half gallon"}
+
+	LocalizeVariant(v, translations, []string{"es"})
+
+	assert.Equal(t, &models.Variant{ID: "1-s", ProductID: "1", Name: "Chica", Details: "Half gallon"}, v)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package translations is a generated GoMock package.
+package translations
+
+import (
+	models "practice-app/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	krogo "github.com/krogertechnology/krogo/pkg/krogo"
+)
+
+// MockTranslationStore is a mock of TranslationStore interface.
+type MockTranslationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationStoreMockRecorder
+}
+
+// MockTranslationStoreMockRecorder is the mock recorder for MockTranslationStore.
+type MockTranslationStoreMockRecorder struct {
+	mock *MockTranslationStore
+}
+
+// NewMockTranslationStore creates a new mock instance.
+func NewMockTranslationStore(ctrl *gomock.Controller) *MockTranslationStore {
+	mock := &MockTranslationStore{ctrl: ctrl}
+	mock.recorder = &MockTranslationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationStore) EXPECT() *MockTranslationStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTranslationStore) Delete(ctx *krogo.Context, productID, variantID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, productID, variantID, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTranslationStoreMockRecorder) Delete(ctx, productID, variantID, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTranslationStore)(nil).Delete), ctx, productID, variantID, locale)
+}
+
+// GetByProductID mocks base method.
+func (m *MockTranslationStore) GetByProductID(ctx *krogo.Context, productID string, locales ...string) ([]models.Translation, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, productID}
+	for _, a := range locales {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByProductID", varargs...)
+	ret0, _ := ret[0].([]models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductID indicates an expected call of GetByProductID.
+func (mr *MockTranslationStoreMockRecorder) GetByProductID(ctx, productID interface{}, locales ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, productID}, locales...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductID", reflect.TypeOf((*MockTranslationStore)(nil).GetByProductID), varargs...)
+}
+
+// GetByProductIDs mocks base method.
+func (m *MockTranslationStore) GetByProductIDs(ctx *krogo.Context, productIDs []string, locales ...string) (map[string][]models.Translation, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, productIDs}
+	for _, a := range locales {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetByProductIDs", varargs...)
+	ret0, _ := ret[0].(map[string][]models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProductIDs indicates an expected call of GetByProductIDs.
+func (mr *MockTranslationStoreMockRecorder) GetByProductIDs(ctx, productIDs interface{}, locales ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, productIDs}, locales...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProductIDs", reflect.TypeOf((*MockTranslationStore)(nil).GetByProductIDs), varargs...)
+}
+
+// Set mocks base method.
+func (m *MockTranslationStore) Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, productID, translation)
+	ret0, _ := ret[0].(*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTranslationStoreMockRecorder) Set(ctx, productID, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTranslationStore)(nil).Set), ctx, productID, translation)
+}
@@ -0,0 +1,142 @@
+package translations
+
+import (
+	"database/sql"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"practice-app/models"
+	"practice-app/store/audit"
+	"strconv"
+	"strings"
+)
+
+type Store struct {
+}
+
+func New() *Store {
+	return &Store{}
+}
+
+// GetByProductID returns the translations of a product and of its variants, product-level rows first.
+// When locales are given only the translations into those locales are read.
+func (s *Store) GetByProductID(ctx *krogo.Context, productID string, locales ...string) ([]models.Translation, error) {
+	query := "SELECT variant_id, locale, name, details FROM translations WHERE product_id=$1"
+	values := []interface{}{productID}
+
+	if len(locales) > 0 {
+		query += " AND locale IN (" + placeholders(&values, locales) + ")"
+	}
+
+	query += " ORDER BY variant_id, locale"
+
+	var res []models.Translation
+
+	rows, err := ctx.DB().QueryContext(ctx, query, values...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.Translation
+
+		if err = rows.Scan(&t.VariantID, &t.Locale, &t.Name, &t.Details); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+// GetByProductIDs returns the translations of several products and of their variants at once, by product, each
+// ordered as GetByProductID orders them. When locales are given only the translations into those locales are read.
+func (s *Store) GetByProductIDs(ctx *krogo.Context, productIDs []string, locales ...string) (map[string][]models.Translation, error) {
+	var values []interface{}
+
+	query := "SELECT product_id, variant_id, locale, name, details FROM translations WHERE product_id IN (" +
+		placeholders(&values, productIDs) + ")"
+
+	if len(locales) > 0 {
+		query += " AND locale IN (" + placeholders(&values, locales) + ")"
+	}
+
+	query += " ORDER BY product_id, variant_id, locale"
+
+	rows, err := ctx.DB().QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	res := make(map[string][]models.Translation)
+
+	for rows.Next() {
+		var (
+			productID string
+			t         models.Translation
+		)
+
+		if err = rows.Scan(&productID, &t.VariantID, &t.Locale, &t.Name, &t.Details); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		res[productID] = append(res[productID], t)
+	}
+
+	return res, nil
+}
+
+// placeholders adds items to the values of a query and returns their placeholders, separated by commas.
+func placeholders(values *[]interface{}, items []string) string {
+	res := make([]string, len(items))
+
+	for i, item := range items {
+		*values = append(*values, item)
+		res[i] = "$" + strconv.Itoa(len(*values))
+	}
+
+	return strings.Join(res, ",")
+}
+
+// Set stores the translation of a product, or of one of its variants, replacing the one already held for the locale.
+func (s *Store) Set(ctx *krogo.Context, productID string, translation *models.Translation) (*models.Translation, error) {
+	query := "INSERT INTO translations(product_id, variant_id, locale, name, details) VALUES ($1,$2,$3,$4,$5) " +
+		"ON CONFLICT (product_id, variant_id, locale) DO UPDATE SET name=EXCLUDED.name, details=EXCLUDED.details"
+
+	_, err := audit.Exec(ctx, models.AuditUpdate, change(productID, translation.VariantID, translation.Locale), query, productID,
+		translation.VariantID, translation.Locale, translation.Name, translation.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+func (s *Store) Delete(ctx *krogo.Context, productID, variantID, locale string) error {
+	_, err := audit.Exec(ctx, models.AuditDelete, change(productID, variantID, locale),
+		"DELETE FROM translations WHERE product_id=$1 AND variant_id=$2 AND locale=$3", productID, variantID, locale)
+
+	return err
+}
+
+// change is the audit record of a change to the translation of a product, or of one of its variants when variantID is
+// set, into a locale.
+func change(productID, variantID, locale string) *audit.Change {
+	entityID := productID
+	if variantID != "" {
+		entityID = variantID
+	}
+
+	return &audit.Change{Entity: "translations", EntityID: entityID, ProductID: productID,
+		Query: audit.Rows("translations", "t.product_id=$1 AND t.variant_id=$2 AND t.locale=$3"),
+		Args:  []interface{}{productID, variantID, locale}}
+}
@@ -0,0 +1,196 @@
+package translations
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/krogertechnology/krogo/pkg/datastore"
+	"github.com/krogertechnology/krogo/pkg/errors"
+	"github.com/krogertechnology/krogo/pkg/krogo"
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
+	ctx := krogo.NewContext(nil, nil, krogo.New())
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Errorf("error while mocking db %v", err)
+	}
+
+	ctx.DataStore = datastore.DataStore{ORM: db}
+	ctx.Context = context.Background()
+
+	return ctx, mock
+}
+
+func Test_GetByProductID(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"variant_id", "locale", "name", "details"}
+
+	testcases := []struct {
+		Desc           string
+		Locales        []string
+		ExpectedResult []models.Translation
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success: all locales",
+			ExpectedResult: []models.Translation{
+				{Locale: "es", Name: "Leche", Details: "Leche entera"},
+				{VariantID: "1-s", Locale: "es", Name: "Pequeño"},
+			},
+			MockCall: mock.ExpectQuery("SELECT variant_id, locale, name, details FROM translations WHERE product_id=\\$1 ORDER BY").
+				WithArgs("1").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("", "es", "Leche", "Leche entera").
+				AddRow("1-s", "es", "Pequeño", "")),
+		},
+		{
+			Desc:           "Success: filtered on locales",
+			Locales:        []string{"es-MX", "es"},
+			ExpectedResult: []models.Translation{{Locale: "es-MX", Name: "Leche"}},
+			MockCall: mock.ExpectQuery("WHERE product_id=\\$1 AND locale IN \\(\\$2,\\$3\\)").
+				WithArgs("1", "es-MX", "es").WillReturnRows(sqlmock.NewRows(columns).AddRow("", "es-MX", "Leche", "")),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductID(ctx, "1", test.Locales...)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByProductIDs(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"product_id", "variant_id", "locale", "name", "details"}
+
+	testcases := []struct {
+		Desc           string
+		Locales        []string
+		ExpectedResult map[string][]models.Translation
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:    "Success",
+			Locales: []string{"es-MX", "es"},
+			ExpectedResult: map[string][]models.Translation{
+				"1": {{Locale: "es", Name: "Leche"}, {VariantID: "1-s", Locale: "es", Name: "Pequeño"}},
+				"2": {{Locale: "es-MX", Name: "Pan"}},
+			},
+			MockCall: mock.ExpectQuery("SELECT product_id, variant_id, locale, name, details FROM translations "+
+				"WHERE product_id IN \\(\\$1,\\$2\\) AND locale IN \\(\\$3,\\$4\\) ORDER BY product_id, variant_id, locale").
+				WithArgs("1", "2", "es-MX", "es").WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "", "es", "Leche", "").
+				AddRow("1", "1-s", "es", "Pequeño", "").
+				AddRow("2", "", "es-MX", "Pan", "")),
+		},
+		{
+			Desc:           "Success: none",
+			ExpectedResult: map[string][]models.Translation{},
+			MockCall: mock.ExpectQuery("WHERE product_id IN \\(\\$1,\\$2\\) ORDER BY").WithArgs("1", "2").
+				WillReturnRows(sqlmock.NewRows(columns)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1", "2").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByProductIDs(ctx, []string{"1", "2"}, test.Locales...)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Set(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	translation := &models.Translation{VariantID: "1-s", Locale: "es", Name: "Pequeño"}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Translation
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: translation,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM translations t").WithArgs("1", "1-s", "es").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO translations.* ON CONFLICT").WithArgs("1", "1-s", "es", "Pequeño", "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("FROM translations t").WithArgs("1", "1-s", "es").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO audit_log").WithArgs("update", "translations", "1-s", "1", "", "", `{}`, `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FROM translations t").WithArgs("1", "1-s", "es").
+					WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+				mock.ExpectExec("INSERT INTO translations").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.Set(ctx, "1", translation)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.NoErrorf(t, mock.ExpectationsWereMet(), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM translations t").WithArgs("1", "", "es").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("DELETE FROM translations").WithArgs("1", "", "es").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM translations t").WithArgs("1", "", "es").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("INSERT INTO audit_log").WithArgs("delete", "translations", "1", "1", "", "", `{}`, `{}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM translations t").WithArgs("1", "", "es").
+		WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`{}`)))
+	mock.ExpectExec("DELETE FROM translations").WithArgs("1", "", "es").WillReturnError(errors.Error("DB Error"))
+	mock.ExpectRollback()
+
+	assert.NoError(t, s.Delete(ctx, "1", "", "es"))
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, s.Delete(ctx, "1", "", "es"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,10 +3,21 @@ package variants
 import (
 	"github.com/krogertechnology/krogo/pkg/krogo"
 	"practice-app/models"
+	"time"
 )
 
 type VariantStore interface {
 	GetByID(ctx *krogo.Context, id, vID string) (*models.Variant, error)
+	Lookup(ctx *krogo.Context, id string) (*models.Variant, error)
+	GetBySKU(ctx *krogo.Context, sku string) (*models.Variant, error)
+	GetByGTIN(ctx *krogo.Context, gtin string) (*models.Variant, error)
 	Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error)
+	CreateAll(ctx *krogo.Context, variants []*models.Variant) ([]models.Variant, error)
+	SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) error
+	SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) error
+	SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) error
 	GetVariantData(ctx *krogo.Context, productID string) ([]models.VariantInfo, error)
+	GetListedVariants(ctx *krogo.Context, productID string, statuses []string) ([]models.VariantInfo, error)
+	GetVariantDataAsOf(ctx *krogo.Context, productID string, at time.Time) ([]models.VariantInfo, error)
+	GetOptionKeys(ctx *krogo.Context, productID string) ([]string, error)
 }
@@ -7,6 +7,7 @@ package variants
 import (
 	models "practice-app/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	krogo "github.com/krogertechnology/krogo/pkg/krogo"
@@ -50,6 +51,36 @@ func (mr *MockVariantStoreMockRecorder) Create(ctx, variant interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVariantStore)(nil).Create), ctx, variant)
 }
 
+// CreateAll mocks base method.
+func (m *MockVariantStore) CreateAll(ctx *krogo.Context, variants []*models.Variant) ([]models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAll", ctx, variants)
+	ret0, _ := ret[0].([]models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAll indicates an expected call of CreateAll.
+func (mr *MockVariantStoreMockRecorder) CreateAll(ctx, variants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAll", reflect.TypeOf((*MockVariantStore)(nil).CreateAll), ctx, variants)
+}
+
+// GetByGTIN mocks base method.
+func (m *MockVariantStore) GetByGTIN(ctx *krogo.Context, gtin string) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGTIN", ctx, gtin)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGTIN indicates an expected call of GetByGTIN.
+func (mr *MockVariantStoreMockRecorder) GetByGTIN(ctx, gtin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGTIN", reflect.TypeOf((*MockVariantStore)(nil).GetByGTIN), ctx, gtin)
+}
+
 // GetByID mocks base method.
 func (m *MockVariantStore) GetByID(ctx *krogo.Context, id, vID string) (*models.Variant, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +96,51 @@ func (mr *MockVariantStoreMockRecorder) GetByID(ctx, id, vID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVariantStore)(nil).GetByID), ctx, id, vID)
 }
 
+// GetBySKU mocks base method.
+func (m *MockVariantStore) GetBySKU(ctx *krogo.Context, sku string) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKU indicates an expected call of GetBySKU.
+func (mr *MockVariantStoreMockRecorder) GetBySKU(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockVariantStore)(nil).GetBySKU), ctx, sku)
+}
+
+// GetListedVariants mocks base method.
+func (m *MockVariantStore) GetListedVariants(ctx *krogo.Context, productID string, statuses []string) ([]models.VariantInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListedVariants", ctx, productID, statuses)
+	ret0, _ := ret[0].([]models.VariantInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListedVariants indicates an expected call of GetListedVariants.
+func (mr *MockVariantStoreMockRecorder) GetListedVariants(ctx, productID, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListedVariants", reflect.TypeOf((*MockVariantStore)(nil).GetListedVariants), ctx, productID, statuses)
+}
+
+// GetOptionKeys mocks base method.
+func (m *MockVariantStore) GetOptionKeys(ctx *krogo.Context, productID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionKeys", ctx, productID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionKeys indicates an expected call of GetOptionKeys.
+func (mr *MockVariantStoreMockRecorder) GetOptionKeys(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionKeys", reflect.TypeOf((*MockVariantStore)(nil).GetOptionKeys), ctx, productID)
+}
+
 // GetVariantData mocks base method.
 func (m *MockVariantStore) GetVariantData(ctx *krogo.Context, productID string) ([]models.VariantInfo, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +155,75 @@ func (mr *MockVariantStoreMockRecorder) GetVariantData(ctx, productID interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantData", reflect.TypeOf((*MockVariantStore)(nil).GetVariantData), ctx, productID)
 }
+
+// GetVariantDataAsOf mocks base method.
+func (m *MockVariantStore) GetVariantDataAsOf(ctx *krogo.Context, productID string, at time.Time) ([]models.VariantInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantDataAsOf", ctx, productID, at)
+	ret0, _ := ret[0].([]models.VariantInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantDataAsOf indicates an expected call of GetVariantDataAsOf.
+func (mr *MockVariantStoreMockRecorder) GetVariantDataAsOf(ctx, productID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantDataAsOf", reflect.TypeOf((*MockVariantStore)(nil).GetVariantDataAsOf), ctx, productID, at)
+}
+
+// Lookup mocks base method.
+func (m *MockVariantStore) Lookup(ctx *krogo.Context, id string) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, id)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockVariantStoreMockRecorder) Lookup(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockVariantStore)(nil).Lookup), ctx, id)
+}
+
+// SetAllergens mocks base method.
+func (m *MockVariantStore) SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAllergens", ctx, id, pID, allergens)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAllergens indicates an expected call of SetAllergens.
+func (mr *MockVariantStoreMockRecorder) SetAllergens(ctx, id, pID, allergens interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllergens", reflect.TypeOf((*MockVariantStore)(nil).SetAllergens), ctx, id, pID, allergens)
+}
+
+// SetMeasurements mocks base method.
+func (m *MockVariantStore) SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMeasurements", ctx, id, pID, measurements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMeasurements indicates an expected call of SetMeasurements.
+func (mr *MockVariantStoreMockRecorder) SetMeasurements(ctx, id, pID, measurements interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMeasurements", reflect.TypeOf((*MockVariantStore)(nil).SetMeasurements), ctx, id, pID, measurements)
+}
+
+// SetNutrition mocks base method.
+func (m *MockVariantStore) SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNutrition", ctx, id, pID, panel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNutrition indicates an expected call of SetNutrition.
+func (mr *MockVariantStoreMockRecorder) SetNutrition(ctx, id, pID, panel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNutrition", reflect.TypeOf((*MockVariantStore)(nil).SetNutrition), ctx, id, pID, panel)
+}
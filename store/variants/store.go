@@ -2,9 +2,18 @@ package variants
 
 import (
 	"database/sql"
+	"encoding/json"
 	"github.com/krogertechnology/krogo/pkg/errors"
 	"github.com/krogertechnology/krogo/pkg/krogo"
 	"practice-app/models"
+	"practice-app/nutrition"
+	"practice-app/store/audit"
+	"practice-app/store/revisions"
+	"practice-app/units"
+	"practice-app/vocabulary"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Store struct {
@@ -14,43 +23,136 @@ func New() *Store {
 	return &Store{}
 }
 
+var selectQuery = "SELECT v.id, v.product_id, v.variant_name, v.variant_details, COALESCE(v.sku, ''), COALESCE(v.gtin, ''), " +
+	"COALESCE(i.on_hand - i.reserved, 0), COALESCE(v.price_cents, 0), v.options, " + vocabulary.Aggregate("v.product_id", "v.id") +
+	", v.measurements, v.nutrition, v.allergens, v.status, v.created_at, v.created_by, v.updated_at, v.updated_by " +
+	"FROM variants v LEFT JOIN inventory i ON i.variant_id = v.id "
+
 func (s *Store) GetByID(ctx *krogo.Context, id, pID string) (*models.Variant, error) {
-	query := "SELECT id, product_id, variant_name, variant_details FROM variants WHERE id=$1 AND product_id=$2"
+	return s.get(ctx, "WHERE v.id=$1 AND v.product_id=$2", id, pID)
+}
+
+// Lookup finds a variant by its ID alone, for callers such as bundles that refer to variants of any product.
+func (s *Store) Lookup(ctx *krogo.Context, id string) (*models.Variant, error) {
+	return s.get(ctx, "WHERE v.id=$1", id)
+}
 
-	var v models.Variant
+// GetBySKU looks a variant up by its SKU, which is unique across all products.
+func (s *Store) GetBySKU(ctx *krogo.Context, sku string) (*models.Variant, error) {
+	return s.get(ctx, "WHERE v.sku=$1", sku)
+}
 
-	err := ctx.DB().QueryRowContext(ctx, query, id, pID).
-		Scan(&v.ID, &v.ProductID, &v.Name, &v.Details)
+// GetByGTIN looks a variant up by its GTIN, stored in its 14-digit form.
+func (s *Store) GetByGTIN(ctx *krogo.Context, gtin string) (*models.Variant, error) {
+	return s.get(ctx, "WHERE v.gtin=$1", gtin)
+}
 
+// insertQuery creates a variant; its arguments are the user creating it followed by insertArgs.
+var insertQuery = "INSERT INTO variants(created_by, id, product_id, variant_name, variant_details, sku, gtin, price_cents, " +
+	"options, option_key, measurements, nutrition, allergens, status) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)"
+
+// Create inserts a variant by the user making the request and returns it as stored, with the times the database set.
+func (s *Store) Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error) {
+	err := update(ctx, models.AuditCreate, variant.ID, variant.ProductID, insertQuery, insertArgs(variant)...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, sql.ErrNoRows
+		return nil, err
+	}
+
+	return s.GetByID(ctx, variant.ID, variant.ProductID)
+}
+
+// CreateAll creates several variants in one transaction, so that either all of them are created or none is.
+func (s *Store) CreateAll(ctx *krogo.Context, variants []*models.Variant) ([]models.Variant, error) {
+	actor := ctx.Header(models.UserHeader)
+
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	for _, v := range variants {
+		if err = write(ctx, tx, actor, models.AuditCreate, v.ID, v.ProductID, insertQuery, insertArgs(v)...); err != nil {
+			_ = tx.Rollback()
+
+			return nil, err
 		}
+	}
 
+	if err = tx.Commit(); err != nil {
 		return nil, errors.DB{Err: err}
 	}
 
-	return &v, nil
-}
+	created := make([]models.Variant, 0, len(variants))
 
-func (s *Store) Create(ctx *krogo.Context, variant *models.Variant) (*models.Variant, error) {
-	query := "INSERT INTO variants(id, product_id, variant_name, variant_details) VALUES ($1,$2,$3,$4)"
+	for _, v := range variants {
+		variant, err := s.GetByID(ctx, v.ID, v.ProductID)
+		if err != nil {
+			return nil, err
+		}
 
-	_, err := ctx.DB().ExecContext(ctx, query, variant.ID, variant.ProductID, variant.Name, variant.Details)
+		created = append(created, *variant)
+	}
 
-	if err != nil {
-		return nil, errors.DB{Err: err}
+	return created, nil
+}
+
+// insertArgs are the arguments of insertQuery after the user creating the variant.
+func insertArgs(variant *models.Variant) []interface{} {
+	var options, key interface{}
+
+	if len(variant.Options) > 0 {
+		options = OptionKey(variant.Options)
+		key = options
 	}
 
-	return variant, nil
+	return []interface{}{variant.ID, variant.ProductID, variant.Name, variant.Details, nullable(variant.SKU),
+		nullable(variant.GTIN), sql.NullInt64{Int64: variant.PriceCents, Valid: variant.PriceCents != 0}, options, key,
+		marshalMeasurements(variant.Measurements), marshalNutrition(variant.Nutrition), marshalAllergens(variant.Allergens),
+		variant.Status}
 }
 
+// infoColumns reads the variants of a product as they are listed with it.
+var infoColumns = "SELECT v.id, v.variant_name, v.variant_details, COALESCE(v.sku, ''), COALESCE(v.gtin, ''), " +
+	"COALESCE(i.on_hand - i.reserved, 0), COALESCE(v.price_cents, 0), v.options, " + vocabulary.Aggregate("v.product_id", "v.id") +
+	", v.measurements, v.nutrition, v.allergens, v.status, v.created_at, v.created_by, v.updated_at, v.updated_by "
+
+// asOfQuery reads the variants of a product from the latest revision of each made up to a point in time. Stock and
+// tags are not versioned, so they are read as they are now. Revisions made before variants kept their times and
+// actors take them from the revision itself.
+var asOfQuery = infoColumns + "FROM (SELECT DISTINCT ON (r.variant_id) (jsonb_populate_record(NULL::variants, " +
+	"jsonb_build_object('created_at', r.created_at, 'created_by', '', 'updated_at', r.created_at, 'updated_by', r.actor) " +
+	"|| r.snapshot)).* " +
+	"FROM revisions r WHERE r.product_id=$1 AND r.variant_id<>'' AND r.created_at <= $2 ORDER BY r.variant_id, r.id DESC) v " +
+	"LEFT JOIN inventory i ON i.variant_id = v.id ORDER BY v.id"
+
 func (s *Store) GetVariantData(ctx *krogo.Context, productID string) ([]models.VariantInfo, error) {
-	query := "SELECT id, variant_name, variant_details FROM variants WHERE product_id=$1"
+	return variantData(ctx, infoColumns+"FROM variants v LEFT JOIN inventory i ON i.variant_id = v.id WHERE v.product_id=$1",
+		productID)
+}
+
+// GetListedVariants reads the variants of a product that are in one of the given statuses, as they are listed with it.
+func (s *Store) GetListedVariants(ctx *krogo.Context, productID string, statuses []string) ([]models.VariantInfo, error) {
+	args := []interface{}{productID}
+	placeholders := make([]string, len(statuses))
 
+	for i, status := range statuses {
+		args = append(args, status)
+		placeholders[i] = "$" + strconv.Itoa(len(args))
+	}
+
+	return variantData(ctx, infoColumns+"FROM variants v LEFT JOIN inventory i ON i.variant_id = v.id WHERE v.product_id=$1 "+
+		"AND v.status IN ("+strings.Join(placeholders, ",")+")", args...)
+}
+
+// GetVariantDataAsOf reads the variants a product had at a point in time, as they were then.
+func (s *Store) GetVariantDataAsOf(ctx *krogo.Context, productID string, at time.Time) ([]models.VariantInfo, error) {
+	return variantData(ctx, asOfQuery, productID, at)
+}
+
+func variantData(ctx *krogo.Context, query string, args ...interface{}) ([]models.VariantInfo, error) {
 	var variantInfo []models.VariantInfo
 
-	rows, err := ctx.DB().QueryContext(ctx, query, productID)
+	rows, err := ctx.DB().QueryContext(ctx, query, args...)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -63,15 +165,267 @@ func (s *Store) GetVariantData(ctx *krogo.Context, productID string) ([]models.V
 	defer rows.Close()
 
 	for rows.Next() {
-		var v models.VariantInfo
+		var (
+			v                              models.VariantInfo
+			options                        []byte
+			tagList                        string
+			measurements, panel, allergens []byte
+		)
 
-		err = rows.Scan(&v.ID, &v.Name, &v.Details)
+		err = rows.Scan(&v.ID, &v.Name, &v.Details, &v.SKU, &v.GTIN, &v.Available, &v.PriceCents, &options, &tagList,
+			&measurements, &panel, &allergens, &v.Status, &v.CreatedAt, &v.CreatedBy, &v.UpdatedAt, &v.UpdatedBy)
 		if err != nil {
 			return nil, errors.DB{Err: err}
 		}
 
+		if v.Options, err = unmarshalOptions(options); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		v.Tags = vocabulary.Split(tagList)
+
+		if v.Measurements, err = unmarshalMeasurements(measurements); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		v.UnitPrice = units.UnitPrice(v.PriceCents, v.Measurements)
+
+		if v.Nutrition, v.Allergens, err = unmarshalNutrition(panel, allergens); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
 		variantInfo = append(variantInfo, v)
 	}
 
 	return variantInfo, nil
 }
+
+// SetMeasurements replaces the measurements of a variant.
+func (s *Store) SetMeasurements(ctx *krogo.Context, id, pID string, measurements *models.Measurements) error {
+	return update(ctx, models.AuditUpdate, id, pID, "UPDATE variants SET updated_by=$1, measurements=$2 WHERE id=$3 AND product_id=$4",
+		marshalMeasurements(measurements), id, pID)
+}
+
+// SetNutrition replaces the nutrition facts panel of a variant.
+func (s *Store) SetNutrition(ctx *krogo.Context, id, pID string, panel *models.Nutrition) error {
+	return update(ctx, models.AuditUpdate, id, pID, "UPDATE variants SET updated_by=$1, nutrition=$2 WHERE id=$3 AND product_id=$4",
+		marshalNutrition(panel), id, pID)
+}
+
+// SetAllergens replaces the allergens a variant is declared to contain.
+func (s *Store) SetAllergens(ctx *krogo.Context, id, pID string, allergens []string) error {
+	return update(ctx, models.AuditUpdate, id, pID, "UPDATE variants SET updated_by=$1, allergens=$2 WHERE id=$3 AND product_id=$4",
+		marshalAllergens(allergens), id, pID)
+}
+
+// GetOptionKeys returns the option combinations already taken by the variants of a product.
+func (s *Store) GetOptionKeys(ctx *krogo.Context, productID string) ([]string, error) {
+	query := "SELECT option_key FROM variants WHERE product_id=$1 AND option_key IS NOT NULL"
+
+	var keys []string
+
+	rows, err := ctx.DB().QueryContext(ctx, query, productID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+
+		if err = rows.Scan(&key); err != nil {
+			return nil, errors.DB{Err: err}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// OptionKey is the canonical form of an option combination that variants of a product are unique on.
+// encoding/json writes map keys in sorted order, so the same combination always yields the same key.
+func OptionKey(options map[string]string) string {
+	b, _ := json.Marshal(options)
+
+	return string(b)
+}
+
+func (s *Store) get(ctx *krogo.Context, where string, args ...interface{}) (*models.Variant, error) {
+	var (
+		v                              models.Variant
+		options                        []byte
+		tagList                        string
+		measurements, panel, allergens []byte
+	)
+
+	err := ctx.DB().QueryRowContext(ctx, selectQuery+where, args...).
+		Scan(&v.ID, &v.ProductID, &v.Name, &v.Details, &v.SKU, &v.GTIN, &v.Available, &v.PriceCents, &options, &tagList,
+			&measurements, &panel, &allergens, &v.Status, &v.CreatedAt, &v.CreatedBy, &v.UpdatedAt, &v.UpdatedBy)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+
+		return nil, errors.DB{Err: err}
+	}
+
+	if v.Options, err = unmarshalOptions(options); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	v.Tags = vocabulary.Split(tagList)
+
+	if v.Measurements, err = unmarshalMeasurements(measurements); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	v.UnitPrice = units.UnitPrice(v.PriceCents, v.Measurements)
+
+	if v.Nutrition, v.Allergens, err = unmarshalNutrition(panel, allergens); err != nil {
+		return nil, errors.DB{Err: err}
+	}
+
+	return &v, nil
+}
+
+// update changes a variant and records a revision of it by the user making the request, with an audit entry of the
+// action, in one transaction. The user is passed to query as $1, ahead of args.
+func update(ctx *krogo.Context, action, id, pID, query string, args ...interface{}) error {
+	tx, err := ctx.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return errors.DB{Err: err}
+	}
+
+	if err = write(ctx, tx, ctx.Header(models.UserHeader), action, id, pID, query, args...); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	return nil
+}
+
+// write runs one change of a variant in tx and records its revision and audit entry. The caller rolls tx back on error.
+func write(ctx *krogo.Context, tx *sql.Tx, actor, action, id, pID, query string, args ...interface{}) error {
+	if _, err := tx.ExecContext(ctx, query, append([]interface{}{actor}, args...)...); err != nil {
+		return errors.DB{Err: err}
+	}
+
+	r := models.Revision{ProductID: pID, VariantID: id, Actor: actor}
+
+	if err := revisions.Record(ctx, tx, &r); err != nil {
+		return err
+	}
+
+	return audit.Record(ctx, tx, action, &r)
+}
+
+func unmarshalOptions(b []byte) (map[string]string, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var options map[string]string
+
+	if err := json.Unmarshal(b, &options); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func marshalMeasurements(m *models.Measurements) interface{} {
+	if m == nil || *m == (models.Measurements{}) {
+		return nil
+	}
+
+	b, _ := json.Marshal(m)
+
+	return string(b)
+}
+
+func unmarshalMeasurements(b []byte) (*models.Measurements, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var m models.Measurements
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// marshalNutrition stores a panel without its daily values, which are computed again on every read.
+func marshalNutrition(panel *models.Nutrition) interface{} {
+	if panel == nil {
+		return nil
+	}
+
+	stored := *panel
+	stored.Nutrients = make([]models.Nutrient, len(panel.Nutrients))
+
+	for i, n := range panel.Nutrients {
+		n.DailyValue = nil
+		stored.Nutrients[i] = n
+	}
+
+	b, _ := json.Marshal(stored)
+
+	return string(b)
+}
+
+func marshalAllergens(allergens []string) string {
+	if len(allergens) == 0 {
+		return "[]"
+	}
+
+	b, _ := json.Marshal(allergens)
+
+	return string(b)
+}
+
+func unmarshalNutrition(panel, allergens []byte) (*models.Nutrition, []string, error) {
+	var (
+		n    *models.Nutrition
+		list []string
+	)
+
+	if len(panel) > 0 {
+		if err := json.Unmarshal(panel, &n); err != nil {
+			return nil, nil, err
+		}
+
+		nutrition.Complete(n)
+	}
+
+	if len(allergens) > 0 {
+		if err := json.Unmarshal(allergens, &list); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(list) == 0 {
+		list = nil
+	}
+
+	return n, list, nil
+}
+
+func nullable(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"practice-app/models"
 	"testing"
+	"time"
 )
 
 func getSqlMock(t *testing.T) (*krogo.Context, sqlmock.Sqlmock) {
@@ -30,6 +31,8 @@ func Test_GetByID(t *testing.T) {
 	ctx, mock := getSqlMock(t)
 	s := New()
 
+	sodiumDV := 20
+
 	testcases := []struct {
 		Desc           string
 		ID             string
@@ -47,11 +50,54 @@ func Test_GetByID(t *testing.T) {
 				Name:      "variant_1",
 				ProductID: "1",
 				Details:   "details",
+				Status:    "active",
+				Available: 5,
 			},
 			ExpectedErr: nil,
 			MockCall: mock.ExpectQuery("SELECT").WithArgs("1", "1").WillReturnRows(
-				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details"}).
-					AddRow("1", "1", "variant_1", "details")),
+				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("1", "1", "variant_1", "details", "", "", 5, 0, nil, "", nil, nil, []byte("[]"), "active", time.Time{}, "", time.Time{}, "")),
+		},
+		{
+			Desc: "Success: with options",
+			ID:   "2",
+			pID:  "1",
+			ExpectedResult: &models.Variant{
+				ID:        "2",
+				Name:      "variant_2",
+				ProductID: "1",
+				Details:   "details",
+				Status:    "active",
+				Options:   map[string]string{"Color": "Red", "Size": "S"},
+			},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("2", "1").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("2", "1", "variant_2", "details", "", "", 0, 0, []byte(`{"Color":"Red","Size":"S"}`), "", nil, nil, []byte("[]"), "active", time.Time{}, "", time.Time{}, "")),
+		},
+		{
+			Desc: "Success: with nutrition and allergens",
+			ID:   "3",
+			pID:  "1",
+			ExpectedResult: &models.Variant{
+				ID:        "3",
+				Name:      "variant_3",
+				ProductID: "1",
+				Details:   "details",
+				Status:    "active",
+				Nutrition: &models.Nutrition{
+					ServingSize: models.Measurement{Value: 28, Unit: "g"},
+					Calories:    160,
+					Nutrients:   []models.Nutrient{{Name: "sodium", Amount: 460, Unit: "mg", DailyValue: &sodiumDV}},
+				},
+				Allergens: []string{"peanut"},
+			},
+			ExpectedErr: nil,
+			MockCall: mock.ExpectQuery("SELECT").WithArgs("3", "1").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("3", "1", "variant_3", "details", "", "", 0, 0, nil, "", nil,
+						[]byte(`{"serving_size":{"value":28,"unit":"g"},"calories":160,"nutrients":[{"name":"sodium","amount":460,"unit":"mg"}]}`),
+						[]byte(`["peanut"]`), "active", time.Time{}, "", time.Time{}, "")),
 		},
 		{
 			Desc:           "sql no rows",
@@ -79,6 +125,92 @@ func Test_GetByID(t *testing.T) {
 	}
 }
 
+func Test_Lookup(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Variant{
+				ID:         "1-s",
+				Name:       "variant_1",
+				ProductID:  "1",
+				Details:    "details",
+				Available:  3,
+				Status:     "active",
+				PriceCents: 1299,
+			},
+			MockCall: mock.ExpectQuery("SELECT .* WHERE v.id=\\$1$").WithArgs("1-s").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("1-s", "1", "variant_1", "details", "", "", 3, 1299, nil, "", nil, nil, []byte("[]"), "active", time.Time{}, "", time.Time{}, "")),
+		},
+		{
+			Desc:        "sql no rows",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1-s").WillReturnError(sql.ErrNoRows),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.Lookup(ctx, "1-s")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetByGTIN(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult *models.Variant
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: &models.Variant{
+				ID:        "1",
+				Name:      "variant_1",
+				ProductID: "1",
+				Details:   "details",
+				Status:    "active",
+				GTIN:      "00012345678905",
+			},
+			MockCall: mock.ExpectQuery("SELECT .* WHERE v.gtin=").WithArgs("00012345678905").WillReturnRows(
+				sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("1", "1", "variant_1", "details", "", "00012345678905", 0, 0, nil, "", nil, nil, []byte("[]"), "active", time.Time{}, "", time.Time{}, "")),
+		},
+		{
+			Desc:        "sql no rows",
+			ExpectedErr: sql.ErrNoRows,
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("00012345678905").WillReturnError(sql.ErrNoRows),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetByGTIN(ctx, "00012345678905")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+// revision is the row returned when a revision of a variant is recorded.
+var at = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func revision() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "snapshot", "created_at"}).AddRow(1, []byte(`{}`), at)
+}
+
 func Test_Create(t *testing.T) {
 	ctx, mock := getSqlMock(t)
 	s := New()
@@ -90,7 +222,7 @@ func Test_Create(t *testing.T) {
 		Body           *models.Variant
 		ExpectedResult *models.Variant
 		ExpectedErr    error
-		MockCall       *sqlmock.ExpectedExec
+		MockCalls      func()
 	}{
 		{
 			Desc: "Success",
@@ -100,15 +232,82 @@ func Test_Create(t *testing.T) {
 				Name:      "variant_1",
 				ProductID: "1",
 				Details:   "details",
+				Status:    "active",
 			},
 			ExpectedResult: &models.Variant{
 				ID:        "1",
 				Name:      "variant_1",
 				ProductID: "1",
 				Details:   "details",
+				Status:    "active",
+				CreatedAt: at,
+				CreatedBy: "u1",
+				UpdatedAt: at,
+				UpdatedBy: "u1",
+			},
+			ExpectedErr: nil,
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO variants\\(created_by, id, product_id, ").WithArgs("", "1", "1", "variant_1", "details", sql.NullString{}, sql.NullString{}, sql.NullInt64{}, nil, nil, nil, nil, "[]", "active").
+					WillReturnResult(sqlmock.NewResult(1, 1)).WillReturnError(nil)
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT .* FROM variants v").WithArgs("1", "1").WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("1", "1", "variant_1", "details", "", "", 0, 0, nil, "", nil, nil, []byte("[]"), "active", at, "u1", at, "u1"))
+			},
+		},
+		{
+			Desc: "Success: with identifiers and options",
+			ID:   "2",
+			Body: &models.Variant{
+				ID:        "2",
+				Name:      "variant_2",
+				ProductID: "1",
+				Details:   "details",
+				Status:    "active",
+				SKU:       "SKU-2",
+				GTIN:      "00012345678905",
+				Options:   map[string]string{"Size": "S", "Color": "Red"},
+
+				PriceCents:   1299,
+				Measurements: &models.Measurements{Volume: &models.Measurement{Value: 500, Unit: "ml"}},
+				Allergens:    []string{"milk", "peanut"},
+			},
+			ExpectedResult: &models.Variant{
+				ID:        "2",
+				Name:      "variant_2",
+				ProductID: "1",
+				Details:   "details",
+				Status:    "active",
+				SKU:       "SKU-2",
+				GTIN:      "00012345678905",
+				Options:   map[string]string{"Size": "S", "Color": "Red"},
+
+				PriceCents:   1299,
+				Measurements: &models.Measurements{Volume: &models.Measurement{Value: 500, Unit: "ml"}},
+				UnitPrice:    &models.UnitPrice{Cents: 2598, Per: "l"},
+				Allergens:    []string{"milk", "peanut"},
+				CreatedAt:    at,
+				CreatedBy:    "u1",
+				UpdatedAt:    at,
+				UpdatedBy:    "u1",
 			},
 			ExpectedErr: nil,
-			MockCall:    mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1)).WillReturnError(nil),
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT").
+					WithArgs("", "2", "1", "variant_2", "details", sql.NullString{String: "SKU-2", Valid: true},
+						sql.NullString{String: "00012345678905", Valid: true}, sql.NullInt64{Int64: 1299, Valid: true}, `{"Color":"Red","Size":"S"}`, `{"Color":"Red","Size":"S"}`,
+						`{"volume":{"value":500,"unit":"ml"}}`, nil, `["milk","peanut"]`, "active").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT .* FROM variants v").WithArgs("2", "1").WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("2", "1", "variant_2", "details", "SKU-2", "00012345678905", 0, 1299, []byte(`{"Color":"Red","Size":"S"}`), "",
+						[]byte(`{"volume":{"value":500,"unit":"ml"}}`), nil, []byte(`["milk","peanut"]`), "active", at, "u1", at, "u1"))
+			},
 		},
 		{
 			Desc: "Failure: DB error",
@@ -118,14 +317,21 @@ func Test_Create(t *testing.T) {
 				Name:      "variant_1",
 				ProductID: "1",
 				Details:   "details",
+				Status:    "active",
 			},
 			ExpectedResult: nil,
 			ExpectedErr:    errors.DB{Err: errors.Error("DB Error")},
-			MockCall:       mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.Error("DB Error")),
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(0, 0)).WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
 		},
 	}
 
 	for i, test := range testcases {
+		test.MockCalls()
+
 		res, err := s.Create(ctx, test.Body)
 
 		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
@@ -133,6 +339,74 @@ func Test_Create(t *testing.T) {
 	}
 }
 
+func Test_CreateAll(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"id", "product_id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options",
+		"tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}
+	body := []*models.Variant{
+		{ID: "1-s", ProductID: "1", Name: "S", Details: "Size: S", Options: map[string]string{"Size": "S"}, Status: "active"},
+		{ID: "1-m", ProductID: "1", Name: "M", Details: "Size: M", Options: map[string]string{"Size": "M"}, Status: "draft"},
+	}
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []models.Variant
+		ExpectedErr    error
+		MockCalls      func()
+	}{
+		{
+			Desc: "Success",
+			ExpectedResult: []models.Variant{
+				{ID: "1-s", ProductID: "1", Name: "S", Details: "Size: S", Options: map[string]string{"Size": "S"},
+					CreatedAt: at, CreatedBy: "u1", UpdatedAt: at, UpdatedBy: "u1"},
+				{ID: "1-m", ProductID: "1", Name: "M", Details: "Size: M", Options: map[string]string{"Size": "M"},
+					CreatedAt: at, CreatedBy: "u1", UpdatedAt: at, UpdatedBy: "u1"},
+			},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO variants").WithArgs("", "1-s", "1", "S", "Size: S", sql.NullString{}, sql.NullString{},
+					sql.NullInt64{}, `{"Size":"S"}`, `{"Size":"S"}`, nil, nil, "[]", "active").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO variants").WithArgs("", "1-m", "1", "M", "Size: M", sql.NullString{}, sql.NullString{},
+					sql.NullInt64{}, `{"Size":"M"}`, `{"Size":"M"}`, nil, nil, "[]", "draft").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery("SELECT .* FROM variants v").WithArgs("1-s", "1").WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1-s", "1", "S", "Size: S", "", "", 0, 0, []byte(`{"Size":"S"}`), "", nil, nil, nil, "", at, "u1", at, "u1"))
+				mock.ExpectQuery("SELECT .* FROM variants v").WithArgs("1-m", "1").WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("1-m", "1", "M", "Size: M", "", "", 0, 0, []byte(`{"Size":"M"}`), "", nil, nil, nil, "", at, "u1", at, "u1"))
+			},
+		},
+		{
+			Desc:        "Failure: second insert rolls back the first",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO variants").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO variants").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		res, err := s.CreateAll(ctx, body)
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_GetVariantData(t *testing.T) {
 	ctx, mock := getSqlMock(t)
 	s := New()
@@ -148,14 +422,22 @@ func Test_GetVariantData(t *testing.T) {
 			Desc: "Success",
 			Pid:  "1",
 			ExpectedResult: []models.VariantInfo{{
-				ID:      "1",
-				Name:    "variant_1",
-				Details: "details",
+				ID:        "1",
+				Name:      "variant_1",
+				Details:   "details",
+				SKU:       "SKU-1",
+				GTIN:      "00012345678905",
+				Available: 5,
+				Status:    "active",
+
+				PriceCents:   349,
+				Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 1, Unit: "lb"}},
+				UnitPrice:    &models.UnitPrice{Cents: 21.81, Per: "oz"},
 			}},
 			ExpectedErr: nil,
 			MockCall: mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(
-				sqlmock.NewRows([]string{"id", "variant_name", "variant_details"}).
-					AddRow("1", "variant_1", "details")),
+				sqlmock.NewRows([]string{"id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags", "measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow("1", "variant_1", "details", "SKU-1", "00012345678905", 5, 349, nil, "", []byte(`{"net_weight":{"value":1,"unit":"lb"}}`), nil, []byte("[]"), "active", time.Time{}, "", time.Time{}, "")),
 		},
 		{
 			Desc:           "Failure: No rows",
@@ -180,3 +462,242 @@ func Test_GetVariantData(t *testing.T) {
 		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
 	}
 }
+
+func Test_GetListedVariants(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags",
+		"measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}
+
+	mock.ExpectQuery("WHERE v.product_id=\\$1 AND v.status IN \\(\\$2,\\$3\\)$").WithArgs("1", "active", "discontinued").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("1", "variant_1", "details", "", "", 0, 299, nil, "", nil, nil, []byte("[]"),
+			"discontinued", time.Time{}, "", time.Time{}, ""))
+
+	res, err := s.GetListedVariants(ctx, "1", []string{"active", "discontinued"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.VariantInfo{{ID: "1", Name: "variant_1", Details: "details", PriceCents: 299, Status: "discontinued"}}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetVariantDataAsOf(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	columns := []string{"id", "variant_name", "variant_details", "sku", "gtin", "available", "price_cents", "options", "tags",
+		"measurements", "nutrition", "allergens", "status", "created_at", "created_by", "updated_at", "updated_by"}
+
+	mock.ExpectQuery("SELECT DISTINCT ON \\(r.variant_id\\) \\(jsonb_populate_record\\(NULL::variants, "+
+		"jsonb_build_object\\('created_at', r.created_at, .*\\) \\|\\| r.snapshot\\)\\).\\* "+
+		"FROM revisions r WHERE r.product_id=\\$1 AND r.variant_id<>'' AND r.created_at <= \\$2").WithArgs("1", at).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("1", "old name", "details", "", "", 0, 299, nil, "", nil, nil, []byte("[]"), "active", at, "u1", at, "u2"))
+	mock.ExpectQuery("FROM revisions").WithArgs("1", at).WillReturnError(errors.Error("DB Error"))
+
+	res, err := s.GetVariantDataAsOf(ctx, "1", at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.VariantInfo{{ID: "1", Name: "old name", Details: "details", PriceCents: 299, Status: "active",
+		CreatedAt: at, CreatedBy: "u1", UpdatedAt: at, UpdatedBy: "u2"}}, res)
+
+	_, err = s.GetVariantDataAsOf(ctx, "1", at)
+
+	assert.Equal(t, errors.DB{Err: errors.Error("DB Error")}, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_SetMeasurements(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc         string
+		Measurements *models.Measurements
+		ExpectedErr  error
+		MockCalls    func()
+	}{
+		{
+			Desc:         "Success",
+			Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 16, Unit: "oz"}},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants SET updated_by=\\$1, measurements=\\$2").
+					WithArgs("", `{"net_weight":{"value":16,"unit":"oz"}}`, "1", "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:         "Success: cleared",
+			Measurements: &models.Measurements{},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants SET updated_by=\\$1, measurements=\\$2").WithArgs("", nil, "1", "1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetMeasurements(ctx, "1", "1", test.Measurements)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_SetNutrition(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	dv := 10
+
+	testcases := []struct {
+		Desc        string
+		Panel       *models.Nutrition
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc: "Success: daily values are not stored",
+			Panel: &models.Nutrition{
+				ServingSize: models.Measurement{Value: 240, Unit: "ml"},
+				Calories:    90,
+				Nutrients:   []models.Nutrient{{Name: "total_fat", Amount: 8, Unit: "g", DailyValue: &dv}},
+			},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants SET updated_by=\\$1, nutrition=\\$2").
+					WithArgs("", `{"serving_size":{"value":240,"unit":"ml"},"calories":90,"nutrients":[{"name":"total_fat","amount":8,"unit":"g"}]}`,
+						"1", "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			Panel:       &models.Nutrition{},
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetNutrition(ctx, "1", "1", test.Panel)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_SetAllergens(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc        string
+		Allergens   []string
+		ExpectedErr error
+		MockCalls   func()
+	}{
+		{
+			Desc:      "Success",
+			Allergens: []string{"milk", "soy"},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants SET updated_by=\\$1, allergens=\\$2").WithArgs("", `["milk","soy"]`, "1", "1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc: "Success: none declared",
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants SET updated_by=\\$1, allergens=\\$2").WithArgs("", "[]", "1", "1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO revisions").WillReturnRows(revision())
+				mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCalls: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE variants").WillReturnError(errors.Error("DB Error"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for i, test := range testcases {
+		test.MockCalls()
+
+		err := s.SetAllergens(ctx, "1", "1", test.Allergens)
+
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_GetOptionKeys(t *testing.T) {
+	ctx, mock := getSqlMock(t)
+	s := New()
+
+	testcases := []struct {
+		Desc           string
+		ExpectedResult []string
+		ExpectedErr    error
+		MockCall       *sqlmock.ExpectedQuery
+	}{
+		{
+			Desc:           "Success",
+			ExpectedResult: []string{`{"Size":"S"}`, `{"Size":"M"}`},
+			MockCall: mock.ExpectQuery("SELECT option_key FROM variants").WithArgs("1").WillReturnRows(
+				sqlmock.NewRows([]string{"option_key"}).AddRow(`{"Size":"S"}`).AddRow(`{"Size":"M"}`)),
+		},
+		{
+			Desc:        "Failure: DB error",
+			ExpectedErr: errors.DB{Err: errors.Error("DB Error")},
+			MockCall:    mock.ExpectQuery("SELECT").WithArgs("1").WillReturnError(errors.Error("DB Error")),
+		},
+	}
+
+	for i, test := range testcases {
+		res, err := s.GetOptionKeys(ctx, "1")
+
+		assert.Equalf(t, test.ExpectedResult, res, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_OptionKey(t *testing.T) {
+	a := OptionKey(map[string]string{"Size": "S", "Color": "Red"})
+	b := OptionKey(map[string]string{"Color": "Red", "Size": "S"})
+
+	assert.Equal(t, `{"Color":"Red","Size":"S"}`, a)
+	assert.Equal(t, a, b)
+}
@@ -0,0 +1,175 @@
+// Package units converts quantities between metric and imperial units of measure.
+package units
+
+import (
+	"errors"
+	"math"
+	"practice-app/models"
+	"strings"
+)
+
+// The kinds of quantity a unit can measure.
+const (
+	Mass   = "mass"
+	Volume = "volume"
+	Length = "length"
+)
+
+var (
+	ErrUnknownUnit  = errors.New("unknown unit")
+	ErrIncompatible = errors.New("units measure different kinds of quantity")
+)
+
+type unit struct {
+	kind string
+	// factor converts a value in this unit to the base unit of its kind: grams, millilitres or millimetres.
+	factor   float64
+	imperial bool
+}
+
+// Volumes are US customary measures.
+var table = map[string]unit{
+	"mcg": {kind: Mass, factor: 0.000001},
+	"mg":  {kind: Mass, factor: 0.001},
+	"g":   {kind: Mass, factor: 1},
+	"kg":  {kind: Mass, factor: 1000},
+	"oz":  {kind: Mass, factor: 28.349523125, imperial: true},
+	"lb":  {kind: Mass, factor: 453.59237, imperial: true},
+
+	"ml":    {kind: Volume, factor: 1},
+	"l":     {kind: Volume, factor: 1000},
+	"fl oz": {kind: Volume, factor: 29.5735295625, imperial: true},
+	"pt":    {kind: Volume, factor: 473.176473, imperial: true},
+	"qt":    {kind: Volume, factor: 946.352946, imperial: true},
+	"gal":   {kind: Volume, factor: 3785.411784, imperial: true},
+
+	"mm": {kind: Length, factor: 1},
+	"cm": {kind: Length, factor: 10},
+	"m":  {kind: Length, factor: 1000},
+	"in": {kind: Length, factor: 25.4, imperial: true},
+	"ft": {kind: Length, factor: 304.8, imperial: true},
+}
+
+// aliases maps other common spellings to the canonical symbol of a unit.
+var aliases = map[string]string{
+	"µg": "mcg", "ug": "mcg", "microgram": "mcg", "micrograms": "mcg",
+	"gram": "g", "grams": "g", "kilogram": "kg", "kilograms": "kg", "milligram": "mg", "milligrams": "mg",
+	"ounce": "oz", "ounces": "oz", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"millilitre": "ml", "milliliter": "ml", "millilitres": "ml", "milliliters": "ml",
+	"litre": "l", "liter": "l", "litres": "l", "liters": "l",
+	"floz": "fl oz", "fl. oz": "fl oz", "fluid ounce": "fl oz", "fluid ounces": "fl oz",
+	"pint": "pt", "pints": "pt", "quart": "qt", "quarts": "qt", "gallon": "gal", "gallons": "gal",
+	"millimetre": "mm", "millimeter": "mm", "millimetres": "mm", "millimeters": "mm",
+	"centimetre": "cm", "centimeter": "cm", "centimetres": "cm", "centimeters": "cm",
+	"metre": "m", "meter": "m", "metres": "m", "meters": "m",
+	"inch": "in", "inches": "in", "foot": "ft", "feet": "ft",
+}
+
+// Normalize returns the canonical symbol of a unit, accepting any case and the common spellings of its name,
+// e.g. "Fluid Ounces" becomes "fl oz".
+func Normalize(symbol string) (string, bool) {
+	symbol = strings.Join(strings.Fields(strings.ToLower(symbol)), " ")
+
+	if alias, ok := aliases[symbol]; ok {
+		symbol = alias
+	}
+
+	if _, ok := table[symbol]; !ok {
+		return "", false
+	}
+
+	return symbol, true
+}
+
+// Kind returns what a unit measures: Mass, Volume or Length.
+func Kind(symbol string) (string, error) {
+	u, err := lookup(symbol)
+	if err != nil {
+		return "", err
+	}
+
+	return u.kind, nil
+}
+
+// IsImperial reports whether a unit belongs to the imperial (US customary) system.
+func IsImperial(symbol string) bool {
+	u, err := lookup(symbol)
+
+	return err == nil && u.imperial
+}
+
+// Convert expresses a value given in one unit in another unit of the same kind, e.g. 1 lb in g.
+func Convert(value float64, from, to string) (float64, error) {
+	f, err := lookup(from)
+	if err != nil {
+		return 0, err
+	}
+
+	t, err := lookup(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if f.kind != t.kind {
+		return 0, ErrIncompatible
+	}
+
+	return value * f.factor / t.factor, nil
+}
+
+// PricingUnit is the unit a unit price is quoted per: ounces or fluid ounces for quantities given in imperial
+// units, and kilograms or litres for metric ones. Lengths are not priced per unit.
+func PricingUnit(symbol string) string {
+	u, err := lookup(symbol)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case u.kind == Mass && u.imperial:
+		return "oz"
+	case u.kind == Mass:
+		return "kg"
+	case u.kind == Volume && u.imperial:
+		return "fl oz"
+	case u.kind == Volume:
+		return "l"
+	}
+
+	return ""
+}
+
+// UnitPrice works out the price per unit of a variant's contents from its net weight, or from its volume when
+// it has no weight. It is nil when the variant has no price or neither quantity. Cents are rounded to two decimals.
+func UnitPrice(priceCents int64, m *models.Measurements) *models.UnitPrice {
+	if priceCents <= 0 || m == nil {
+		return nil
+	}
+
+	quantity := m.NetWeight
+	if quantity == nil {
+		quantity = m.Volume
+	}
+
+	if quantity == nil || quantity.Value <= 0 {
+		return nil
+	}
+
+	per := PricingUnit(quantity.Unit)
+
+	value, err := Convert(quantity.Value, quantity.Unit, per)
+	if err != nil || per == "" {
+		return nil
+	}
+
+	return &models.UnitPrice{Cents: math.Round(float64(priceCents)/value*100) / 100, Per: per}
+}
+
+func lookup(symbol string) (unit, error) {
+	symbol, ok := Normalize(symbol)
+	if !ok {
+		return unit{}, ErrUnknownUnit
+	}
+
+	return table[symbol], nil
+}
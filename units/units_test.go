@@ -0,0 +1,122 @@
+package units
+
+import (
+	"github.com/stretchr/testify/assert"
+	"practice-app/models"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	testcases := []struct {
+		Unit     string
+		Expected string
+		OK       bool
+	}{
+		{"oz", "oz", true},
+		{"Fluid  Ounces", "fl oz", true},
+		{"FL OZ", "fl oz", true},
+		{"lbs", "lb", true},
+		{"µg", "mcg", true},
+		{"Litres", "l", true},
+		{"stone", "", false},
+	}
+
+	for i, test := range testcases {
+		res, ok := Normalize(test.Unit)
+
+		assert.Equalf(t, test.Expected, res, "TEST[%v] FAILED - %s", i, test.Unit)
+		assert.Equalf(t, test.OK, ok, "TEST[%v] FAILED - %s", i, test.Unit)
+	}
+}
+
+func Test_Convert(t *testing.T) {
+	testcases := []struct {
+		Desc        string
+		Value       float64
+		From, To    string
+		Expected    float64
+		ExpectedErr error
+	}{
+		{Desc: "pounds to grams", Value: 1, From: "lb", To: "g", Expected: 453.59237},
+		{Desc: "grams to ounces", Value: 453.59237, From: "g", To: "oz", Expected: 16},
+		{Desc: "gallons to litres", Value: 1, From: "gal", To: "l", Expected: 3.785411784},
+		{Desc: "inches to centimetres", Value: 10, From: "in", To: "cm", Expected: 25.4},
+		{Desc: "same unit", Value: 2.5, From: "kg", To: "kg", Expected: 2.5},
+		{Desc: "mass to volume", Value: 1, From: "kg", To: "l", ExpectedErr: ErrIncompatible},
+		{Desc: "unknown unit", Value: 1, From: "stone", To: "kg", ExpectedErr: ErrUnknownUnit},
+	}
+
+	for i, test := range testcases {
+		res, err := Convert(test.Value, test.From, test.To)
+
+		assert.InDeltaf(t, test.Expected, res, 1e-9, "TEST[%v] FAILED - %s", i, test.Desc)
+		assert.Equalf(t, test.ExpectedErr, err, "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
+
+func Test_Kind(t *testing.T) {
+	kind, err := Kind("Gallons")
+
+	assert.Equal(t, Volume, kind)
+	assert.NoError(t, err)
+
+	_, err = Kind("cup")
+
+	assert.Equal(t, ErrUnknownUnit, err)
+	assert.True(t, IsImperial("ft"))
+	assert.False(t, IsImperial("m"))
+}
+
+func Test_UnitPrice(t *testing.T) {
+	testcases := []struct {
+		Desc         string
+		PriceCents   int64
+		Measurements *models.Measurements
+		Expected     *models.UnitPrice
+	}{
+		{
+			Desc:         "per ounce",
+			PriceCents:   349,
+			Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 1, Unit: "lb"}},
+			Expected:     &models.UnitPrice{Cents: 21.81, Per: "oz"},
+		},
+		{
+			Desc:         "per kilogram",
+			PriceCents:   250,
+			Measurements: &models.Measurements{NetWeight: &models.Measurement{Value: 500, Unit: "g"}},
+			Expected:     &models.UnitPrice{Cents: 500, Per: "kg"},
+		},
+		{
+			Desc:       "weight wins over volume",
+			PriceCents: 400,
+			Measurements: &models.Measurements{
+				NetWeight: &models.Measurement{Value: 8, Unit: "oz"},
+				Volume:    &models.Measurement{Value: 1, Unit: "l"},
+			},
+			Expected: &models.UnitPrice{Cents: 50, Per: "oz"},
+		},
+		{
+			Desc:         "per fluid ounce",
+			PriceCents:   399,
+			Measurements: &models.Measurements{Volume: &models.Measurement{Value: 1, Unit: "gal"}},
+			Expected:     &models.UnitPrice{Cents: 3.12, Per: "fl oz"},
+		},
+		{
+			Desc:         "no price",
+			Measurements: &models.Measurements{Volume: &models.Measurement{Value: 1, Unit: "l"}},
+		},
+		{
+			Desc:         "only dimensions",
+			PriceCents:   399,
+			Measurements: &models.Measurements{Length: &models.Measurement{Value: 10, Unit: "cm"}},
+		},
+		{
+			Desc:       "no measurements",
+			PriceCents: 399,
+		},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.Expected, UnitPrice(test.PriceCents, test.Measurements), "TEST[%v] FAILED - %s", i, test.Desc)
+	}
+}
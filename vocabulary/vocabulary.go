@@ -0,0 +1,34 @@
+// Package vocabulary normalizes the tags of products and variants and reads them back from the database.
+package vocabulary
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the longest a normalized tag may be.
+const MaxLength = 64
+
+// Normalize reduces a tag to its vocabulary form: lower case, with every run of characters that are not
+// letters or digits collapsed to a hyphen, e.g. "Gluten Free!" becomes "gluten-free".
+func Normalize(tag string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// Split turns the comma separated tags read by Aggregate back into a list.
+func Split(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
+
+// Aggregate selects the tags of a variant, or of a product when variantID is an empty string literal, as one
+// comma separated, sorted string. Normalized tags never contain commas.
+func Aggregate(productID, variantID string) string {
+	return "COALESCE((SELECT string_agg(t.tag, ',' ORDER BY t.tag) FROM product_tags t WHERE t.product_id = " +
+		productID + " AND t.variant_id = " + variantID + "), '')"
+}
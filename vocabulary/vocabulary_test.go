@@ -0,0 +1,38 @@
+package vocabulary
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Normalize(t *testing.T) {
+	testcases := []struct {
+		Tag      string
+		Expected string
+	}{
+		{"organic", "organic"},
+		{"  Gluten Free! ", "gluten-free"},
+		{"New_Arrival", "new-arrival"},
+		{"Café", "café"},
+		{"***", ""},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.Expected, Normalize(test.Tag), "TEST[%v] FAILED - %s", i, test.Tag)
+	}
+}
+
+func Test_Split(t *testing.T) {
+	testcases := []struct {
+		Tags     string
+		Expected []string
+	}{
+		{"", nil},
+		{"organic", []string{"organic"}},
+		{"gluten-free,organic", []string{"gluten-free", "organic"}},
+	}
+
+	for i, test := range testcases {
+		assert.Equalf(t, test.Expected, Split(test.Tags), "TEST[%v] FAILED - %s", i, test.Tags)
+	}
+}